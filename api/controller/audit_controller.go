@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditController struct {
+	AuditUsecase domain.AuditUsecase
+	Env          *bootstrap.Env
+}
+
+// FetchAudit lista a trilha de auditoria
+// @Summary Fetch Audit Trail
+// @Description Lists the audit trail (logins and mutations with their before/after changes) of the caller's organization, platform admins see every organization. Pages are ordered by ID, pass next_cursor as cursor to get the next one.
+// @Tags Audit
+// @Produce json
+// @Param user_id query int false "Acting user ID"
+// @Param organization_id query int false "Organization ID of the actor"
+// @Param action query string false "Action (e.g. login, user.update)"
+// @Param entity_type query string false "Entity type (e.g. user, service)"
+// @Param entity_id query string false "Entity ID"
+// @Param ip query string false "IP address"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day, inclusive (YYYY-MM-DD)"
+// @Param sort query string false "Sort" Enums(asc, desc) default(desc)
+// @Param limit query int false "Page size (max 200)" default(50)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} domain.SuccessResponse{data=domain.AuditPage}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /audit [get]
+func (ac *AuditController) FetchAudit(c *gin.Context) {
+	filter := domain.AuditFilter{
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+		IPAddress:  c.Query("ip"),
+	}
+
+	var ok bool
+	if filter.From, filter.To, ok = parseDateRange(c); !ok {
+		return
+	}
+	if !parseUintQueries(c, map[string]*uint{
+		"user_id":         &filter.UserID,
+		"organization_id": &filter.OrganizationID,
+	}) {
+		return
+	}
+
+	switch c.DefaultQuery("sort", "desc") {
+	case "desc":
+		filter.Descending = true
+	case "asc":
+	default:
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: domain.ErrInvalidAuditQuery.Error()})
+		return
+	}
+	if limit := c.Query("limit"); limit != "" {
+		var err error
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: domain.ErrInvalidAuditQuery.Error()})
+			return
+		}
+	}
+	if cursor := c.Query("cursor"); cursor != "" {
+		var err error
+		if filter.Cursor, err = domain.DecodeAuditCursor(cursor); err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
+
+	page, err := ac.AuditUsecase.Fetch(c, filter)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidAuditQuery:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(page))
+}
+
+// VerifyAudit confere a integridade da trilha de auditoria
+// @Summary Verify Audit Trail
+// @Description Walks the hash chain of the audit trail (logins and mutations) and reports the first row that was modified, removed or reordered. Store head_hash outside the database to also detect the removal of the latest rows.
+// @Tags Audit
+// @Produce json
+// @Success 200 {object} domain.SuccessResponse{data=domain.AuditVerification}
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /audit/verify [get]
+func (ac *AuditController) VerifyAudit(c *gin.Context) {
+	verification, err := ac.AuditUsecase.Verify(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(verification))
+}
@@ -1,8 +1,10 @@
 package controller
 
 import (
-	"log"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gabrielfmcoelho/platform-core/bootstrap"
 	"github.com/gabrielfmcoelho/platform-core/domain"
@@ -27,6 +29,7 @@ type AuthController struct {
 // @Failure 400 {object} domain.ErrorResponse "Bad Request - Invalid input"
 // @Failure 401 {object} domain.ErrorResponse "Unauthorized - Incorrect email or password"
 // @Failure 404 {object} domain.ErrorResponse "Not Found - User not found"
+// @Failure 429 {object} domain.ErrorResponse "Too Many Requests - Rate limited or account locked, see Retry-After"
 // @Failure 500 {object} domain.ErrorResponse "Internal Server Error"
 // @Router /login [post]
 func (lc *AuthController) Login(c *gin.Context) {
@@ -43,6 +46,7 @@ func (lc *AuthController) Login(c *gin.Context) {
 		c,
 		request.Email,
 		request.Password,
+		c.ClientIP(),
 		lc.Env.AccessTokenSecret,
 		lc.Env.AccessTokenExpiryHour,
 		lc.Env.RefreshTokenSecret,
@@ -50,6 +54,12 @@ func (lc *AuthController) Login(c *gin.Context) {
 	)
 
 	if err != nil {
+		var locked *domain.AccountLockedError
+		if errors.As(err, &locked) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, domain.ErrorResponse{Message: err.Error()})
+			return
+		}
 		switch err {
 		case domain.ErrUserEmailNotFound:
 			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
@@ -72,20 +82,26 @@ func (lc *AuthController) Login(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} domain.SuccessResponse{data=domain.LoginResponse} "Successful login, returns access and refresh tokens"
+// @Failure 503 {object} domain.ErrorResponse "No user has the Guest role"
+// @Failure 429 {object} domain.ErrorResponse "Too Many Requests - Rate limited, see Retry-After"
 // @Failure 500 {object} domain.ErrorResponse "Internal Server Error"
 // @Router /login-guest [post]
 func (lc *AuthController) LoginGuest(c *gin.Context) {
-	log.Println("Requesting IP")
-
 	loginResponse, err := lc.AuthUsecase.LoginGuestUser(
 		c,
+		c.ClientIP(),
 		lc.Env.AccessTokenSecret,
 		lc.Env.AccessTokenExpiryHour,
 		lc.Env.RefreshTokenSecret,
 		lc.Env.RefreshTokenExpiryHour,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		switch err {
+		case domain.ErrGuestLoginUnavailable:
+			c.JSON(http.StatusServiceUnavailable, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
 		return
 	}
 
@@ -93,44 +109,114 @@ func (lc *AuthController) LoginGuest(c *gin.Context) {
 }
 
 // @Summary Forgot Password
-// @Description Sends an email to the user with a link to reset their password
+// @Description Sends an email to the user with a single-use link to reset their password. The response is the same whether or not the email is registered.
 // @Tags Auth User
 // @ID forgotPassword
 // @Accept json
 // @Produce json
-// @Param email query string true "User email"
-// @Success 200 {object} domain.SuccessResponse "Email sent successfully"
+// @Param forgotPasswordRequest body domain.ForgotPasswordRequest true "Forgot Password Request"
+// @Success 200 {object} domain.SuccessResponse "Reset link sent if the email is registered"
 // @Failure 400 {object} domain.ErrorResponse "Bad Request - Invalid input"
-// @Failure 404 {object} domain.ErrorResponse "Not Found - User not found"
+// @Failure 429 {object} domain.ErrorResponse "Too Many Requests - Rate limited, see Retry-After"
 // @Failure 500 {object} domain.ErrorResponse "Internal Server Error"
 // @Router /forgot-password [post]
-func (lc *AuthController) ForgotPassword(c *gin.Context) {}
+func (lc *AuthController) ForgotPassword(c *gin.Context) {
+	var request domain.ForgotPasswordRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = lc.AuthUsecase.ForgotPassword(
+		c,
+		request.Email,
+		lc.Env.PasswordResetURL,
+		lc.Env.PasswordResetExpiryMin,
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "If the email is registered, a reset link has been sent."})
+}
 
 // @Summary Reset Password
-// @Description Resets the user's password
+// @Description Resets the user's password using the token received by email. The token can only be used once and every active session of the user is revoked.
 // @Tags Auth User
 // @ID resetPassword
 // @Accept json
 // @Produce json
-// @Param email query string true "User email"
-// @Param newPassword query string true "New password"
+// @Param resetPasswordRequest body domain.ResetPasswordRequest true "Reset Password Request"
 // @Success 200 {object} domain.SuccessResponse "Password reset successfully"
-// @Failure 400 {object} domain.ErrorResponse "Bad Request - Invalid input"
-// @Failure 404 {object} domain.ErrorResponse "Not Found - User not found"
+// @Failure 400 {object} domain.ErrorResponse "Bad Request - Invalid input or invalid, expired or used token"
+// @Failure 429 {object} domain.ErrorResponse "Too Many Requests - Rate limited, see Retry-After"
 // @Failure 500 {object} domain.ErrorResponse "Internal Server Error"
 // @Router /reset-password [post]
-func (lc *AuthController) ResetPassword(c *gin.Context) {}
+func (lc *AuthController) ResetPassword(c *gin.Context) {
+	var request domain.ResetPasswordRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = lc.AuthUsecase.ResetPassword(c, request.Token, request.NewPassword)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidResetToken:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Password reset successfully."})
+}
 
 // @Summary Refresh Token
-// @Description Refreshes the user's access token
+// @Description Rotates the refresh token and returns a new access and refresh token pair. Presenting a refresh token that was already rotated revokes every token of its login session.
 // @Tags Auth User
 // @ID refreshToken
 // @Accept json
 // @Produce json
-// @Param refreshToken query string true "Refresh token"
+// @Param refreshTokenRequest body domain.RefreshTokenRequest true "Refresh Token Request"
 // @Success 200 {object} domain.RefreshTokenResponse "Access token refreshed successfully"
 // @Failure 400 {object} domain.ErrorResponse "Bad Request - Invalid input"
-// @Failure 401 {object} domain.ErrorResponse "Unauthorized - Invalid refresh token"
+// @Failure 401 {object} domain.ErrorResponse "Unauthorized - Invalid, expired or reused refresh token"
 // @Failure 500 {object} domain.ErrorResponse "Internal Server Error"
 // @Router /refresh-token [post]
-func (lc *AuthController) RefreshToken(c *gin.Context) {}
+func (lc *AuthController) RefreshToken(c *gin.Context) {
+	var request domain.RefreshTokenRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	refreshTokenResponse, err := lc.AuthUsecase.RefreshToken(
+		c,
+		request.RefreshToken,
+		lc.Env.AccessTokenSecret,
+		lc.Env.AccessTokenExpiryHour,
+		lc.Env.RefreshTokenSecret,
+		lc.Env.RefreshTokenExpiryHour,
+	)
+
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidRefreshToken, domain.ErrRefreshTokenReused:
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, refreshTokenResponse)
+}
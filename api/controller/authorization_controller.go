@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type AuthorizationController struct {
+	AuthorizationUsecase domain.AuthorizationUsecase
+	Env                  *bootstrap.Env
+}
+
+// FetchPermissions retorna todas as permissões
+// @Summary Fetch Permissions
+// @Description Gets every permission that can be granted to user and organization roles
+// @Tags Authorization
+// @Produce json
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicPermission}
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /permissions [get]
+func (ac *AuthorizationController) FetchPermissions(c *gin.Context) {
+	permissions, err := ac.AuthorizationUsecase.FetchPermissions(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(permissions))
+}
+
+// FetchUserRoles retorna os UserRoles e suas permissões
+// @Summary Fetch User Roles
+// @Description Gets the user roles (Admin, Manager, User, Guest) with their permissions
+// @Tags Authorization
+// @Produce json
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicRole}
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /roles/user [get]
+func (ac *AuthorizationController) FetchUserRoles(c *gin.Context) {
+	roles, err := ac.AuthorizationUsecase.FetchUserRoles(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(roles))
+}
+
+// FetchOrganizationRoles retorna os OrganizationRoles e suas permissões
+// @Summary Fetch Organization Roles
+// @Description Gets the organization roles (Admin, Hospital, Guest) with their permissions
+// @Tags Authorization
+// @Produce json
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicRole}
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /roles/organization [get]
+func (ac *AuthorizationController) FetchOrganizationRoles(c *gin.Context) {
+	roles, err := ac.AuthorizationUsecase.FetchOrganizationRoles(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(roles))
+}
+
+// SetUserRolePermissions substitui as permissões de um UserRole
+// @Summary Set User Role Permissions
+// @Description Replaces the permissions of a user role. Takes effect on the next request of its users.
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param roleID path int true "User Role ID"
+// @Param permissions body domain.SetRolePermissions true "Permission names"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicRole}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /roles/user/{roleID}/permissions [put]
+func (ac *AuthorizationController) SetUserRolePermissions(c *gin.Context) {
+	roleID, err := internal.ParseUint(c.Param("roleID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid roleID"})
+		return
+	}
+
+	var request domain.SetRolePermissions
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	role, err := ac.AuthorizationUsecase.SetUserRolePermissions(c, roleID, request.Permissions)
+	if err != nil {
+		switch err {
+		case domain.ErrUnknownPermission:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(role))
+}
+
+// SetOrganizationRolePermissions substitui as permissões de um OrganizationRole
+// @Summary Set Organization Role Permissions
+// @Description Replaces the permissions of an organization role. They cap the permissions of every user of organizations with this role.
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param roleID path int true "Organization Role ID"
+// @Param permissions body domain.SetRolePermissions true "Permission names"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicRole}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /roles/organization/{roleID}/permissions [put]
+func (ac *AuthorizationController) SetOrganizationRolePermissions(c *gin.Context) {
+	roleID, err := internal.ParseUint(c.Param("roleID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid roleID"})
+		return
+	}
+
+	var request domain.SetRolePermissions
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	role, err := ac.AuthorizationUsecase.SetOrganizationRolePermissions(c, roleID, request.Permissions)
+	if err != nil {
+		switch err {
+		case domain.ErrUnknownPermission:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(role))
+}
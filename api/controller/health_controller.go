@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController struct {
+	HealthUsecase domain.HealthUsecase
+}
+
+// Liveness informa que o processo está atendendo requisições
+// @Summary Liveness
+// @Description Answers 200 while the process serves requests, the orchestrator restarts the instance when it stops answering
+// @Tags Health
+// @Produce json
+// @Success 200 {object} domain.Readiness
+// @Router /healthz [get]
+func (hc *HealthController) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, domain.Readiness{Status: domain.HealthStatusOK, Checks: map[string]domain.HealthCheck{}})
+}
+
+// Readiness informa se a instância pode receber tráfego
+// @Summary Readiness
+// @Description Answers 200 when the database responds and has every migration of the binary, and 503 otherwise or once the instance is shutting down
+// @Tags Health
+// @Produce json
+// @Success 200 {object} domain.Readiness
+// @Failure 503 {object} domain.Readiness
+// @Router /readyz [get]
+func (hc *HealthController) Readiness(c *gin.Context) {
+	readiness := hc.HealthUsecase.Readiness(c)
+	if !readiness.Ready() {
+		c.JSON(http.StatusServiceUnavailable, readiness)
+		return
+	}
+	c.JSON(http.StatusOK, readiness)
+}
@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type InvitationController struct {
+	InvitationUsecase domain.InvitationUsecase
+	Env               *bootstrap.Env
+}
+
+// CreateInvitation convida um email para a organização
+// @Summary Invite User
+// @Description Invites an email to the organization with the chosen user role and emails the sign-up link. The role can't grant permissions the inviter doesn't have and the organization must be under the users limit of its subscription.
+// @Tags Invitation
+// @Accept json
+// @Produce json
+// @Param identifier path int true "Organization ID"
+// @Param invitation body domain.CreateInvitation true "Invitation data"
+// @Success 201 {object} domain.SuccessResponse{data=domain.PublicInvitation}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier}/invitations [post]
+func (ic *InvitationController) CreateInvitation(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	var request domain.CreateInvitation
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	invitation, err := ic.InvitationUsecase.Create(c, organizationID, &request, ic.Env.InvitationURL, ic.Env.InvitationExpiryHour)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidUserRole:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrForbidden, domain.ErrOutOfOrganization, domain.ErrSubscriptionUsersLimit:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrUserAlreadyExists, domain.ErrInvitationExists:
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, parser.ToSuccessResponse(invitation))
+}
+
+// FetchPendingInvitations retorna os convites pendentes da organização
+// @Summary Fetch Pending Invitations
+// @Description Gets the invitations of the organization that were not accepted, revoked or expired yet
+// @Tags Invitation
+// @Produce json
+// @Param identifier path int true "Organization ID"
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicInvitation}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier}/invitations [get]
+func (ic *InvitationController) FetchPendingInvitations(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	invitations, err := ic.InvitationUsecase.FetchPending(c, organizationID)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(invitations))
+}
+
+// ResendInvitation reenvia o convite com um novo link
+// @Summary Resend Invitation
+// @Description Emails a new sign-up link (the previous one stops working) and renews the expiration, expired invitations can be resent too
+// @Tags Invitation
+// @Produce json
+// @Param invitationID path int true "Invitation ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicInvitation}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /invitations/{invitationID}/resend [post]
+func (ic *InvitationController) ResendInvitation(c *gin.Context) {
+	invitationID, err := internal.ParseUint(c.Param("invitationID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid invitationID"})
+		return
+	}
+
+	invitation, err := ic.InvitationUsecase.Resend(c, invitationID, ic.Env.InvitationURL, ic.Env.InvitationExpiryHour)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidInvitation:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(invitation))
+}
+
+// RevokeInvitation cancela um convite
+// @Summary Revoke Invitation
+// @Description Revokes an invitation that was not accepted yet, its link stops working
+// @Tags Invitation
+// @Produce json
+// @Param invitationID path int true "Invitation ID"
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /invitations/{invitationID} [delete]
+func (ic *InvitationController) RevokeInvitation(c *gin.Context) {
+	invitationID, err := internal.ParseUint(c.Param("invitationID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid invitationID"})
+		return
+	}
+
+	if err := ic.InvitationUsecase.Revoke(c, invitationID); err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Invitation revoked successfully."})
+}
+
+// AcceptInvitation conclui o cadastro a partir do link do convite
+// @Summary Accept Invitation
+// @Description Creates the user (with bio, metrics and config) in the organization and role of the invitation. The token can only be used once.
+// @Tags Invitation
+// @Accept json
+// @Produce json
+// @Param acceptInvitationRequest body domain.AcceptInvitationRequest true "Accept Invitation Request"
+// @Success 201 {object} domain.SuccessResponse{data=domain.PublicUser}
+// @Failure 400 {object} domain.ErrorResponse "Bad Request - Invalid input or invalid, expired or revoked invitation"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden - The organization reached the users limit of its subscription"
+// @Failure 409 {object} domain.ErrorResponse "Conflict - The email is already registered"
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /accept-invitation [post]
+func (ic *InvitationController) AcceptInvitation(c *gin.Context) {
+	var request domain.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, err := ic.InvitationUsecase.Accept(c, &request)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidInvitation:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrSubscriptionUsersLimit:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrUserAlreadyExists:
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, parser.ToSuccessResponse(user))
+}
@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type InvoiceController struct {
+	InvoiceUsecase domain.InvoiceUsecase
+	Env            *bootstrap.Env
+}
+
+// GenerateInvoices gera os rascunhos das faturas de um mês
+// @Summary Generate Invoices
+// @Description Generates the draft invoices of a month for one organization or for every organization with subscribed services. Each subscribed service becomes a line item with its flat price plus, when the service has an hourly price, the usage logged in the month. Drafts are recomputed, issued invoices are kept (or rejected with 409 when a single organization is requested).
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param request body domain.GenerateInvoices true "Billing period"
+// @Success 201 {object} domain.SuccessResponse{data=[]domain.PublicInvoice}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /invoices/generate [post]
+func (ic *InvoiceController) GenerateInvoices(c *gin.Context) {
+	var request domain.GenerateInvoices
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	invoices, err := ic.InvoiceUsecase.Generate(c, &request)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidBillingPeriod:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrOutOfOrganization:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrInvoiceExists:
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, parser.ToSuccessResponse(invoices))
+}
+
+// FetchInvoices retorna as faturas
+// @Summary Fetch Invoices
+// @Description Gets the invoices visible to the caller, newest period first
+// @Tags Invoice
+// @Produce json
+// @Param organization_id query int false "Organization ID"
+// @Param status query string false "Status" Enums(draft, issued, paid, void)
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicInvoice}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /invoices [get]
+func (ic *InvoiceController) FetchInvoices(c *gin.Context) {
+	var filter domain.InvoiceFilter
+	if organizationID := c.Query("organization_id"); organizationID != "" {
+		id, err := internal.ParseUint(organizationID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organization_id"})
+			return
+		}
+		filter.OrganizationID = id
+	}
+	filter.Status = c.Query("status")
+
+	invoices, err := ic.InvoiceUsecase.Fetch(c, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(invoices))
+}
+
+// GetInvoice retorna uma fatura
+// @Summary Get Invoice
+// @Description Gets an invoice with its line items
+// @Tags Invoice
+// @Produce json
+// @Param invoiceID path int true "Invoice ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicInvoice}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /invoices/{invoiceID} [get]
+func (ic *InvoiceController) GetInvoice(c *gin.Context) {
+	invoiceID, err := internal.ParseUint(c.Param("invoiceID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid invoiceID"})
+		return
+	}
+
+	invoice, err := ic.InvoiceUsecase.GetByID(c, invoiceID)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(invoice))
+}
+
+// RenderInvoice retorna a fatura em HTML ou PDF
+// @Summary Render Invoice
+// @Description Renders the invoice as an HTML page or a PDF document, generated by the API itself
+// @Tags Invoice
+// @Produce html
+// @Produce application/pdf
+// @Param invoiceID path int true "Invoice ID"
+// @Param format query string false "Format" Enums(html, pdf) default(html)
+// @Success 200 {file} file
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /invoices/{invoiceID}/render [get]
+func (ic *InvoiceController) RenderInvoice(c *gin.Context) {
+	invoiceID, err := internal.ParseUint(c.Param("invoiceID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid invoiceID"})
+		return
+	}
+	format := c.DefaultQuery("format", domain.InvoiceFormatHTML)
+
+	document, err := ic.InvoiceUsecase.Render(c, invoiceID, format)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidInvoiceFormat:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+
+	if format == domain.InvoiceFormatPDF {
+		c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"invoice-%d.pdf\"", invoiceID))
+		c.Data(http.StatusOK, "application/pdf", document)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", document)
+}
+
+// IssueInvoice emite um rascunho
+// @Summary Issue Invoice
+// @Description Issues a draft invoice, it gets a number and is no longer recomputed
+// @Tags Invoice
+// @Produce json
+// @Param invoiceID path int true "Invoice ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicInvoice}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /invoices/{invoiceID}/issue [post]
+func (ic *InvoiceController) IssueInvoice(c *gin.Context) {
+	ic.changeStatus(c, ic.InvoiceUsecase.Issue)
+}
+
+// PayInvoice registra o pagamento de uma fatura
+// @Summary Pay Invoice
+// @Description Marks an issued invoice as paid
+// @Tags Invoice
+// @Produce json
+// @Param invoiceID path int true "Invoice ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicInvoice}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /invoices/{invoiceID}/pay [post]
+func (ic *InvoiceController) PayInvoice(c *gin.Context) {
+	ic.changeStatus(c, ic.InvoiceUsecase.Pay)
+}
+
+// VoidInvoice cancela uma fatura
+// @Summary Void Invoice
+// @Description Voids a draft or issued invoice, the period can then be generated again
+// @Tags Invoice
+// @Produce json
+// @Param invoiceID path int true "Invoice ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicInvoice}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /invoices/{invoiceID}/void [post]
+func (ic *InvoiceController) VoidInvoice(c *gin.Context) {
+	ic.changeStatus(c, ic.InvoiceUsecase.Void)
+}
+
+// changeStatus trata as transições de status, que compartilham parâmetros e respostas
+func (ic *InvoiceController) changeStatus(c *gin.Context, transition func(ctx context.Context, id uint) (domain.PublicInvoice, error)) {
+	invoiceID, err := internal.ParseUint(c.Param("invoiceID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid invoiceID"})
+		return
+	}
+
+	invoice, err := transition(c, invoiceID)
+	if err != nil {
+		switch err {
+		case domain.ErrOutOfOrganization:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrInvalidInvoiceStatus:
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(invoice))
+}
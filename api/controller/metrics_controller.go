@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type MetricsController struct {
+	UserMetricsUsecase         domain.UserMetricsUsecase
+	OrganizationMetricsUsecase domain.OrganizationMetricsUsecase
+	Env                        *bootstrap.Env
+}
+
+// GetOrganizationMetrics retorna as métricas de uma organização
+// @Summary Get Organization Metrics
+// @Description Gets the metrics of an organization (members and subscribed services), rolled up hourly by the metrics job. updated_at tells when they were last computed.
+// @Tags Metrics
+// @Produce json
+// @Param identifier path int true "Organization ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicOrganizationMetrics}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier}/metrics [get]
+func (mc *MetricsController) GetOrganizationMetrics(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	metrics, err := mc.OrganizationMetricsUsecase.GetByOrganizationID(c, organizationID)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(metrics))
+}
+
+// GetUserMetrics retorna as métricas de um usuário
+// @Summary Get User Metrics
+// @Description Gets the metrics of a user (logins, last IP and service usage), rolled up hourly by the metrics job. updated_at tells when they were last computed.
+// @Tags Metrics
+// @Produce json
+// @Param identifier path int true "User ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicUserMetrics}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /user/{identifier}/metrics [get]
+func (mc *MetricsController) GetUserMetrics(c *gin.Context) {
+	userID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid userID"})
+		return
+	}
+
+	metrics, err := mc.UserMetricsUsecase.GetByUserID(c, userID)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(metrics))
+}
@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationController struct {
+	OrganizationUsecase domain.OrganizationUsecase
+	Env                 *bootstrap.Env
+}
+
+// CreateOrganization cria uma nova organização
+// @Summary Create Organization
+// @Description Creates a new organization (tenant) with the given organization role
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param organization body domain.CreateOrganization true "Organization data"
+// @Success 201 {object} domain.SuccessResponse{data=domain.PublicOrganization}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations [post]
+func (oc *OrganizationController) CreateOrganization(c *gin.Context) {
+	var request domain.CreateOrganization
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	organization, err := oc.OrganizationUsecase.Create(c, &request)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidOrganization:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrOutOfOrganization:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrOrganizationExists:
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, parser.ToSuccessResponse(organization))
+}
+
+// FetchOrganizations retorna todas as organizações
+// @Summary Fetch Organizations
+// @Description Gets all organizations visible to the caller
+// @Tags Organization
+// @Produce json
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicOrganization}
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations [get]
+func (oc *OrganizationController) FetchOrganizations(c *gin.Context) {
+	organizations, err := oc.OrganizationUsecase.Fetch(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(organizations))
+}
+
+// GetOrganizationByIdentifier retorna uma organização por ID ou nome
+// @Summary Get Organization by Identifier
+// @Description Gets organization by numeric ID (e.g., /organizations/1) or name (/organizations/Solude)
+// @Tags Organization
+// @Produce json
+// @Param identifier path string true "Organization ID or Name"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicOrganization}
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier} [get]
+func (oc *OrganizationController) GetOrganizationByIdentifier(c *gin.Context) {
+	organization, err := oc.OrganizationUsecase.GetByIdentifier(c, c.Param("identifier"))
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(organization))
+}
+
+// GetOrganizationUsers retorna os membros de uma organização
+// @Summary Get Organization Users
+// @Description Gets the users (members) of an organization
+// @Tags Organization
+// @Produce json
+// @Param identifier path int true "Organization ID"
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicUser}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier}/users [get]
+func (oc *OrganizationController) GetOrganizationUsers(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	users, err := oc.OrganizationUsecase.GetUsers(c, organizationID)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(users))
+}
+
+// GetOrganizationServices retorna os serviços disponíveis para uma organização
+// @Summary Get Organization Subscribed Services
+// @Description Gets the services an organization is subscribed to
+// @Tags Organization
+// @Produce json
+// @Param identifier path int true "Organization ID"
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicService}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier}/services [get]
+func (oc *OrganizationController) GetOrganizationServices(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	services, err := oc.OrganizationUsecase.GetSubscribedServices(c, organizationID)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(services))
+}
+
+// UpdateOrganization atualiza nome, apelido e logo de uma organização
+// @Summary Update Organization
+// @Description Updates name, nickname and logo of an organization, only the fields sent are changed
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param organizationID path int true "Organization ID"
+// @Param organization body domain.UpdateOrganization true "Organization data"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicOrganization}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{organizationID} [put]
+func (oc *OrganizationController) UpdateOrganization(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("organizationID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	var request domain.UpdateOrganization
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	organization, err := oc.OrganizationUsecase.Update(c, organizationID, &request)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrOrganizationExists:
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(organization))
+}
+
+// DeleteOrganization arquiva uma organização
+// @Summary Delete Organization
+// @Description Archives (soft deletes) an organization
+// @Tags Organization
+// @Produce json
+// @Param organizationID path int true "Organization ID"
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{organizationID} [delete]
+func (oc *OrganizationController) DeleteOrganization(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("organizationID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	if err := oc.OrganizationUsecase.Delete(c, organizationID); err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Organization deleted successfully."})
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationSubscriptionController struct {
+	OrganizationSubscriptionUsecase domain.OrganizationSubscriptionUsecase
+	Env                             *bootstrap.Env
+}
+
+// CreateSubscription cria a assinatura de uma organização
+// @Summary Create Subscription
+// @Description Creates the subscription of an organization, it ends one period after init_date (defaults to now). Each organization has a single subscription that is later renewed or cancelled.
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param identifier path int true "Organization ID"
+// @Param subscription body domain.CreateSubscription true "Subscription data"
+// @Success 201 {object} domain.SuccessResponse{data=domain.PublicSubscription}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier}/subscription [post]
+func (sc *OrganizationSubscriptionController) CreateSubscription(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	var request domain.CreateSubscription
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	subscription, err := sc.OrganizationSubscriptionUsecase.Create(c, organizationID, &request)
+	if err != nil {
+		switch err {
+		case domain.ErrOutOfOrganization:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrSubscriptionExists:
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, parser.ToSuccessResponse(subscription))
+}
+
+// FetchSubscriptions retorna as assinaturas
+// @Summary Fetch Subscriptions
+// @Description Gets the subscriptions visible to the caller
+// @Tags Subscription
+// @Produce json
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicSubscription}
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /subscriptions [get]
+func (sc *OrganizationSubscriptionController) FetchSubscriptions(c *gin.Context) {
+	subscriptions, err := sc.OrganizationSubscriptionUsecase.Fetch(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(subscriptions))
+}
+
+// GetSubscription retorna a assinatura de uma organização
+// @Summary Get Organization Subscription
+// @Description Gets the subscription of an organization
+// @Tags Subscription
+// @Produce json
+// @Param identifier path int true "Organization ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicSubscription}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier}/subscription [get]
+func (sc *OrganizationSubscriptionController) GetSubscription(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	subscription, err := sc.OrganizationSubscriptionUsecase.GetByOrganizationID(c, organizationID)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(subscription))
+}
+
+// RenewSubscription renova a assinatura de uma organização
+// @Summary Renew Subscription
+// @Description Extends the subscription by one period and reactivates it. A running subscription is extended from its end date, an expired or cancelled one starts over now. The terms sent replace the current ones.
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param identifier path int true "Organization ID"
+// @Param subscription body domain.RenewSubscription true "New terms (all optional)"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicSubscription}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier}/subscription/renew [post]
+func (sc *OrganizationSubscriptionController) RenewSubscription(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	var request domain.RenewSubscription
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	subscription, err := sc.OrganizationSubscriptionUsecase.Renew(c, organizationID, &request)
+	if err != nil {
+		switch err {
+		case domain.ErrOutOfOrganization:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(subscription))
+}
+
+// CancelSubscription cancela a assinatura de uma organização
+// @Summary Cancel Subscription
+// @Description Deactivates the subscription immediately, its users can't use services until it is renewed
+// @Tags Subscription
+// @Produce json
+// @Param identifier path int true "Organization ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicSubscription}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier}/subscription/cancel [post]
+func (sc *OrganizationSubscriptionController) CancelSubscription(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	subscription, err := sc.OrganizationSubscriptionUsecase.Cancel(c, organizationID)
+	if err != nil {
+		switch err {
+		case domain.ErrOutOfOrganization:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrSubscriptionInactive:
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(subscription))
+}
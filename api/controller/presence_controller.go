@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	presenceWriteWait  = 10 * time.Second
+	presencePongWait   = 60 * time.Second
+	presencePingPeriod = presencePongWait * 9 / 10
+	presenceReadLimit  = 512
+)
+
+var presenceUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// a conexão é autenticada pelo access token e não por cookies, então qualquer origem pode conectar
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type PresenceController struct {
+	PresenceUsecase domain.PresenceUsecase
+	ServiceUsecase  domain.ServiceUsecase
+	Shutdown        *bootstrap.Shutdown // the sockets are closed with "going away" and waited for on shutdown
+	Env             *bootstrap.Env
+}
+
+// GetPresence retorna quem está usando os serviços da organização
+// @Summary Get Presence
+// @Description Gets the users with an active usage session in each service of the organization right now
+// @Tags Presence
+// @Produce json
+// @Param identifier path int true "Organization ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PresenceSnapshot}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /organizations/{identifier}/presence [get]
+func (pc *PresenceController) GetPresence(c *gin.Context) {
+	organizationID, err := internal.ParseUint(c.Param("identifier"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	snapshot, err := pc.PresenceUsecase.GetByOrganizationID(c, organizationID)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(snapshot))
+}
+
+// PresenceSocket abre o WebSocket de presença
+// @Summary Presence WebSocket
+// @Description Upgrades to a WebSocket (the access token may be sent as the access_token query parameter). Users with service:use send {"type":"heartbeat","log_id":1} and {"type":"end","log_id":1} for their usage sessions and receive {"type":"session","session":{...}}. Principals with presence:read receive {"type":"presence","presence":{...}} with the active users per service of their organization (platform admins may pick organization_id) at connection and on every change. Failures are sent as {"type":"error","message":"..."}.
+// @Tags Presence
+// @Param organization_id query int false "Organization ID, defaults to the caller's organization"
+// @Param access_token query string false "Access token, when the Authorization header can't be set"
+// @Success 101
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Failure 503 {object} domain.ErrorResponse
+// @Router /presence/ws [get]
+func (pc *PresenceController) PresenceSocket(c *gin.Context) {
+	// registrado antes do upgrade, enquanto o servidor ainda aguarda esta requisição no Shutdown
+	release := pc.Shutdown.Track()
+	defer release()
+	if pc.Shutdown.Stopping() {
+		c.JSON(http.StatusServiceUnavailable, domain.ErrorResponse{Message: "server is shutting down"})
+		return
+	}
+
+	principal, _ := domain.PrincipalFromContext(c)
+	canUse := principal.UserID != 0 && principal.HasPermission(domain.PermServiceUse)
+	canWatch := principal.HasPermission(domain.PermPresenceRead)
+	if !canUse && !canWatch {
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: domain.ErrForbidden.Error() + " " + domain.PermServiceUse})
+		return
+	}
+
+	// o acesso à organização é verificado antes do upgrade, para responder com o status HTTP adequado
+	var snapshot domain.PresenceSnapshot
+	if canWatch {
+		organizationID := principal.OrganizationID
+		if param := c.Query("organization_id"); param != "" {
+			id, err := internal.ParseUint(param)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organization_id"})
+				return
+			}
+			organizationID = id
+		}
+
+		var err error
+		snapshot, err = pc.PresenceUsecase.GetByOrganizationID(c, organizationID)
+		if err != nil {
+			switch err {
+			case domain.ErrNotFound:
+				c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+			}
+			return
+		}
+	}
+
+	conn, err := presenceUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return // o upgrader já respondeu com o erro
+	}
+	defer conn.Close()
+
+	outgoing := make(chan domain.PresenceMessage, 16)
+	var snapshots <-chan domain.PresenceSnapshot
+	if canWatch {
+		var unsubscribe func()
+		snapshots, unsubscribe = pc.PresenceUsecase.Subscribe(snapshot.OrganizationID)
+		defer unsubscribe()
+		outgoing <- domain.PresenceMessage{Type: domain.PresenceMessagePresence, Presence: &snapshot}
+	}
+
+	closed := make(chan struct{})
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		writePresence(conn, outgoing, snapshots, closed, pc.Shutdown.Done())
+	}()
+
+	pc.readPresence(c, conn, principal.UserID, canUse, outgoing, writerDone)
+	close(closed)
+	<-writerDone
+}
+
+// readPresence trata as mensagens do cliente até a conexão ser fechada
+func (pc *PresenceController) readPresence(c *gin.Context, conn *websocket.Conn, userID uint, canUse bool, outgoing chan<- domain.PresenceMessage, writerDone <-chan struct{}) {
+	conn.SetReadLimit(presenceReadLimit)
+	conn.SetReadDeadline(time.Now().Add(presencePongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(presencePongWait))
+	})
+
+	maxGap := time.Duration(pc.Env.UsageHeartbeatMaxGapSeconds) * time.Second
+	for {
+		var message domain.PresenceMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			return
+		}
+
+		var reply domain.PresenceMessage
+		switch {
+		case message.Type != domain.PresenceMessageHeartbeat && message.Type != domain.PresenceMessageEnd:
+			reply = domain.PresenceMessage{Type: domain.PresenceMessageError, Message: "unknown message type"}
+		case !canUse:
+			reply = domain.PresenceMessage{Type: domain.PresenceMessageError, Message: domain.ErrForbidden.Error() + " " + domain.PermServiceUse}
+		default:
+			advance := pc.ServiceUsecase.Heartbeat
+			if message.Type == domain.PresenceMessageEnd {
+				advance = pc.ServiceUsecase.EndUsage
+			}
+			session, err := advance(c, userID, message.LogID, maxGap)
+			if err != nil {
+				reply = domain.PresenceMessage{Type: domain.PresenceMessageError, LogID: message.LogID, Message: err.Error()}
+			} else {
+				reply = domain.PresenceMessage{Type: domain.PresenceMessageSession, Session: &session}
+			}
+		}
+
+		select {
+		case outgoing <- reply:
+		case <-writerDone:
+			return
+		}
+	}
+}
+
+// writePresence é o único escritor da conexão: respostas, atualizações de presença e pings
+func writePresence(conn *websocket.Conn, outgoing <-chan domain.PresenceMessage, snapshots <-chan domain.PresenceSnapshot, closed <-chan struct{}, shutdown <-chan struct{}) {
+	ticker := time.NewTicker(presencePingPeriod)
+	defer ticker.Stop()
+	// fechar a conexão encerra também a leitura
+	defer conn.Close()
+
+	for {
+		var err error
+		conn.SetWriteDeadline(time.Now().Add(presenceWriteWait))
+		select {
+		case <-closed:
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		case <-shutdown:
+			// o cliente reconecta em outra instância
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down"))
+			return
+		case message := <-outgoing:
+			err = conn.WriteJSON(message)
+		case snapshot := <-snapshots:
+			err = conn.WriteJSON(domain.PresenceMessage{Type: domain.PresenceMessagePresence, Presence: &snapshot})
+		case <-ticker.C:
+			err = conn.WriteMessage(websocket.PingMessage, nil)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gin-gonic/gin"
+)
+
+// parseDateRange lê as datas from e to (YYYY-MM-DD, UTC). to é inclusivo na query e retornado exclusivo
+// (o dia seguinte), datas ausentes ficam zeradas. Responde 400 e retorna ok false quando uma data é inválida
+func parseDateRange(c *gin.Context) (from time.Time, to time.Time, ok bool) {
+	for _, date := range []struct {
+		param  string
+		target *time.Time
+		days   int
+	}{{"from", &from, 0}, {"to", &to, 1}} {
+		value := c.Query(date.param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.UTC)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid " + date.param + ", expected YYYY-MM-DD"})
+			return from, to, false
+		}
+		*date.target = parsed.AddDate(0, 0, date.days)
+	}
+	return from, to, true
+}
+
+// parseUintQueries lê os ids opcionais da query nos destinos indicados, responde 400 e retorna false quando um é inválido
+// usage: parseUintQueries(c, map[string]*uint{"user_id": &filter.UserID})
+func parseUintQueries(c *gin.Context, targets map[string]*uint) bool {
+	for param, target := range targets {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := internal.ParseUint(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid " + param})
+			return false
+		}
+		*target = parsed
+	}
+	return true
+}
@@ -0,0 +1,246 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceAccountController struct {
+	ServiceAccountUsecase domain.ServiceAccountUsecase
+	Env                   *bootstrap.Env
+}
+
+// CreateServiceAccount cria uma nova conta de serviço
+// @Summary Create Service Account
+// @Description Creates a machine-to-machine service account. Keys are issued separately.
+// @Tags Service Account
+// @Accept json
+// @Produce json
+// @Param serviceAccount body domain.CreateServiceAccount true "Service account data"
+// @Success 201 {object} domain.SuccessResponse{data=domain.PublicServiceAccount}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse "Scope not held by the caller or organization of another tenant"
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /service-accounts [post]
+func (sac *ServiceAccountController) CreateServiceAccount(c *gin.Context) {
+	var request domain.CreateServiceAccount
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	serviceAccount, err := sac.ServiceAccountUsecase.Create(c, &request)
+	if err != nil {
+		switch err {
+		case domain.ErrForbidden, domain.ErrOutOfOrganization:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, parser.ToSuccessResponse(serviceAccount))
+}
+
+// FetchServiceAccounts retorna todas as contas de serviço
+// @Summary Fetch Service Accounts
+// @Description Gets all service accounts with their keys (secrets are never returned)
+// @Tags Service Account
+// @Produce json
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicServiceAccount}
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /service-accounts [get]
+func (sac *ServiceAccountController) FetchServiceAccounts(c *gin.Context) {
+	serviceAccounts, err := sac.ServiceAccountUsecase.Fetch(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(serviceAccounts))
+}
+
+// GetServiceAccount retorna uma conta de serviço
+// @Summary Get Service Account
+// @Description Gets a service account by ID
+// @Tags Service Account
+// @Produce json
+// @Param id path int true "Service Account ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicServiceAccount}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /service-accounts/{id} [get]
+func (sac *ServiceAccountController) GetServiceAccount(c *gin.Context) {
+	id, err := internal.ParseUint(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid service account ID"})
+		return
+	}
+
+	serviceAccount, err := sac.ServiceAccountUsecase.GetByID(c, id)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(serviceAccount))
+}
+
+// UpdateServiceAccount atualiza uma conta de serviço
+// @Summary Update Service Account
+// @Description Updates description, scopes or active flag of a service account. Omitted fields are kept.
+// @Tags Service Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Service Account ID"
+// @Param serviceAccount body domain.UpdateServiceAccount true "Service account data"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicServiceAccount}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse "Scope not held by the caller"
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /service-accounts/{id} [put]
+func (sac *ServiceAccountController) UpdateServiceAccount(c *gin.Context) {
+	id, err := internal.ParseUint(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid service account ID"})
+		return
+	}
+
+	var request domain.UpdateServiceAccount
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	serviceAccount, err := sac.ServiceAccountUsecase.Update(c, id, &request)
+	if err != nil {
+		switch err {
+		case domain.ErrForbidden:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(serviceAccount))
+}
+
+// DeleteServiceAccount remove uma conta de serviço
+// @Summary Delete Service Account
+// @Description Deletes a service account and all of its keys
+// @Tags Service Account
+// @Produce json
+// @Param id path int true "Service Account ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /service-accounts/{id} [delete]
+func (sac *ServiceAccountController) DeleteServiceAccount(c *gin.Context) {
+	id, err := internal.ParseUint(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid service account ID"})
+		return
+	}
+
+	if err := sac.ServiceAccountUsecase.Delete(c, id); err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// IssueServiceAccountKey gera uma nova chave de API
+// @Summary Issue Service Account Key
+// @Description Issues a new API key. The full key is only returned in this response, use it as "Authorization: ApiKey <key>".
+// @Tags Service Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Service Account ID"
+// @Param key body domain.CreateServiceAccountKey false "Key options"
+// @Success 201 {object} domain.SuccessResponse{data=domain.IssuedServiceAccountKey}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /service-accounts/{id}/keys [post]
+func (sac *ServiceAccountController) IssueServiceAccountKey(c *gin.Context) {
+	id, err := internal.ParseUint(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid service account ID"})
+		return
+	}
+
+	var request domain.CreateServiceAccountKey
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
+
+	key, err := sac.ServiceAccountUsecase.IssueKey(c, id, request.ExpiresInDays)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, parser.ToSuccessResponse(key))
+}
+
+// RevokeServiceAccountKey revoga uma chave de API
+// @Summary Revoke Service Account Key
+// @Description Revokes an API key of the service account
+// @Tags Service Account
+// @Produce json
+// @Param id path int true "Service Account ID"
+// @Param keyID path int true "Key ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /service-accounts/{id}/keys/{keyID} [delete]
+func (sac *ServiceAccountController) RevokeServiceAccountKey(c *gin.Context) {
+	id, err := internal.ParseUint(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid service account ID"})
+		return
+	}
+	keyID, err := internal.ParseUint(c.Param("keyID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid key ID"})
+		return
+	}
+
+	if err := sac.ServiceAccountUsecase.RevokeKey(c, id, keyID); err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
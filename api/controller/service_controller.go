@@ -1,16 +1,15 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-
-	"strings"
+	"time"
 
 	"github.com/gabrielfmcoelho/platform-core/bootstrap"
 	"github.com/gabrielfmcoelho/platform-core/domain"
 	"github.com/gabrielfmcoelho/platform-core/internal"
 	"github.com/gabrielfmcoelho/platform-core/internal/parser"
-	"github.com/gabrielfmcoelho/platform-core/internal/tokenutil"
 	"github.com/gin-gonic/gin"
 )
 
@@ -180,15 +179,54 @@ func (sc *ServiceController) SetServiceAvailabilityToOrganization(c *gin.Context
 	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Service availability set successfully."})
 }
 
+// RemoveServiceAvailabilityFromOrganization desvincula um service de uma organização
+// @Summary Remove Service Availability
+// @Description Unlinks the service from an organization
+// @Tags Service
+// @Produce json
+// @Param serviceID path int true "Service ID"
+// @Param organizationID path int true "Organization ID"
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /services/{serviceID}/organization/{organizationID} [delete]
+func (sc *ServiceController) RemoveServiceAvailabilityFromOrganization(c *gin.Context) {
+	sID, err := internal.ParseUint(c.Param("serviceID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid serviceID"})
+		return
+	}
+	oID, err := internal.ParseUint(c.Param("organizationID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid organizationID"})
+		return
+	}
+
+	err = sc.ServiceUsecase.RemoveAvailabilityFromOrganization(c, sID, oID)
+	if err != nil {
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Service availability removed successfully."})
+}
+
 // UseService
-// @Summary Start using a service (create a usage log)
-// @Description Logs that a user started using a service, returns log ID and public service data
+// @Summary Start using a service (start a usage session)
+// @Description Starts a usage session of the authenticated user, returns the session (log) ID and public service data. The session must be kept alive with heartbeats and ended when the user leaves, sessions without heartbeats are expired by the server. Organizations with an inactive or expired subscription are refused and services not linked to the organization of the user are reported as not found.
 // @Tags Service
 // @Accept json
 // @Produce json
 // @Param serviceID path int true "Service ID"
 // @Success 200 {object} domain.SuccessResponse{data=domain.UseService}
 // @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
 // @Failure 404 {object} domain.ErrorResponse
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /services/{serviceID}/use [post]
@@ -201,18 +239,9 @@ func (sc *ServiceController) UseService(c *gin.Context) {
 		return
 	}
 
-	// 2) extract UserID from jwt token
-	authHeader := c.Request.Header.Get("Authorization")
-	t := strings.Split(authHeader, " ")
-	authToken := t[1]
-	userID, err := tokenutil.ExtractIDFromToken(authToken, sc.Env.AccessTokenSecret)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
-	}
-	uID, err := internal.ParseUint(userID)
-
-	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid userID"})
+	// 2) usage sessions belong to the authenticated user
+	uID, ok := sessionUserID(c)
+	if !ok {
 		return
 	}
 
@@ -220,6 +249,8 @@ func (sc *ServiceController) UseService(c *gin.Context) {
 	service, logID, err := sc.ServiceUsecase.Use(c, uID, sID)
 	if err != nil {
 		switch err {
+		case domain.ErrSubscriptionInactive:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
 		case domain.ErrNotFound:
 			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
 		default:
@@ -236,36 +267,78 @@ func (sc *ServiceController) UseService(c *gin.Context) {
 
 // HeartbeatService
 // @Summary Heartbeat usage
-// @Description Adds usage duration (in seconds) to a log record
+// @Description Keeps a usage session of the authenticated user alive. The server adds the time since the previous heartbeat to the session duration, a gap longer than USAGE_HEARTBEAT_MAX_GAP_SECONDS only counts as the max gap.
 // @Tags Service
 // @Accept json
 // @Produce json
 // @Param heartbeat body domain.Heartbeat true "Heartbeat data"
-// @Success 200 {object} domain.SuccessResponse
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicUserServiceLog}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
 // @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /services/heartbeat [patch]
 func (sc *ServiceController) HeartbeatService(c *gin.Context) {
-	// 1) Parse JSON body for logID and duration
+	sc.advanceSession(c, sc.ServiceUsecase.Heartbeat)
+}
+
+// EndServiceUsage
+// @Summary End usage
+// @Description Ends a usage session of the authenticated user, the time since the previous heartbeat is counted as in a heartbeat
+// @Tags Service
+// @Accept json
+// @Produce json
+// @Param heartbeat body domain.Heartbeat true "Usage session"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PublicUserServiceLog}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 409 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /services/end [patch]
+func (sc *ServiceController) EndServiceUsage(c *gin.Context) {
+	sc.advanceSession(c, sc.ServiceUsecase.EndUsage)
+}
+
+// advanceSession trata heartbeat e encerramento, que compartilham parâmetros e respostas
+func (sc *ServiceController) advanceSession(c *gin.Context, advance func(ctx context.Context, userID uint, logID uint, maxGap time.Duration) (domain.PublicUserServiceLog, error)) {
 	var req domain.Heartbeat
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	err := sc.ServiceUsecase.Heartbeat(c, req.LogID, req.Duration)
+	uID, ok := sessionUserID(c)
+	if !ok {
+		return
+	}
+
+	session, err := advance(c, uID, req.LogID, time.Duration(sc.Env.UsageHeartbeatMaxGapSeconds)*time.Second)
 	if err != nil {
 		switch err {
+		case domain.ErrUsageSessionOwner:
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
 		case domain.ErrNotFound:
 			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		case domain.ErrUsageSessionClosed:
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
 		}
 		return
 	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(session))
+}
 
-	// 3) Return success
-	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Usage duration updated successfully"})
+// sessionUserID retorna o usuário autenticado, sessões de uso não podem ser abertas por service accounts
+func sessionUserID(c *gin.Context) (uint, bool) {
+	principal, ok := domain.PrincipalFromContext(c)
+	if !ok || principal.UserID == 0 {
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: "usage sessions require an authenticated user"})
+		return 0, false
+	}
+	return principal.UserID, true
 }
 
 // UpdateService atualiza um service
@@ -277,6 +350,7 @@ func (sc *ServiceController) HeartbeatService(c *gin.Context) {
 // @Param serviceID path int true "Service ID"
 // @Param service body domain.Service true "Service data"
 // @Success 200 {object} domain.SuccessResponse{data=domain.PublicService}
+// @Failure 404 {object} domain.ErrorResponse
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /services/{serviceID} [put]
 func (sc *ServiceController) UpdateService(c *gin.Context) {
@@ -297,7 +371,12 @@ func (sc *ServiceController) UpdateService(c *gin.Context) {
 
 	err = sc.ServiceUsecase.Update(c, sID, &service)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
 		return
 	}
 
@@ -311,6 +390,7 @@ func (sc *ServiceController) UpdateService(c *gin.Context) {
 // @Produce json
 // @Param serviceID path int true "Service ID"
 // @Success 204 "No Content"
+// @Failure 404 {object} domain.ErrorResponse
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /services/{serviceID} [delete]
 func (sc *ServiceController) DeleteService(c *gin.Context) {
@@ -325,7 +405,12 @@ func (sc *ServiceController) DeleteService(c *gin.Context) {
 
 	err = sc.ServiceUsecase.Delete(c, sID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		switch err {
+		case domain.ErrNotFound:
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
 		return
 	}
 
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gin-gonic/gin"
+)
+
+type UsageAnalyticsController struct {
+	UsageAnalyticsUsecase domain.UsageAnalyticsUsecase
+	Env                   *bootstrap.Env
+}
+
+// GetUsage retorna o uso agregado dos serviços
+// @Summary Usage Analytics
+// @Description Aggregates the usage sessions (count, total duration in seconds and distinct users) started in the period, grouped by time bucket and/or service, user and organization. Without from/to the last 30 days are used.
+// @Tags Analytics
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day, inclusive (YYYY-MM-DD)"
+// @Param bucket query string false "Time bucket" Enums(day, week, month)
+// @Param group_by query string false "Comma separated groups: service, user, organization"
+// @Param service_id query int false "Service ID"
+// @Param user_id query int false "User ID"
+// @Param organization_id query int false "Organization ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.UsageAnalytics}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /analytics/usage [get]
+func (ac *UsageAnalyticsController) GetUsage(c *gin.Context) {
+	filter, ok := parseUsageAnalyticsFilter(c)
+	if !ok {
+		return
+	}
+	if groupBy := c.Query("group_by"); groupBy != "" {
+		for _, group := range strings.Split(groupBy, ",") {
+			filter.GroupBy = append(filter.GroupBy, strings.TrimSpace(group))
+		}
+	}
+
+	analytics, err := ac.UsageAnalyticsUsecase.Usage(c, filter)
+	respondUsageAnalytics(c, analytics, err)
+}
+
+// GetTopServices retorna os serviços mais usados
+// @Summary Top Services
+// @Description Ranks the services by total usage duration in the period. Without from/to the last 30 days are used.
+// @Tags Analytics
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day, inclusive (YYYY-MM-DD)"
+// @Param limit query int false "Number of services (max 100)" default(10)
+// @Param user_id query int false "User ID"
+// @Param organization_id query int false "Organization ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.UsageAnalytics}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /analytics/usage/top-services [get]
+func (ac *UsageAnalyticsController) GetTopServices(c *gin.Context) {
+	filter, ok := parseUsageAnalyticsFilter(c)
+	if !ok {
+		return
+	}
+	limit := 0
+	if param := c.Query("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid limit"})
+			return
+		}
+	}
+
+	analytics, err := ac.UsageAnalyticsUsecase.TopServices(c, filter, limit)
+	respondUsageAnalytics(c, analytics, err)
+}
+
+// GetActiveUsers retorna os usuários ativos por período
+// @Summary Active Users
+// @Description Counts the distinct users with usage sessions in each bucket (daily active users by default). Without from/to the last 30 days are used.
+// @Tags Analytics
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day, inclusive (YYYY-MM-DD)"
+// @Param bucket query string false "Time bucket" Enums(day, week, month) default(day)
+// @Param service_id query int false "Service ID"
+// @Param organization_id query int false "Organization ID"
+// @Success 200 {object} domain.SuccessResponse{data=domain.UsageAnalytics}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /analytics/usage/active-users [get]
+func (ac *UsageAnalyticsController) GetActiveUsers(c *gin.Context) {
+	filter, ok := parseUsageAnalyticsFilter(c)
+	if !ok {
+		return
+	}
+
+	analytics, err := ac.UsageAnalyticsUsecase.ActiveUsers(c, filter)
+	respondUsageAnalytics(c, analytics, err)
+}
+
+// parseUsageAnalyticsFilter lê o período e os filtros comuns
+func parseUsageAnalyticsFilter(c *gin.Context) (domain.UsageAnalyticsFilter, bool) {
+	filter := domain.UsageAnalyticsFilter{Bucket: c.Query("bucket")}
+
+	var ok bool
+	if filter.From, filter.To, ok = parseDateRange(c); !ok {
+		return filter, false
+	}
+	ok = parseUintQueries(c, map[string]*uint{
+		"service_id":      &filter.ServiceID,
+		"user_id":         &filter.UserID,
+		"organization_id": &filter.OrganizationID,
+	})
+	return filter, ok
+}
+
+func respondUsageAnalytics(c *gin.Context, analytics domain.UsageAnalytics, err error) {
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidAnalyticsQuery:
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, parser.ToSuccessResponse(analytics))
+}
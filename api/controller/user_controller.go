@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gabrielfmcoelho/platform-core/bootstrap"
@@ -24,6 +25,8 @@ type UserController struct {
 // @Param user body domain.CreateUser true "User object"
 // @Success 201 "User created successfully"
 // @Failure 400 {object} domain.ErrorResponse "Bad Request - Invalid input"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden - Organization outside of the caller's scope, role with permissions the caller lacks or users limit of its subscription reached"
+// @Failure 409 {object} domain.ErrorResponse "Conflict - Email already in use"
 // @Failure 500 {object} domain.ErrorResponse "Internal Server Error"
 // @Router /user/create [post]
 func (uc *UserController) CreateUser(c *gin.Context) {
@@ -37,6 +40,24 @@ func (uc *UserController) CreateUser(c *gin.Context) {
 
 	err := uc.UserUsecase.Create(c, &user)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidUserRole) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Message: "Failed to create user: " + err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrOutOfOrganization) || errors.Is(err, domain.ErrForbidden) || errors.Is(err, domain.ErrSubscriptionUsersLimit) {
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{
+				Message: "Failed to create user: " + err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrUserAlreadyExists) {
+			c.JSON(http.StatusConflict, domain.ErrorResponse{
+				Message: "Failed to create user: " + err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
 			Message: "Failed to create user: " + err.Error(),
 		})
@@ -96,9 +117,11 @@ func (uc *UserController) GetUser(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
-// @Param user body domain.User true "User object"
+// @Param user body domain.UpdateUser true "Fields to change"
 // @Success 200 "User updated successfully"
 // @Failure 400 {object} domain.ErrorResponse "Bad Request - Invalid input"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden - Organization outside of the caller's scope or current or new role with permissions the caller lacks"
+// @Failure 404 {object} domain.ErrorResponse "User not found"
 // @Failure 500 {object} domain.ErrorResponse "Internal Server Error"
 // @Router /user/{id} [put]
 func (uc *UserController) UpdateUser(c *gin.Context) {
@@ -111,7 +134,7 @@ func (uc *UserController) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	var user domain.User
+	var user domain.UpdateUser
 	if err := c.ShouldBindJSON(&user); err != nil {
 		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Message: "Invalid input: " + err.Error(),
@@ -121,6 +144,23 @@ func (uc *UserController) UpdateUser(c *gin.Context) {
 
 	err = uc.UserUsecase.Update(c, id, &user)
 	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Message: "Failed to update user: " + err.Error(),
+			})
+			return
+		case errors.Is(err, domain.ErrInvalidUserRole):
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Message: "Failed to update user: " + err.Error(),
+			})
+			return
+		case errors.Is(err, domain.ErrOutOfOrganization), errors.Is(err, domain.ErrForbidden):
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{
+				Message: "Failed to update user: " + err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
 			Message: "Failed to update user: " + err.Error(),
 		})
@@ -137,6 +177,8 @@ func (uc *UserController) UpdateUser(c *gin.Context) {
 // @Produce json
 // @Param id path string true "User ID"
 // @Success 204 "User deleted successfully"
+// @Failure 403 {object} domain.ErrorResponse "Forbidden - User role with permissions the caller lacks"
+// @Failure 404 {object} domain.ErrorResponse "User not found"
 // @Failure 500 {object} domain.ErrorResponse "Internal Server Error"
 // @Router /user/{id} [delete]
 func (uc *UserController) DeleteUser(c *gin.Context) {
@@ -151,6 +193,18 @@ func (uc *UserController) DeleteUser(c *gin.Context) {
 
 	err = uc.UserUsecase.Archive(c, id)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, domain.ErrorResponse{
+				Message: "Failed to delete user: " + err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{
+				Message: "Failed to delete user: " + err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
 			Message: "Failed to delete user: " + err.Error(),
 		})
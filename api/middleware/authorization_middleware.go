@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// LoadPermissions resolves the permissions of the authenticated principal once per request,
+// it must run after JwtAuthMiddleware
+func LoadPermissions(authorizationUsecase domain.AuthorizationUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := domain.PrincipalFromContext(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Not authorized"})
+			c.Abort()
+			return
+		}
+
+		permissions, err := authorizationUsecase.GetPrincipalPermissions(c, principal)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+			c.Abort()
+			return
+		}
+
+		principal.Permissions = permissions
+		setPrincipal(c, principal)
+		c.Next()
+	}
+}
+
+// RequirePermission aborts with 403 unless the principal holds every one of the given permissions
+// usage: group.POST("/services", middleware.RequirePermission(domain.PermServiceWrite), sc.CreateService)
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := domain.PrincipalFromContext(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Not authorized"})
+			c.Abort()
+			return
+		}
+
+		for _, permission := range permissions {
+			if !principal.HasPermission(permission) {
+				c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: domain.ErrForbidden.Error() + " " + permission})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -1,50 +1,82 @@
 package middleware
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
 	"github.com/gabrielfmcoelho/platform-core/internal/tokenutil"
 	"github.com/gin-gonic/gin"
 )
 
-func JwtAuthMiddleware(secret string) gin.HandlerFunc {
+// JwtAuthMiddleware authenticates the request either with a user access token ("Authorization: Bearer <jwt>")
+// or with a service account key ("Authorization: ApiKey <prefix>.<secret>") and stores the resulting
+// domain.Principal in the request context
+func JwtAuthMiddleware(secret string, serviceAccountUsecase domain.ServiceAccountUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.Request.Header.Get("Authorization")
-		// console log the authHeader
-		fmt.Println("Authorization header")
+		// browsers can't set headers on WebSocket connections, there the access token may come in the query string
+		if authHeader == "" && c.IsWebsocket() && c.Query("access_token") != "" {
+			authHeader = "Bearer " + c.Query("access_token")
+		}
 		t := strings.Split(authHeader, " ")
 		if len(t) == 2 {
-			authToken := t[1]
-			authorized, err := tokenutil.IsAuthorized(authToken, secret)
-			skip, _ := tokenutil.SkipTokenValidation(authToken)
-			if skip {
+			if strings.EqualFold(t[0], "ApiKey") {
+				serviceAccount, err := serviceAccountUsecase.Authenticate(c, t[1])
+				if err != nil {
+					status := http.StatusUnauthorized
+					if err != domain.ErrInvalidAPIKey {
+						status = http.StatusInternalServerError
+					}
+					c.JSON(status, domain.ErrorResponse{Message: err.Error()})
+					c.Error(err) // the reason goes to the request log
+					c.Abort()
+					return
+				}
+				setPrincipal(c, domain.Principal{
+					ServiceAccountID: serviceAccount.ID,
+					OrganizationID:   serviceAccount.OrganizationID,
+					Scopes:           internal.ParseDelimitedStrings(serviceAccount.Scopes),
+				})
+				c.Set("x-service-account-id", serviceAccount.ID)
 				c.Next()
-				fmt.Println("Skip token validation")
 				return
 			}
+
+			authToken := t[1]
+			authorized, err := tokenutil.IsAuthorized(authToken, secret)
 			if authorized {
-				userID, err := tokenutil.ExtractIDFromToken(authToken, secret)
+				claims, err := tokenutil.ExtractAccessClaims(authToken, secret)
+				if err != nil {
+					c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+					c.Error(err)
+					c.Abort()
+					return
+				}
+				uID, err := tokenutil.ExtractUserIDFromSubject(claims.Subject)
 				if err != nil {
 					c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+					c.Error(err)
 					c.Abort()
-					fmt.Println("Error extracting ID from token")
 					return
 				}
-				c.Set("x-user-id", userID)
+				setPrincipal(c, domain.Principal{UserID: uID, OrganizationID: claims.OrganizationID})
+				c.Set("x-user-id", claims.Subject)
 				c.Next()
-				fmt.Println("Authorized")
 				return
 			}
 			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+			c.Error(err)
 			c.Abort()
-			fmt.Println("Not authorized")
 			return
 		}
 		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Not authorized"})
 		c.Abort()
-		fmt.Println("Not authorized")
 	}
 }
+
+// setPrincipal stores the principal in the request context, so it reaches usecases and repositories through ctx
+func setPrincipal(c *gin.Context, principal domain.Principal) {
+	c.Request = c.Request.WithContext(domain.WithPrincipal(c.Request.Context(), principal))
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPMetrics records the count and latency of every request by route template, so "/user/1" and "/user/2"
+// share a series. Requests that match no route are grouped as "unmatched"
+func HTTPMetrics(registry *metrics.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		registry.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// RequireMetricsToken protects /metrics with "Authorization: Bearer <METRICS_TOKEN>"
+func RequireMetricsToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		bearer, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Not authorized"})
+			return
+		}
+		c.Next()
+	}
+}
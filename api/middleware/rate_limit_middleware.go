@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// maxKeyBodySize is how much of the body ByBodyEmail reads looking for the email, login requests are tiny
+const maxKeyBodySize = 64 << 10
+
+// RateLimitKey returns the identifier the request is limited by, false lets the request through unlimited
+type RateLimitKey func(c *gin.Context) (string, bool)
+
+// RateLimit takes a token from the bucket of the request, named name plus its key, and answers 429 with
+// Retry-After when it is empty. Every limited response carries the RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers (IETF draft), of the bucket closest to run out when several limits apply. The
+// request is let through when the store fails, an unavailable limiter must not take the API down with it
+func RateLimit(store domain.RateLimitStore, name string, limit domain.RateLimit, key RateLimitKey) gin.HandlerFunc {
+	if !limit.Enabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+	policy := strconv.Itoa(limit.Requests) + ";w=" + strconv.Itoa(int(limit.Period.Seconds()))
+
+	return func(c *gin.Context) {
+		identifier, ok := key(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(c, name+":"+identifier, limit)
+		if err != nil {
+			slog.WarnContext(c, "rate limit store failed, request not limited", "limit", name, "error", err)
+			c.Next()
+			return
+		}
+
+		if !result.Allowed {
+			setRateLimitHeaders(c, result, policy, true)
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, domain.ErrorResponse{Message: domain.ErrTooManyRequests.Error()})
+			return
+		}
+		setRateLimitHeaders(c, result, policy, false)
+		c.Next()
+	}
+}
+
+// setRateLimitHeaders keeps the headers already set by another limit when it has fewer requests remaining
+func setRateLimitHeaders(c *gin.Context, result domain.RateLimitResult, policy string, force bool) {
+	if current := c.Writer.Header().Get("RateLimit-Remaining"); current != "" && !force {
+		if remaining, err := strconv.Atoi(current); err == nil && remaining <= result.Remaining {
+			return
+		}
+	}
+	c.Header("RateLimit-Policy", policy)
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// ByClientIP limits by the client IP, taken from X-Forwarded-For only behind the TRUSTED_PROXIES
+func ByClientIP(c *gin.Context) (string, bool) {
+	return c.ClientIP(), true
+}
+
+// ByPrincipal limits by the authenticated user or service account, it must run after JwtAuthMiddleware
+func ByPrincipal(c *gin.Context) (string, bool) {
+	principal, ok := domain.PrincipalFromContext(c)
+	switch {
+	case !ok:
+		return "", false
+	case principal.IsServiceAccount():
+		return "service_account:" + strconv.FormatUint(uint64(principal.ServiceAccountID), 10), true
+	default:
+		return "user:" + strconv.FormatUint(uint64(principal.UserID), 10), true
+	}
+}
+
+// ByBodyEmail limits by the email field of the JSON or form body, case insensitive. The body is restored for
+// the handler, requests without an email are left to its validation
+func ByBodyEmail(c *gin.Context) (string, bool) {
+	var email string
+	if c.ContentType() == gin.MIMEJSON {
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxKeyBodySize))
+		if err != nil {
+			return "", false
+		}
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+
+		var request struct {
+			Email string `json:"email"`
+		}
+		if json.Unmarshal(body, &request) != nil {
+			return "", false
+		}
+		email = request.Email
+	} else {
+		email = c.PostForm("email")
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	return email, email != ""
+}
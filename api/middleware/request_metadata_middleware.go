@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/tokenutil"
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// request ids sent by proxies are kept only when they are short and printable, they end up in the audit trail
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+// RequestMetadata stores the request id, client IP and user agent of the request in its context (see
+// domain.RequestMetadataFromContext). The X-Request-ID header is reused when valid, generated otherwise,
+// and returned in the response
+func RequestMetadata() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID, _ = tokenutil.GenerateTokenID()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		userAgent := c.Request.UserAgent()
+		if len(userAgent) > 512 {
+			userAgent = userAgent[:512]
+		}
+		c.Request = c.Request.WithContext(domain.WithRequestMetadata(c.Request.Context(), domain.RequestMetadata{
+			RequestID: requestID,
+			IPAddress: c.ClientIP(),
+			UserAgent: userAgent,
+		}))
+		c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewAuditRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	ulr := repository.NewUserLogRepository(db)
+	ac := &controller.AuditController{
+		AuditUsecase: usecase.NewAuditUsecase(ulr, timeout),
+		Env:          env,
+	}
+
+	group.GET("/audit", middleware.RequirePermission(domain.PermAuditRead), ac.FetchAudit)
+	// the chain spans every organization, only platform admins can walk it
+	group.GET("/audit/verify", middleware.RequirePermission(domain.PermPlatformAdmin), ac.VerifyAudit)
+}
@@ -4,24 +4,35 @@ import (
 	"time"
 
 	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
 	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
 	"github.com/gabrielfmcoelho/platform-core/repository"
 	"github.com/gabrielfmcoelho/platform-core/usecase"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func NewAuthRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+func NewAuthRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, runtimeMetrics domain.RuntimeMetrics, limiter domain.RateLimitStore, group *gin.RouterGroup) {
 	ur := repository.NewUserRepository(db)
 	ulr := repository.NewUserLogRepository(db)
+	rtr := repository.NewRefreshTokenRepository(db)
+	prtr := repository.NewPasswordResetTokenRepository(db)
+	llr := repository.NewLoginLockoutRepository(db)
+	mailer := bootstrap.NewMailer(env)
 	ac := &controller.AuthController{
-		AuthUsecase: usecase.NewAuthUsecase(ur, ulr, timeout),
+		AuthUsecase: usecase.NewAuthUsecase(ur, ulr, rtr, prtr, llr, bootstrap.LoginLockoutPolicy(env), mailer, runtimeMetrics, timeout),
 		Env:         env,
 	}
 
-	group.POST("/login", ac.Login)
-	group.POST("/login-guest", ac.LoginGuest)
-	group.POST("/forgot-password", ac.ForgotPassword)
-	group.POST("/reset-password", ac.ResetPassword)
+	// Credentials and reset links are limited per client IP and, when the request names an account, per email,
+	// so neither many accounts from one IP nor one account from many IPs can be guessed at full speed
+	byIP := middleware.RateLimit(limiter, "auth_ip", perMinute(env.RateLimitAuthIPPerMinute), middleware.ByClientIP)
+	byEmail := middleware.RateLimit(limiter, "auth_email", perMinute(env.RateLimitAuthEmailPerMinute), middleware.ByBodyEmail)
+
+	group.POST("/login", byIP, byEmail, ac.Login)
+	group.POST("/login-guest", byIP, ac.LoginGuest)
+	group.POST("/forgot-password", byIP, byEmail, ac.ForgotPassword)
+	group.POST("/reset-password", byIP, ac.ResetPassword)
 	group.POST("/refresh-token", ac.RefreshToken)
 }
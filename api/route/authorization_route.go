@@ -0,0 +1,32 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewAuthorizationRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	pr := repository.NewPermissionRepository(db)
+	urr := repository.NewUserRoleRepository(db)
+	orr := repository.NewOrganizationRoleRepository(db)
+	au := usecase.NewAuditUsecase(repository.NewUserLogRepository(db), timeout)
+	ac := &controller.AuthorizationController{
+		AuthorizationUsecase: usecase.NewAuthorizationUsecase(pr, urr, orr, au, timeout),
+		Env:                  env,
+	}
+
+	roleManage := middleware.RequirePermission(domain.PermRoleManage)
+	group.GET("/permissions", roleManage, ac.FetchPermissions)
+	group.GET("/roles/user", roleManage, ac.FetchUserRoles)
+	group.PUT("/roles/user/:roleID/permissions", roleManage, ac.SetUserRolePermissions)
+	group.GET("/roles/organization", roleManage, ac.FetchOrganizationRoles)
+	group.PUT("/roles/organization/:roleID/permissions", roleManage, ac.SetOrganizationRolePermissions)
+}
@@ -0,0 +1,26 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewExportRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	uslr := repository.NewUserServiceLogRepository(db)
+	ulr := repository.NewUserLogRepository(db)
+	ec := &controller.ExportController{
+		ExportUsecase: usecase.NewExportUsecase(uslr, ulr),
+		Env:           env,
+	}
+
+	group.GET("/exports/usage", middleware.RequirePermission(domain.PermDataExport), ec.ExportUsage)
+	group.GET("/exports/logins", middleware.RequirePermission(domain.PermDataExport), ec.ExportLogins)
+}
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewHealthRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, shutdown *bootstrap.Shutdown, group *gin.RouterGroup) {
+	hr := repository.NewHealthRepository(db)
+	hc := &controller.HealthController{
+		HealthUsecase: usecase.NewHealthUsecase(hr, bootstrap.LatestMigration(db), shutdown.Done(), timeout),
+	}
+
+	group.GET("/healthz", hc.Liveness)
+	group.GET("/readyz", hc.Readiness)
+}
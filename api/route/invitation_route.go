@@ -0,0 +1,44 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func newInvitationController(env *bootstrap.Env, timeout time.Duration, db *gorm.DB) *controller.InvitationController {
+	ir := repository.NewInvitationRepository(db)
+	or := repository.NewOrganizationRepository(db)
+	urr := repository.NewUserRoleRepository(db)
+	ur := repository.NewUserRepository(db)
+	osr := repository.NewOrganizationSubscriptionRepository(db)
+	mailer := bootstrap.NewMailer(env)
+	return &controller.InvitationController{
+		InvitationUsecase: usecase.NewInvitationUsecase(ir, or, urr, ur, osr, mailer, timeout),
+		Env:               env,
+	}
+}
+
+// NewInvitationRouter registers the invitation management endpoints of the organizations (protected)
+func NewInvitationRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	ic := newInvitationController(env, timeout, db)
+
+	group.POST("/organizations/:identifier/invitations", middleware.RequirePermission(domain.PermUserWrite), ic.CreateInvitation)
+	group.GET("/organizations/:identifier/invitations", middleware.RequirePermission(domain.PermUserRead), ic.FetchPendingInvitations)
+	group.POST("/invitations/:invitationID/resend", middleware.RequirePermission(domain.PermUserWrite), ic.ResendInvitation) // link is replaced
+	group.DELETE("/invitations/:invitationID", middleware.RequirePermission(domain.PermUserWrite), ic.RevokeInvitation)
+}
+
+// NewOnboardingRouter registers the endpoint used by the invited person to sign up (public)
+func NewOnboardingRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	ic := newInvitationController(env, timeout, db)
+
+	group.POST("/accept-invitation", ic.AcceptInvitation)
+}
@@ -0,0 +1,33 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewInvoiceRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	ir := repository.NewInvoiceRepository(db)
+	or := repository.NewOrganizationRepository(db)
+	sr := repository.NewServiceRepository(db)
+	uslr := repository.NewUserServiceLogRepository(db)
+	ic := &controller.InvoiceController{
+		InvoiceUsecase: usecase.NewInvoiceUsecase(ir, or, sr, uslr, timeout),
+		Env:            env,
+	}
+
+	group.POST("/invoices/generate", middleware.RequirePermission(domain.PermInvoiceManage), ic.GenerateInvoices) // drafts of a month
+	group.GET("/invoices", middleware.RequirePermission(domain.PermInvoiceRead), ic.FetchInvoices)
+	group.GET("/invoices/:invoiceID", middleware.RequirePermission(domain.PermInvoiceRead), ic.GetInvoice)
+	group.GET("/invoices/:invoiceID/render", middleware.RequirePermission(domain.PermInvoiceRead), ic.RenderInvoice) // ?format=html|pdf
+	group.POST("/invoices/:invoiceID/issue", middleware.RequirePermission(domain.PermInvoiceManage), ic.IssueInvoice)
+	group.POST("/invoices/:invoiceID/pay", middleware.RequirePermission(domain.PermInvoiceManage), ic.PayInvoice)
+	group.POST("/invoices/:invoiceID/void", middleware.RequirePermission(domain.PermInvoiceManage), ic.VoidInvoice)
+}
@@ -0,0 +1,29 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewMetricsRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	umr := repository.NewUserMetricsRepository(db)
+	omr := repository.NewOrganizationMetricsRepository(db)
+	ur := repository.NewUserRepository(db)
+	or := repository.NewOrganizationRepository(db)
+	mc := &controller.MetricsController{
+		UserMetricsUsecase:         usecase.NewUserMetricsUsecase(umr, ur, timeout),
+		OrganizationMetricsUsecase: usecase.NewOrganizationMetricsUsecase(omr, or, timeout),
+		Env:                        env,
+	}
+
+	group.GET("/organizations/:identifier/metrics", middleware.RequirePermission(domain.PermOrgRead), mc.GetOrganizationMetrics)
+	group.GET("/user/:identifier/metrics", middleware.RequirePermission(domain.PermUserRead), mc.GetUserMetrics)
+}
@@ -0,0 +1,32 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewOrganizationRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	or := repository.NewOrganizationRepository(db)
+	orr := repository.NewOrganizationRoleRepository(db)
+	au := usecase.NewAuditUsecase(repository.NewUserLogRepository(db), timeout)
+	oc := &controller.OrganizationController{
+		OrganizationUsecase: usecase.NewOrganizationUsecase(or, orr, au, timeout),
+		Env:                 env,
+	}
+
+	group.POST("/organizations", middleware.RequirePermission(domain.PermOrgManage), oc.CreateOrganization)
+	group.GET("/organizations", middleware.RequirePermission(domain.PermOrgRead), oc.FetchOrganizations)
+	group.GET("/organizations/:identifier", middleware.RequirePermission(domain.PermOrgRead), oc.GetOrganizationByIdentifier) // ID or name
+	group.GET("/organizations/:identifier/users", middleware.RequirePermission(domain.PermOrgRead, domain.PermUserRead), oc.GetOrganizationUsers)
+	group.GET("/organizations/:identifier/services", middleware.RequirePermission(domain.PermOrgRead, domain.PermServiceRead), oc.GetOrganizationServices)
+	group.PUT("/organizations/:organizationID", middleware.RequirePermission(domain.PermOrgManage), oc.UpdateOrganization)
+	group.DELETE("/organizations/:organizationID", middleware.RequirePermission(domain.PermOrgManage), oc.DeleteOrganization)
+}
@@ -0,0 +1,29 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewOrganizationSubscriptionRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	osr := repository.NewOrganizationSubscriptionRepository(db)
+	or := repository.NewOrganizationRepository(db)
+	sc := &controller.OrganizationSubscriptionController{
+		OrganizationSubscriptionUsecase: usecase.NewOrganizationSubscriptionUsecase(osr, or, timeout),
+		Env:                             env,
+	}
+
+	group.GET("/subscriptions", middleware.RequirePermission(domain.PermSubscriptionRead), sc.FetchSubscriptions)
+	group.GET("/organizations/:identifier/subscription", middleware.RequirePermission(domain.PermSubscriptionRead), sc.GetSubscription)
+	group.POST("/organizations/:identifier/subscription", middleware.RequirePermission(domain.PermSubscriptionManage), sc.CreateSubscription)
+	group.POST("/organizations/:identifier/subscription/renew", middleware.RequirePermission(domain.PermSubscriptionManage), sc.RenewSubscription) // extends by one period
+	group.POST("/organizations/:identifier/subscription/cancel", middleware.RequirePermission(domain.PermSubscriptionManage), sc.CancelSubscription)
+}
@@ -0,0 +1,32 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewPresenceRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, presence domain.PresenceBroker, runtimeMetrics domain.RuntimeMetrics, shutdown *bootstrap.Shutdown, group *gin.RouterGroup) {
+	sr := repository.NewServiceRepository(db)
+	uslr := repository.NewUserServiceLogRepository(db)
+	ur := repository.NewUserRepository(db)
+	or := repository.NewOrganizationRepository(db)
+	osr := repository.NewOrganizationSubscriptionRepository(db)
+	au := usecase.NewAuditUsecase(repository.NewUserLogRepository(db), timeout)
+	pc := &controller.PresenceController{
+		PresenceUsecase: usecase.NewPresenceUsecase(uslr, or, presence, timeout),
+		ServiceUsecase:  usecase.NewServiceUsecase(sr, uslr, ur, osr, presence, au, runtimeMetrics, timeout),
+		Shutdown:        shutdown,
+		Env:             env,
+	}
+
+	group.GET("/organizations/:identifier/presence", middleware.RequirePermission(domain.PermPresenceRead), pc.GetPresence)
+	group.GET("/presence/ws", pc.PresenceSocket) // heartbeats need service:use, presence updates need presence:read
+}
@@ -5,6 +5,10 @@ import (
 
 	"github.com/gabrielfmcoelho/platform-core/api/middleware"
 	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/metrics"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
 
 	//_ "github.com/gabrielfmcoelho/platform-coredocs"
 	"github.com/gin-gonic/gin"
@@ -14,7 +18,7 @@ import (
 	"gorm.io/gorm"
 )
 
-func Setup(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, router *gin.Engine) {
+func Setup(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, presence domain.PresenceBroker, shutdown *bootstrap.Shutdown, registry *metrics.Registry, limiter domain.RateLimitStore, router *gin.Engine) {
 	// Router documentation binding
 	doc := redoc.Redoc{
 		Title:       "Platform Core API",
@@ -25,18 +29,55 @@ func Setup(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, router *gin.E
 	}
 	router.GET("/docs/*any", ginredoc.New(doc))
 
+	// Prometheus metrics, served here only when they have no listener of their own (METRICS_ADDRESS)
+	if env.MetricsAddress == "" && env.MetricsToken != "" {
+		router.GET("/metrics", middleware.RequireMetricsToken(env.MetricsToken), gin.WrapH(registry.Handler()))
+	}
+
+	// Request id, client IP and user agent of every request, recorded in the audit trail
+	router.Use(middleware.RequestMetadata())
+
+	// Health probes, registered before the rate limits so the orchestrator is never throttled
+	NewHealthRouter(env, timeout, db, shutdown, router.Group("/"))
+
+	// Per client IP limit of every API, the auth routes add stricter ones
+	router.Use(middleware.RateLimit(limiter, "ip", perMinute(env.RateLimitIPPerMinute), middleware.ByClientIP))
+
 	// All Public APIs
 	publicRouter := router.Group("/")
 	//NewSignupRouter(env, timeout, db, publicRouter)
-	NewAuthRouter(env, timeout, db, publicRouter)
+	NewAuthRouter(env, timeout, db, registry, limiter, publicRouter)
+	NewOnboardingRouter(env, timeout, db, publicRouter)
 	//NewRefreshTokenRouter(env, timeout, db, publicRouter)
 
 	// All Private APIs
 	protectedRouter := router.Group("/")
-	/// Middleware to verify AccessToken
-	protectedRouter.Use(middleware.JwtAuthMiddleware(env.AccessTokenSecret))
+	/// Middleware to verify AccessToken or service account ApiKey
+	sau := usecase.NewServiceAccountUsecase(repository.NewServiceAccountRepository(db), timeout)
+	protectedRouter.Use(middleware.JwtAuthMiddleware(env.AccessTokenSecret, sau))
+	/// Per user or service account limit, shared by all the IPs of the caller
+	protectedRouter.Use(middleware.RateLimit(limiter, "principal", perMinute(env.RateLimitUserPerMinute), middleware.ByPrincipal))
+	/// Middleware to resolve the permissions of the authenticated principal
+	au := usecase.NewAuthorizationUsecase(repository.NewPermissionRepository(db), repository.NewUserRoleRepository(db), repository.NewOrganizationRoleRepository(db), usecase.NewAuditUsecase(repository.NewUserLogRepository(db), timeout), timeout)
+	protectedRouter.Use(middleware.LoadPermissions(au))
 	NewUserRouter(env, timeout, db, protectedRouter)
-	NewServiceRouter(env, timeout, db, protectedRouter)
+	NewServiceRouter(env, timeout, db, presence, registry, protectedRouter)
+	NewPresenceRouter(env, timeout, db, presence, registry, shutdown, protectedRouter)
+	NewOrganizationRouter(env, timeout, db, protectedRouter)
+	NewOrganizationSubscriptionRouter(env, timeout, db, protectedRouter)
+	NewInvoiceRouter(env, timeout, db, protectedRouter)
+	NewMetricsRouter(env, timeout, db, protectedRouter)
+	NewUsageAnalyticsRouter(env, timeout, db, protectedRouter)
+	NewExportRouter(env, timeout, db, protectedRouter)
+	NewAuditRouter(env, timeout, db, protectedRouter)
+	NewInvitationRouter(env, timeout, db, protectedRouter)
+	NewServiceAccountRouter(env, timeout, db, protectedRouter)
+	NewAuthorizationRouter(env, timeout, db, protectedRouter)
 	//NewProfileRouter(env, timeout, db, protectedRouter)
 	//NewTaskRouter(env, timeout, db, protectedRouter)
 }
+
+// perMinute is the RateLimit of a RATE_LIMIT_*_PER_MINUTE key, 0 disables it
+func perMinute(requests int) domain.RateLimit {
+	return domain.RateLimit{Requests: requests, Period: time.Minute}
+}
@@ -0,0 +1,31 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewServiceAccountRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	sar := repository.NewServiceAccountRepository(db)
+	sac := &controller.ServiceAccountController{
+		ServiceAccountUsecase: usecase.NewServiceAccountUsecase(sar, timeout),
+		Env:                   env,
+	}
+
+	manage := middleware.RequirePermission(domain.PermServiceAccountManage)
+	group.POST("/service-accounts", manage, sac.CreateServiceAccount)
+	group.GET("/service-accounts", manage, sac.FetchServiceAccounts)
+	group.GET("/service-accounts/:id", manage, sac.GetServiceAccount)
+	group.PUT("/service-accounts/:id", manage, sac.UpdateServiceAccount)
+	group.DELETE("/service-accounts/:id", manage, sac.DeleteServiceAccount)
+	group.POST("/service-accounts/:id/keys", manage, sac.IssueServiceAccountKey) // key is only shown once
+	group.DELETE("/service-accounts/:id/keys/:keyID", manage, sac.RevokeServiceAccountKey)
+}
@@ -4,27 +4,34 @@ import (
 	"time"
 
 	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
 	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
 	"github.com/gabrielfmcoelho/platform-core/repository"
 	"github.com/gabrielfmcoelho/platform-core/usecase"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func NewServiceRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+func NewServiceRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, presence domain.PresenceBroker, runtimeMetrics domain.RuntimeMetrics, group *gin.RouterGroup) {
 	sr := repository.NewServiceRepository(db)
 	uslr := repository.NewUserServiceLogRepository(db)
+	ur := repository.NewUserRepository(db)
+	osr := repository.NewOrganizationSubscriptionRepository(db)
+	au := usecase.NewAuditUsecase(repository.NewUserLogRepository(db), timeout)
 	sc := &controller.ServiceController{
-		ServiceUsecase: usecase.NewServiceUsecase(sr, uslr, timeout),
+		ServiceUsecase: usecase.NewServiceUsecase(sr, uslr, ur, osr, presence, au, runtimeMetrics, timeout),
 		Env:            env,
 	}
 
-	group.POST("/services", sc.CreateService)
-	group.GET("/services", sc.FetchServices)
-	group.GET("/services/:identifier", sc.GetServiceByIdentifier)
-	group.POST("/services/:serviceID/organization/:organizationID", sc.SetServiceAvailabilityToOrganization)
-	group.PUT("/services/:serviceID", sc.UpdateService)
-	group.DELETE("/services/:serviceID", sc.DeleteService)
-	group.POST("/services/:serviceID/use", sc.UseService)   // "start" usage
-	group.PATCH("/services/heartbeat", sc.HeartbeatService) // "update" usage duration
+	group.POST("/services", middleware.RequirePermission(domain.PermServiceWrite), sc.CreateService)
+	group.GET("/services", middleware.RequirePermission(domain.PermServiceRead), sc.FetchServices)
+	group.GET("/services/:identifier", middleware.RequirePermission(domain.PermServiceRead), sc.GetServiceByIdentifier)
+	group.POST("/services/:serviceID/organization/:organizationID", middleware.RequirePermission(domain.PermServiceLink), sc.SetServiceAvailabilityToOrganization)
+	group.DELETE("/services/:serviceID/organization/:organizationID", middleware.RequirePermission(domain.PermServiceLink), sc.RemoveServiceAvailabilityFromOrganization)
+	group.PUT("/services/:serviceID", middleware.RequirePermission(domain.PermServiceWrite), sc.UpdateService)
+	group.DELETE("/services/:serviceID", middleware.RequirePermission(domain.PermServiceWrite), sc.DeleteService)
+	group.POST("/services/:serviceID/use", middleware.RequirePermission(domain.PermServiceUse), sc.UseService)   // "start" usage
+	group.PATCH("/services/heartbeat", middleware.RequirePermission(domain.PermServiceUse), sc.HeartbeatService) // "update" usage duration
+	group.PATCH("/services/end", middleware.RequirePermission(domain.PermServiceUse), sc.EndServiceUsage)        // "end" usage
 }
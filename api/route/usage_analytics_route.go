@@ -0,0 +1,26 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NewUsageAnalyticsRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
+	uar := repository.NewUsageAnalyticsRepository(db)
+	ac := &controller.UsageAnalyticsController{
+		UsageAnalyticsUsecase: usecase.NewUsageAnalyticsUsecase(uar, timeout),
+		Env:                   env,
+	}
+
+	group.GET("/analytics/usage", middleware.RequirePermission(domain.PermUserServiceLogRead), ac.GetUsage)
+	group.GET("/analytics/usage/top-services", middleware.RequirePermission(domain.PermUserServiceLogRead), ac.GetTopServices)
+	group.GET("/analytics/usage/active-users", middleware.RequirePermission(domain.PermUserServiceLogRead), ac.GetActiveUsers)
+}
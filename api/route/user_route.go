@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"github.com/gabrielfmcoelho/platform-core/api/controller"
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
 	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
 	"github.com/gabrielfmcoelho/platform-core/repository"
 	"github.com/gabrielfmcoelho/platform-core/usecase"
 	"github.com/gin-gonic/gin"
@@ -13,16 +15,18 @@ import (
 
 func NewUserRouter(env *bootstrap.Env, timeout time.Duration, db *gorm.DB, group *gin.RouterGroup) {
 	ur := repository.NewUserRepository(db)
+	osr := repository.NewOrganizationSubscriptionRepository(db)
+	au := usecase.NewAuditUsecase(repository.NewUserLogRepository(db), timeout)
 	uc := &controller.UserController{
-		UserUsecase: usecase.NewUserUsecase(ur, timeout),
+		UserUsecase: usecase.NewUserUsecase(ur, osr, repository.NewUserRoleRepository(db), au, timeout),
 		Env:         env,
 	}
 
-	group.POST("/user/create", uc.CreateUser)  // Create a new user account
-	group.GET("/users", uc.FetchUsers)         // Get all users
-	group.GET("/user/:identifier", uc.GetUser) // Get user by ID or email
-	group.PUT("/user/:id", uc.UpdateUser)      // Update basic user information (email, password, etc)
-	group.DELETE("/user/:id", uc.DeleteUser)   // Soft delete user (archive)
+	group.POST("/user/create", middleware.RequirePermission(domain.PermUserWrite), uc.CreateUser)  // Create a new user account
+	group.GET("/users", middleware.RequirePermission(domain.PermUserRead), uc.FetchUsers)          // Get all users
+	group.GET("/user/:identifier", middleware.RequirePermission(domain.PermUserRead), uc.GetUser)  // Get user by ID or email
+	group.PUT("/user/:id", middleware.RequirePermission(domain.PermUserWrite), uc.UpdateUser)      // Update basic user information (email, password, etc)
+	group.DELETE("/user/:id", middleware.RequirePermission(domain.PermUserArchive), uc.DeleteUser) // Soft delete user (archive)
 }
 
 // DOUBT: How to implement query parameters in the routes in go?
@@ -1,29 +1,53 @@
 package bootstrap
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/metrics"
 
 	"gorm.io/gorm"
 )
 
 type Application struct {
-	Env *Env
-	DB  *gorm.DB
+	Env         *Env
+	Logger      *slog.Logger
+	DB          *gorm.DB
+	Presence    domain.PresenceBroker // shared by the HTTP handlers and the background jobs
+	Shutdown    *Shutdown             // begun by serve on SIGTERM/SIGINT
+	Metrics     *metrics.Registry     // recorded by the usecases, exposed by serve on /metrics
+	RateLimiter domain.RateLimitStore // token buckets of the rate limited routes
+
+	closeTracing func(context.Context) error
 }
 
-func App() Application {
+// App carrega a configuração e conecta ao banco, migrations e seeds ficam a cargo de cada comando (ver cmd/)
+func App(source EnvSource) Application {
 	app := &Application{}
-	app.Env = NewEnv()
-	app.DB = NewDatabaseConnection(app.Env)
-
-	// Run auto-migration
-	AutoMigrate(app.DB)
-
-	RunSeeds(app.DB)
+	app.Env = NewEnv(source)
+	app.Logger = NewLogger(app.Env)
+	app.closeTracing = NewTracing(app.Env)
+	app.DB = NewDatabaseConnection(app.Env, app.Logger)
+	app.Presence = NewPresenceBroker(app.Env)
+	app.Shutdown = NewShutdown()
+	app.Metrics = metrics.New()
+	app.RateLimiter = NewRateLimitStore(app.Env)
 
 	return *app
 }
 
+// CloseTracing envia os spans pendentes ao exporter, deve ser chamado antes de CloseDBConnection
+func (app *Application) CloseTracing() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := app.closeTracing(ctx); err != nil {
+		log.Printf("Failed to flush the traces: %v", err)
+	}
+}
+
 func (app *Application) CloseDBConnection() {
 	sqlDB, err := app.DB.DB()
 	if err != nil {
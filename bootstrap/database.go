@@ -3,15 +3,20 @@ package bootstrap
 import (
 	"fmt"
 	"log"
+	"log/slog"
 
+	"github.com/gabrielfmcoelho/platform-core/internal/logger"
+	"github.com/gabrielfmcoelho/platform-core/internal/tracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-func NewDatabaseConnection(env *Env) *gorm.DB {
+func NewDatabaseConnection(env *Env, l *slog.Logger) *gorm.DB {
 	var db *gorm.DB
 	var err error
+	level, _ := logger.ParseLevel(env.LogLevel)
+	config := &gorm.Config{Logger: logger.NewGormLogger(l, level)}
 
 	log.Default().Printf("Connecting to %s database", env.DBType)
 
@@ -20,7 +25,7 @@ func NewDatabaseConnection(env *Env) *gorm.DB {
 			"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 			env.DBHost, env.DBPort, env.DBUser, env.DBPass, env.DBName,
 		)
-		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		db, err = gorm.Open(postgres.Open(dsn), config)
 		if err != nil {
 			log.Fatal("Failed to connect to database:", err)
 		}
@@ -34,7 +39,7 @@ func NewDatabaseConnection(env *Env) *gorm.DB {
 		db.Exec("SET CONSTRAINTS ALL DEFERRED;")
 	} else if env.DBType == "sqlite" {
 		dbFilePath := fmt.Sprintf("%s.db", env.DBName)
-		db, err = gorm.Open(sqlite.Open(dbFilePath), &gorm.Config{})
+		db, err = gorm.Open(sqlite.Open(dbFilePath), config)
 		// Enable foreign key constraints for SQLite
 		db.Exec("PRAGMA foreign_keys = ON;")
 	} else {
@@ -44,6 +49,10 @@ func NewDatabaseConnection(env *Env) *gorm.DB {
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
+	// every query becomes a span, child of the span in its context
+	if err := db.Use(tracing.Plugin{}); err != nil {
+		log.Fatal("Failed to set up query tracing:", err)
+	}
 
 	return db
 }
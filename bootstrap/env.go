@@ -1,94 +1,332 @@
 package bootstrap
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"net"
 	"os"
+	"reflect"
+	"sort"
+	"strings"
 
+	"github.com/gabrielfmcoelho/platform-core/internal/logger"
+	"github.com/gabrielfmcoelho/platform-core/internal/tracing"
 	"github.com/spf13/viper"
 )
 
+// Env is the configuration of the application. Fields tagged secret are never printed (see String)
 type Env struct {
-	AppEnv                 string `mapstructure:"APP_ENV"`
-	ServerAddress          string `mapstructure:"SERVER_ADDRESS"`
-	ContextTimeout         int    `mapstructure:"CONTEXT_TIMEOUT"`
-	DBType                 string `mapstructure:"DB_TYPE"`
-	DBHost                 string `mapstructure:"DB_HOST"`
-	DBPort                 string `mapstructure:"DB_PORT"`
-	DBUser                 string `mapstructure:"DB_USER"`
-	DBPass                 string `mapstructure:"DB_PASS"`
-	DBName                 string `mapstructure:"DB_NAME"`
-	AccessTokenExpiryHour  int    `mapstructure:"ACCESS_TOKEN_EXPIRY_HOUR"`
-	RefreshTokenExpiryHour int    `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
-	AccessTokenSecret      string `mapstructure:"ACCESS_TOKEN_SECRET"`
-	RefreshTokenSecret     string `mapstructure:"REFRESH_TOKEN_SECRET"`
+	AppEnv                      string   `mapstructure:"APP_ENV"`
+	LogLevel                    string   `mapstructure:"LOG_LEVEL"`
+	ServerAddress               string   `mapstructure:"SERVER_ADDRESS"`
+	ServerReadTimeoutSeconds    int      `mapstructure:"SERVER_READ_TIMEOUT_SECONDS"`
+	ServerWriteTimeoutSeconds   int      `mapstructure:"SERVER_WRITE_TIMEOUT_SECONDS"`
+	ServerIdleTimeoutSeconds    int      `mapstructure:"SERVER_IDLE_TIMEOUT_SECONDS"`
+	ShutdownTimeoutSeconds      int      `mapstructure:"SHUTDOWN_TIMEOUT_SECONDS"`
+	ShutdownDelaySeconds        int      `mapstructure:"SHUTDOWN_DELAY_SECONDS"` // /readyz fails while requests are still served
+	ContextTimeout              int      `mapstructure:"CONTEXT_TIMEOUT"`
+	DBType                      string   `mapstructure:"DB_TYPE"`
+	DBHost                      string   `mapstructure:"DB_HOST"`
+	DBPort                      string   `mapstructure:"DB_PORT"`
+	DBUser                      string   `mapstructure:"DB_USER"`
+	DBPass                      string   `mapstructure:"DB_PASS" secret:"true"`
+	DBName                      string   `mapstructure:"DB_NAME"`
+	AccessTokenExpiryHour       int      `mapstructure:"ACCESS_TOKEN_EXPIRY_HOUR"`
+	RefreshTokenExpiryHour      int      `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
+	AccessTokenSecret           string   `mapstructure:"ACCESS_TOKEN_SECRET" secret:"true"`
+	RefreshTokenSecret          string   `mapstructure:"REFRESH_TOKEN_SECRET" secret:"true"`
+	MailDriver                  string   `mapstructure:"MAIL_DRIVER"`
+	MailFrom                    string   `mapstructure:"MAIL_FROM"`
+	MailOutputDir               string   `mapstructure:"MAIL_OUTPUT_DIR"`
+	SMTPHost                    string   `mapstructure:"SMTP_HOST"`
+	SMTPPort                    string   `mapstructure:"SMTP_PORT"`
+	SMTPUser                    string   `mapstructure:"SMTP_USER"`
+	SMTPPass                    string   `mapstructure:"SMTP_PASS" secret:"true"`
+	PasswordResetURL            string   `mapstructure:"PASSWORD_RESET_URL"`
+	PasswordResetExpiryMin      int      `mapstructure:"PASSWORD_RESET_EXPIRY_MINUTES"`
+	InvitationURL               string   `mapstructure:"INVITATION_URL"`
+	InvitationExpiryHour        int      `mapstructure:"INVITATION_EXPIRY_HOUR"`
+	UsageHeartbeatMaxGapSeconds int      `mapstructure:"USAGE_HEARTBEAT_MAX_GAP_SECONDS"`
+	UsageSessionIdleSeconds     int      `mapstructure:"USAGE_SESSION_IDLE_SECONDS"`
+	PresenceDriver              string   `mapstructure:"PRESENCE_DRIVER"`
+	SkipMigrations              bool     `mapstructure:"SKIP_MIGRATIONS"`
+	SeedProfile                 string   `mapstructure:"SEED_PROFILE"`
+	MetricsAddress              string   `mapstructure:"METRICS_ADDRESS"` // separate listener for /metrics
+	MetricsToken                string   `mapstructure:"METRICS_TOKEN" secret:"true"`
+	TracingExporter             string   `mapstructure:"TRACING_EXPORTER"`
+	TracingOTLPEndpoint         string   `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingSampleRatio          float64  `mapstructure:"TRACING_SAMPLE_RATIO"`
+	TrustedProxies              []string `mapstructure:"TRUSTED_PROXIES"` // IPs or CIDRs allowed to set X-Forwarded-For
+	RateLimitDriver             string   `mapstructure:"RATE_LIMIT_DRIVER"`
+	RateLimitIPPerMinute        int      `mapstructure:"RATE_LIMIT_IP_PER_MINUTE"`
+	RateLimitUserPerMinute      int      `mapstructure:"RATE_LIMIT_USER_PER_MINUTE"`
+	RateLimitAuthIPPerMinute    int      `mapstructure:"RATE_LIMIT_AUTH_IP_PER_MINUTE"`
+	RateLimitAuthEmailPerMinute int      `mapstructure:"RATE_LIMIT_AUTH_EMAIL_PER_MINUTE"`
+	LoginLockoutThreshold       int      `mapstructure:"LOGIN_LOCKOUT_THRESHOLD"`
+	LoginLockoutSeconds         int      `mapstructure:"LOGIN_LOCKOUT_SECONDS"`
+	LoginLockoutMaxSeconds      int      `mapstructure:"LOGIN_LOCKOUT_MAX_SECONDS"`
 }
 
-// Helper function to handle writing environment variables and errors
-func writeEnvVariableToFile(file *os.File, key, value string) {
-	_, err := file.WriteString(key + "=" + value + "\n")
+// minimum length of the token secrets, production requires longer ones
+const (
+	minSecretLength           = 16
+	minProductionSecretLength = 32
+)
+
+var envDefaults = map[string]interface{}{
+	"APP_ENV":                          "development",
+	"LOG_LEVEL":                        "info",
+	"SERVER_ADDRESS":                   ":8080",
+	"SERVER_READ_TIMEOUT_SECONDS":      15,
+	"SERVER_WRITE_TIMEOUT_SECONDS":     120, // exports are streamed in the response
+	"SERVER_IDLE_TIMEOUT_SECONDS":      120,
+	"SHUTDOWN_TIMEOUT_SECONDS":         30,
+	"CONTEXT_TIMEOUT":                  2,
+	"DB_TYPE":                          "sqlite",
+	"ACCESS_TOKEN_EXPIRY_HOUR":         2,
+	"REFRESH_TOKEN_EXPIRY_HOUR":        168,
+	"MAIL_DRIVER":                      "file",
+	"MAIL_OUTPUT_DIR":                  "logs/mail",
+	"SMTP_PORT":                        "587",
+	"PASSWORD_RESET_EXPIRY_MINUTES":    30,
+	"INVITATION_EXPIRY_HOUR":           72,
+	"USAGE_HEARTBEAT_MAX_GAP_SECONDS":  120,
+	"USAGE_SESSION_IDLE_SECONDS":       300,
+	"PRESENCE_DRIVER":                  "memory",
+	"TRACING_EXPORTER":                 "none",
+	"TRACING_SAMPLE_RATIO":             1.0,
+	"RATE_LIMIT_DRIVER":                "memory",
+	"RATE_LIMIT_IP_PER_MINUTE":         600,
+	"RATE_LIMIT_USER_PER_MINUTE":       300,
+	"RATE_LIMIT_AUTH_IP_PER_MINUTE":    20,
+	"RATE_LIMIT_AUTH_EMAIL_PER_MINUTE": 5,
+	"LOGIN_LOCKOUT_THRESHOLD":          5,
+	"LOGIN_LOCKOUT_SECONDS":            60,
+	"LOGIN_LOCKOUT_MAX_SECONDS":        3600,
+}
+
+// EnvSource is where the configuration is read from besides the environment: the optional file (CONFIG_FILE
+// or .env when it exists) and the values given on the command line, which take precedence over everything
+type EnvSource struct {
+	File      string
+	Overrides map[string]string
+}
+
+// NewEnv carrega e valida a configuração, encerrando a aplicação quando ela é inválida
+func NewEnv(source EnvSource) *Env {
+	env, err := LoadEnv(source)
 	if err != nil {
-		log.Fatalf("Error writing %s to .env file: %v", key, err)
+		log.Fatalf("Invalid configuration: %v", err)
 	}
+
+	log.Printf("Environment: %s", env.AppEnv)
+	if env.AppEnv == "development" {
+		log.Println("Configuration:", env)
+	}
+	return env
 }
 
-func exportEnvToFile() {
-	// List of environment variables
-	envVars := map[string]string{
-		"APP_ENV":                   os.Getenv("APP_ENV"),
-		"SERVER_ADDRESS":            os.Getenv("SERVER_ADDRESS"),
-		"CONTEXT_TIMEOUT":           os.Getenv("CONTEXT_TIMEOUT"),
-		"DB_TYPE":                   os.Getenv("DB_TYPE"),
-		"DB_HOST":                   os.Getenv("DB_HOST"),
-		"DB_PORT":                   os.Getenv("DB_PORT"),
-		"DB_USER":                   os.Getenv("DB_USER"),
-		"DB_PASS":                   os.Getenv("DB_PASS"),
-		"DB_NAME":                   os.Getenv("DB_NAME"),
-		"ACCESS_TOKEN_EXPIRY_HOUR":  os.Getenv("ACCESS_TOKEN_EXPIRY_HOUR"),
-		"REFRESH_TOKEN_EXPIRY_HOUR": os.Getenv("REFRESH_TOKEN_EXPIRY_HOUR"),
-		"ACCESS_TOKEN_SECRET":       os.Getenv("ACCESS_TOKEN_SECRET"),
-		"REFRESH_TOKEN_SECRET":      os.Getenv("REFRESH_TOKEN_SECRET"),
-	}
-
-	// Create the .env file
-	file, err := os.Create(".env")
+// LoadEnv reads every key from, in order of precedence: the overrides, the environment variable or the file
+// named by <KEY>_FILE (for secrets mounted by the container runtime), the config file and the defaults.
+// Empty values are ignored, so a blank variable never hides the file or the default
+func LoadEnv(source EnvSource) (*Env, error) {
+	fileValues, err := readEnvFile(source.File)
 	if err != nil {
-		log.Fatal("Error creating .env file: ", err)
+		return nil, err
 	}
-	defer file.Close() // Ensure the file is closed after writing
 
-	// Write each environment variable to the file
-	for key, value := range envVars {
-		writeEnvVariableToFile(file, key, value)
+	v := viper.New()
+	var problems []string
+	known := make(map[string]bool)
+	for _, key := range envKeys() {
+		known[key] = true
+		value, ok, err := lookupEnv(key, source.Overrides, fileValues)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if ok {
+			v.Set(key, value)
+		} else if value, ok := envDefaults[key]; ok {
+			v.Set(key, value)
+		}
 	}
+	for key := range source.Overrides {
+		if !known[key] {
+			problems = append(problems, fmt.Sprintf("unknown configuration key %s", key))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+
+	env := Env{}
+	if err := v.Unmarshal(&env); err != nil {
+		return nil, err
+	}
+	if err := env.Validate(); err != nil {
+		return nil, err
+	}
+	return &env, nil
 }
 
-func NewEnv() *Env {
-	value := os.Getenv("APP_ENV")
-	log.Println("Environment variable: ", value)
+// lookupEnv returns the value of key with the highest precedence
+func lookupEnv(key string, overrides map[string]string, fileValues map[string]string) (string, bool, error) {
+	if value := overrides[key]; value != "" {
+		return value, true, nil
+	}
 
-	if _, err := os.Stat(".env"); os.IsNotExist(err) {
-		log.Println("No .env file found, creating one...")
-		exportEnvToFile()
+	value := os.Getenv(key)
+	if path := os.Getenv(key + "_FILE"); path != "" {
+		if value != "" {
+			return "", false, fmt.Errorf("%s and %s_FILE are both set", key, key)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("%s_FILE: %v", key, err)
+		}
+		value = strings.TrimRight(string(content), "\r\n")
+	}
+	if value != "" {
+		return value, true, nil
 	}
 
-	env := Env{}
-	viper.SetConfigFile(".env")
-	err := viper.ReadInConfig()
-	if err != nil {
-		log.Println("No .env file found")
+	if value := fileValues[key]; value != "" {
+		return value, true, nil
+	}
+	return "", false, nil
+}
+
+// readEnvFile reads the KEY=VALUE file, path defaults to CONFIG_FILE and then to .env when it exists
+func readEnvFile(path string) (map[string]string, error) {
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path == "" {
+		if _, err := os.Stat(".env"); err != nil {
+			return nil, nil
+		}
+		path = ".env"
 	}
 
-	err = viper.Unmarshal(&env)
-	log.Println("Environment data: ", env)
+	v := viper.New()
+	v.SetConfigFile(path)
+	if !strings.Contains(path, ".") || strings.HasSuffix(path, ".env") {
+		v.SetConfigType("env")
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("config file %s: %v", path, err)
+	}
+
+	values := make(map[string]string)
+	for _, key := range v.AllKeys() {
+		values[strings.ToUpper(key)] = v.GetString(key)
+	}
+	return values, nil
+}
 
-	if err != nil || env.AppEnv == "" {
-		log.Fatal("Error upon loading can't be loaded: ")
+// Validate checks the required fields and the ranges, every problem is reported at once
+func (e Env) Validate() error {
+	var problems []string
+	require := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
 	}
 
-	if env.AppEnv == "development" {
-		log.Println("Environment data: ", env)
-		log.Println("The App is running in development environment")
+	_, validLevel := logger.ParseLevel(e.LogLevel)
+	require(validLevel, "LOG_LEVEL must be debug, info, warn or error")
+	require(e.ServerAddress != "", "SERVER_ADDRESS is required")
+	require(e.ContextTimeout > 0, "CONTEXT_TIMEOUT must be positive")
+	require(e.ServerReadTimeoutSeconds > 0, "SERVER_READ_TIMEOUT_SECONDS must be positive")
+	require(e.ServerWriteTimeoutSeconds > 0, "SERVER_WRITE_TIMEOUT_SECONDS must be positive")
+	require(e.ServerIdleTimeoutSeconds > 0, "SERVER_IDLE_TIMEOUT_SECONDS must be positive")
+	require(e.ShutdownTimeoutSeconds > 0, "SHUTDOWN_TIMEOUT_SECONDS must be positive")
+	require(e.ShutdownDelaySeconds >= 0, "SHUTDOWN_DELAY_SECONDS can not be negative")
+
+	switch e.DBType {
+	case "postgres":
+		require(e.DBHost != "" && e.DBPort != "" && e.DBUser != "" && e.DBName != "", "DB_HOST, DB_PORT, DB_USER and DB_NAME are required for postgres")
+	case "sqlite":
+		require(e.DBName != "", "DB_NAME is required")
+	default:
+		problems = append(problems, "DB_TYPE must be postgres or sqlite")
+	}
+
+	minLength := minSecretLength
+	if e.AppEnv == "production" {
+		minLength = minProductionSecretLength
 	}
+	require(len(e.AccessTokenSecret) >= minLength, "ACCESS_TOKEN_SECRET must have at least %d characters", minLength)
+	require(len(e.RefreshTokenSecret) >= minLength, "REFRESH_TOKEN_SECRET must have at least %d characters", minLength)
+	require(e.AccessTokenSecret == "" || e.AccessTokenSecret != e.RefreshTokenSecret, "ACCESS_TOKEN_SECRET and REFRESH_TOKEN_SECRET must be different")
 
-	return &env
+	require(e.AccessTokenExpiryHour > 0, "ACCESS_TOKEN_EXPIRY_HOUR must be positive")
+	require(e.RefreshTokenExpiryHour > 0, "REFRESH_TOKEN_EXPIRY_HOUR must be positive")
+	require(e.PasswordResetExpiryMin > 0, "PASSWORD_RESET_EXPIRY_MINUTES must be positive")
+	require(e.InvitationExpiryHour > 0, "INVITATION_EXPIRY_HOUR must be positive")
+	require(e.UsageHeartbeatMaxGapSeconds > 0, "USAGE_HEARTBEAT_MAX_GAP_SECONDS must be positive")
+	require(e.UsageSessionIdleSeconds > 0, "USAGE_SESSION_IDLE_SECONDS must be positive")
+
+	switch e.MailDriver {
+	case "smtp":
+		require(e.SMTPHost != "" && e.SMTPPort != "", "SMTP_HOST and SMTP_PORT are required for the smtp MAIL_DRIVER")
+	case "file":
+	default:
+		problems = append(problems, "MAIL_DRIVER must be smtp or file")
+	}
+	require(e.PresenceDriver == "memory", "PRESENCE_DRIVER must be memory")
+	require(e.MetricsToken == "" || len(e.MetricsToken) >= minLength, "METRICS_TOKEN must have at least %d characters", minLength)
+	switch e.TracingExporter {
+	case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout:
+	default:
+		problems = append(problems, "TRACING_EXPORTER must be none, otlp or stdout")
+	}
+	require(e.TracingSampleRatio >= 0 && e.TracingSampleRatio <= 1, "TRACING_SAMPLE_RATIO must be between 0 and 1")
+	require(e.MetricsAddress == "" || e.MetricsAddress != e.ServerAddress, "METRICS_ADDRESS must differ from SERVER_ADDRESS")
+
+	for _, proxy := range e.TrustedProxies {
+		_, _, cidrErr := net.ParseCIDR(proxy)
+		require(cidrErr == nil || net.ParseIP(proxy) != nil, "TRUSTED_PROXIES: %q is not an IP or CIDR", proxy)
+	}
+	require(e.RateLimitDriver == "memory", "RATE_LIMIT_DRIVER must be memory")
+	require(e.RateLimitIPPerMinute >= 0 && e.RateLimitUserPerMinute >= 0 && e.RateLimitAuthIPPerMinute >= 0 && e.RateLimitAuthEmailPerMinute >= 0,
+		"RATE_LIMIT_*_PER_MINUTE can not be negative, 0 disables the limit")
+	require(e.LoginLockoutThreshold >= 0, "LOGIN_LOCKOUT_THRESHOLD can not be negative, 0 disables the lockout")
+	if e.LoginLockoutThreshold > 0 {
+		require(e.LoginLockoutSeconds > 0, "LOGIN_LOCKOUT_SECONDS must be positive")
+		require(e.LoginLockoutMaxSeconds >= e.LoginLockoutSeconds, "LOGIN_LOCKOUT_MAX_SECONDS must be at least LOGIN_LOCKOUT_SECONDS")
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// String lists the configuration with the secrets redacted, it is what the logs get when an Env is printed
+func (e Env) String() string {
+	value := reflect.ValueOf(e)
+	fields := make([]string, 0, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		content := fmt.Sprint(value.Field(i).Interface())
+		if field.Tag.Get("secret") == "true" && content != "" {
+			content = "[redacted]"
+		}
+		fields = append(fields, field.Tag.Get("mapstructure")+"="+content)
+	}
+	return strings.Join(fields, " ")
+}
+
+// envKeys returns the configuration keys, from the mapstructure tags of Env
+func envKeys() []string {
+	envType := reflect.TypeOf(Env{})
+	keys := make([]string, 0, envType.NumField())
+	for i := 0; i < envType.NumField(); i++ {
+		keys = append(keys, envType.Field(i).Tag.Get("mapstructure"))
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,17 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/gabrielfmcoelho/platform-core/internal/logger"
+)
+
+// NewLogger cria o logger da aplicação (JSON em produção) e o torna o padrão, as mensagens do pacote log
+// passam a sair pelo mesmo handler
+func NewLogger(env *Env) *slog.Logger {
+	level, _ := logger.ParseLevel(env.LogLevel)
+	l := logger.New(os.Stderr, level, env.AppEnv == "production")
+	slog.SetDefault(l)
+	return l
+}
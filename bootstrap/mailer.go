@@ -0,0 +1,28 @@
+package bootstrap
+
+import (
+	"log"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/mailer"
+)
+
+// NewMailer picks the Mailer implementation from MAIL_DRIVER ("smtp" or "file", the default)
+func NewMailer(env *Env) domain.Mailer {
+	switch env.MailDriver {
+	case "smtp":
+		return mailer.NewSMTPMailer(env.SMTPHost, env.SMTPPort, env.SMTPUser, env.SMTPPass, env.MailFrom)
+	case "", "file":
+		outputDir := env.MailOutputDir
+		if outputDir == "" {
+			outputDir = "logs/mail"
+		}
+		if env.AppEnv == "production" {
+			log.Println("MAIL_DRIVER is not smtp, emails will only be written to", outputDir)
+		}
+		return mailer.NewFileMailer(outputDir, env.MailFrom)
+	default:
+		log.Fatalf("Unsupported MAIL_DRIVER: %s", env.MailDriver)
+		return nil
+	}
+}
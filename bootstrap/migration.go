@@ -1,21 +1,102 @@
 package bootstrap
 
 import (
+	"context"
 	"log"
 
 	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/migrate"
+	"github.com/gabrielfmcoelho/platform-core/migrations"
 	"gorm.io/gorm"
 )
 
-func AutoMigrate(db *gorm.DB) {
+// baselineVersion is the migration holding the schema AutoMigrate created before the versioned migrations
+const baselineVersion = 1
+
+// NewMigrator carrega as migrations do dialeto do banco, adotando os bancos criados pelo AutoMigrate:
+// eles são atualizados uma última vez pelo AutoMigrate e o baseline é registrado sem ser executado
+func NewMigrator(db *gorm.DB) *migrate.Migrator {
+	migrator, err := migrate.New(db, migrations.Files)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	if !db.Migrator().HasTable("schema_migrations") && db.Migrator().HasTable(&domain.User{}) {
+		log.Println("[Migrate] Banco criado pelo AutoMigrate, adotando o baseline")
+		legacyAutoMigrate(db)
+		if err := migrator.Baseline(context.Background(), baselineVersion); err != nil {
+			log.Fatalf("Failed to adopt the migration baseline: %v", err)
+		}
+	}
+	return migrator
+}
+
+// Migrate aplica as migrations pendentes
+func Migrate(db *gorm.DB) {
+	applied, err := NewMigrator(db).Up(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to migrate the database: %v", err)
+	}
+	if applied > 0 {
+		log.Printf("[Migrate] %d migrations aplicadas\n", applied)
+	}
+}
+
+// LatestMigration retorna a última versão de migration conhecida pelo binário, sem tocar no banco
+func LatestMigration(db *gorm.DB) int64 {
+	migrator, err := migrate.New(db, migrations.Files)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	return migrator.Latest()
+}
+
+// legacyAutoMigrate brings a database created by AutoMigrate to the baseline schema, it is no longer used for new changes
+func legacyAutoMigrate(db *gorm.DB) {
 	err := db.AutoMigrate(
 		// domains like: &domain.User{},
 		&domain.User{},
+		&domain.UserBio{},
+		&domain.UserMetrics{},
+		&domain.OrganizationMetrics{},
+		&domain.UserConfig{},
+		&domain.UserServiceConfig{},
 		&domain.UserLog{},
 		&domain.UserServiceLog{},
 		&domain.Service{},
+		&domain.RefreshToken{},
+		&domain.PasswordResetToken{},
+		&domain.ServiceAccount{},
+		&domain.ServiceAccountKey{},
+		&domain.Permission{},
+		&domain.UserRole{},
+		&domain.OrganizationRole{},
+		&domain.Invitation{},
+		&domain.OrganizationSubscription{},
+		&domain.Invoice{},
+		&domain.InvoiceItem{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to auto-migrate models: %v", err)
 	}
+
+	// UserLog.UserID became optional when the audit trail started recording service account actions
+	if err := dropNotNull(db, &domain.UserLog{}, "user_id"); err != nil {
+		log.Fatalf("Failed to auto-migrate models: %v", err)
+	}
+}
+
+// dropNotNull makes a column that became optional nullable, AutoMigrate only ever adds NOT NULL constraints
+func dropNotNull(db *gorm.DB, model interface{}, column string) error {
+	columnTypes, err := db.Migrator().ColumnTypes(model)
+	if err != nil {
+		return err
+	}
+	for _, columnType := range columnTypes {
+		if nullable, ok := columnType.Nullable(); ok && !nullable && columnType.Name() == column {
+			log.Printf("[Migrate] Removendo NOT NULL de %s\n", column)
+			return db.Migrator().AlterColumn(model, column)
+		}
+	}
+	return nil
 }
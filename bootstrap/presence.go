@@ -0,0 +1,19 @@
+package bootstrap
+
+import (
+	"log"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/pubsub"
+)
+
+// NewPresenceBroker picks the PresenceBroker implementation from PRESENCE_DRIVER ("memory", the default)
+func NewPresenceBroker(env *Env) domain.PresenceBroker {
+	switch env.PresenceDriver {
+	case "", "memory":
+		return pubsub.NewMemoryBroker()
+	default:
+		log.Fatalf("Unsupported PRESENCE_DRIVER: %s", env.PresenceDriver)
+		return nil
+	}
+}
@@ -0,0 +1,30 @@
+package bootstrap
+
+import (
+	"log"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/ratelimit"
+)
+
+// NewRateLimitStore escolhe a implementação do RateLimitStore por RATE_LIMIT_DRIVER ("memory", o padrão). Na
+// memória cada instância tem os seus próprios limites
+func NewRateLimitStore(env *Env) domain.RateLimitStore {
+	switch env.RateLimitDriver {
+	case "", "memory":
+		return ratelimit.NewMemoryStore()
+	default:
+		log.Fatalf("Unsupported RATE_LIMIT_DRIVER: %s", env.RateLimitDriver)
+		return nil
+	}
+}
+
+// LoginLockoutPolicy monta a política de bloqueio por senhas erradas a partir de LOGIN_LOCKOUT_*
+func LoginLockoutPolicy(env *Env) domain.LoginLockoutPolicy {
+	return domain.LoginLockoutPolicy{
+		Threshold:   env.LoginLockoutThreshold,
+		Duration:    time.Duration(env.LoginLockoutSeconds) * time.Second,
+		MaxDuration: time.Duration(env.LoginLockoutMaxSeconds) * time.Second,
+	}
+}
@@ -7,35 +7,57 @@ import (
 	"gorm.io/gorm"
 )
 
-// RunSeeds é a função principal que chama cada seeder específico
-func RunSeeds(db *gorm.DB) {
-	// Podemos rodar dentro de uma transação, caso deseje atomicidade
-	err := db.Transaction(func(tx *gorm.DB) error {
-		if err := seeds.SeedServices(tx); err != nil {
-			return err
-		}
+// SeedProfile retorna o perfil de seeds do ambiente: SEED_PROFILE ou, quando vazio, "minimal" em produção e "demo" nos demais
+func SeedProfile(env *Env) string {
+	if env.SeedProfile != "" {
+		return env.SeedProfile
+	}
+	if env.AppEnv == "production" {
+		return "minimal"
+	}
+	return "demo"
+}
 
-		if err := seeds.SeedOrganizationRoles(tx); err != nil {
-			return err
-		}
+// RunSeeds aplica o perfil (nome de um perfil embutido ou caminho de um arquivo YAML/JSON) e os perfis que ele
+// estende, seguidos das permissões. Tudo roda em uma transação e pode ser repetido, os registros são atualizados
+// pela chave natural
+func RunSeeds(db *gorm.DB, profile string, appEnv string) error {
+	chain, err := seeds.LoadProfile(profile)
+	if err == nil {
+		err = seeds.CheckEnvironment(chain, appEnv)
+	}
+	if err != nil {
+		return err
+	}
 
-		if err := seeds.SeedOrganizations(tx); err != nil {
-			return err
-		}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range chain {
+			if err := seeds.SeedUserRoles(tx, p.UserRoles); err != nil {
+				return err
+			}
 
-		if err := seeds.SeedUserRoles(tx); err != nil {
-			return err
-		}
+			if err := seeds.SeedOrganizationRoles(tx, p.OrganizationRoles); err != nil {
+				return err
+			}
+
+			if err := seeds.SeedServices(tx, p.Services); err != nil {
+				return err
+			}
+
+			if err := seeds.SeedOrganizations(tx, p.Organizations); err != nil {
+				return err
+			}
 
-		if err := seeds.SeedUsers(tx); err != nil {
-			return err
+			if err := seeds.SeedUsers(tx, p.Users); err != nil {
+				return err
+			}
 		}
 
-		return nil
+		return seeds.SeedPermissions(tx)
 	})
 	if err != nil {
-		log.Printf("Erro ao rodar seeds: %v", err)
-	} else {
-		log.Printf("Seeds executados com sucesso!")
+		return err
 	}
+	log.Printf("Seeds do perfil %s executados com sucesso!", profile)
+	return nil
 }
@@ -1,59 +1,63 @@
 package seeds
 
 import (
+	"errors"
+	"fmt"
 	"log"
 
 	"github.com/gabrielfmcoelho/platform-core/domain"
 	"gorm.io/gorm"
 )
 
-// SeedOrganizations cria registros iniciais de organização, se não existirem
-func SeedOrganizations(db *gorm.DB) error {
-	var count int64
-	if err := db.Model(&domain.Organization{}).Count(&count).Error; err != nil {
-		return err
-	}
-
-	if count == 0 {
-		// Exemplo: suposição de que já exista um "OrganizationRole" com ID=1 e ID=2
-
-		var resistracker domain.Service
-		if err := db.Where("name = ?", "Resistracker").First(&resistracker).Error; err != nil {
-			return err
+// SeedOrganizations cria ou atualiza as organizações do perfil pelo nome e vincula os seus serviços (vínculos
+// existentes são mantidos), uma organização removida não é recriada
+func SeedOrganizations(db *gorm.DB, fixtures []OrganizationFixture) error {
+	var created, updated int
+	for _, fixture := range fixtures {
+		var role domain.OrganizationRole
+		if err := db.Where("role_name = ?", fixture.Role).First(&role).Error; err != nil {
+			return fmt.Errorf("organization %s: role %s: %w", fixture.Name, fixture.Role, err)
 		}
-
-		orgs := []domain.Organization{
-			{
-				Name:               "Solude",
-				LogoUrl:            "https://example.com/logo-acme.png",
-				RoleID:             1, // por exemplo, se 1 = "Admin"
-				SubscribedServices: []domain.Service{resistracker},
-			},
-			{
-				Name:               "Hospital São Marcos",
-				Nickname:           "HSM",
-				LogoUrl:            "https://example.com/logo-beta.png",
-				RoleID:             2, // por exemplo, se 2 = "Manager"
-				SubscribedServices: []domain.Service{resistracker},
-			},
-			{
-				Name:     "Hospital Universitário - UFPI",
-				Nickname: "HU - UFPI",
-				LogoUrl:  "https://example.com/logo-beta.png",
-				RoleID:   2,
-			},
-			{
-				Name:    "Guest Organization",
-				LogoUrl: "https://example.com/logo-beta.png",
-				RoleID:  3, // por exemplo, se 3 = "Guest"
-			},
+		services := make([]domain.Service, 0, len(fixture.Services))
+		for _, name := range fixture.Services {
+			var service domain.Service
+			if err := db.Where("name = ?", name).First(&service).Error; err != nil {
+				return fmt.Errorf("organization %s: service %s: %w", fixture.Name, name, err)
+			}
+			services = append(services, service)
 		}
 
-		if err := db.Create(&orgs).Error; err != nil {
+		var organization domain.Organization
+		err := db.Unscoped().Where("name = ?", fixture.Name).First(&organization).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			organization = domain.Organization{Name: fixture.Name, Nickname: fixture.Nickname, LogoUrl: fixture.LogoUrl, RoleID: role.ID}
+			if err := db.Create(&organization).Error; err != nil {
+				return err
+			}
+			created++
+		case err != nil:
 			return err
+		case organization.DeletedAt.Valid:
+			log.Printf("[SeedOrganizations] Organização %s foi removida, ignorada\n", fixture.Name)
+			continue
+		case organization.Nickname != fixture.Nickname || organization.LogoUrl != fixture.LogoUrl || organization.RoleID != role.ID:
+			err := db.Model(&organization).Select("Nickname", "LogoUrl", "RoleID").
+				Updates(domain.Organization{Nickname: fixture.Nickname, LogoUrl: fixture.LogoUrl, RoleID: role.ID}).Error
+			if err != nil {
+				return err
+			}
+			updated++
 		}
 
-		log.Printf("[SeedOrganizations] Criadas %d organizações (Acme, Beta)\n", len(orgs))
+		if len(services) > 0 {
+			if err := db.Model(&organization).Association("SubscribedServices").Append(services); err != nil {
+				return err
+			}
+		}
+	}
+	if created > 0 || updated > 0 {
+		log.Printf("[SeedOrganizations] Criadas %d e atualizadas %d organizações\n", created, updated)
 	}
 	return nil
 }
@@ -0,0 +1,142 @@
+package seeds
+
+import (
+	"log"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+var permissionDescriptions = []domain.Permission{
+	{Name: domain.PermServiceRead, Description: "List and view services"},
+	{Name: domain.PermServiceWrite, Description: "Create, update and delete services"},
+	{Name: domain.PermServiceLink, Description: "Make services available to organizations"},
+	{Name: domain.PermServiceUse, Description: "Start and keep service usage sessions"},
+	{Name: domain.PermUserRead, Description: "List and view users"},
+	{Name: domain.PermUserWrite, Description: "Create and update users"},
+	{Name: domain.PermUserArchive, Description: "Archive (soft delete) users"},
+	{Name: domain.PermOrgRead, Description: "List and view organizations"},
+	{Name: domain.PermOrgManage, Description: "Create, update and delete organizations"},
+	{Name: domain.PermRoleManage, Description: "Edit role permissions"},
+	{Name: domain.PermServiceAccountManage, Description: "Manage service accounts and their API keys"},
+	{Name: domain.PermUserServiceLogRead, Description: "Read service usage logs"},
+	{Name: domain.PermSubscriptionRead, Description: "View organization subscriptions"},
+	{Name: domain.PermSubscriptionManage, Description: "Create, renew and cancel organization subscriptions"},
+	{Name: domain.PermInvoiceRead, Description: "View and download invoices"},
+	{Name: domain.PermInvoiceManage, Description: "Generate, issue, pay and void invoices"},
+	{Name: domain.PermPresenceRead, Description: "Watch who is using the services in real time"},
+	{Name: domain.PermDataExport, Description: "Export usage and login history spreadsheets"},
+	{Name: domain.PermAuditRead, Description: "Read the audit trail of the organization"},
+	{Name: domain.PermPlatformAdmin, Description: "Access data of every organization"},
+}
+
+// permissões padrão de cada UserRole, o Admin recebe todas
+var defaultUserRolePermissions = map[string][]string{
+	"Manager": {
+		domain.PermServiceRead, domain.PermServiceUse,
+		domain.PermUserRead, domain.PermUserWrite, domain.PermUserArchive,
+		domain.PermOrgRead, domain.PermUserServiceLogRead, domain.PermSubscriptionRead, domain.PermInvoiceRead,
+		domain.PermPresenceRead, domain.PermDataExport,
+	},
+	"User":  {domain.PermServiceRead, domain.PermServiceUse, domain.PermOrgRead, domain.PermUserRead},
+	"Guest": {domain.PermServiceRead, domain.PermServiceUse},
+}
+
+// permissões padrão de cada OrganizationRole, o Admin recebe todas
+var defaultOrganizationRolePermissions = map[string][]string{
+	"Hospital": {
+		domain.PermServiceRead, domain.PermServiceUse,
+		domain.PermUserRead, domain.PermUserWrite, domain.PermUserArchive,
+		domain.PermOrgRead, domain.PermUserServiceLogRead, domain.PermServiceAccountManage,
+		domain.PermSubscriptionRead, domain.PermInvoiceRead, domain.PermPresenceRead, domain.PermDataExport,
+		domain.PermAuditRead, // capped by the UserRole, only the Admin users of the hospital get it
+	},
+	"Guest": {domain.PermServiceRead, domain.PermServiceUse},
+}
+
+// SeedPermissions cria as permissões que ainda não existem e associa as permissões padrão
+// aos roles que ainda não possuem nenhuma (alterações feitas em runtime são preservadas).
+// Permissões novas são sempre concedidas aos roles Admin
+func SeedPermissions(db *gorm.DB) error {
+	var created []domain.Permission
+	for _, p := range permissionDescriptions {
+		permission := p
+		result := db.Where(domain.Permission{Name: permission.Name}).Attrs(domain.Permission{Description: permission.Description}).FirstOrCreate(&permission)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			created = append(created, permission)
+		}
+	}
+	if len(created) > 0 {
+		log.Printf("[SeedPermissions] Criadas %d Permissions\n", len(created))
+	}
+
+	var permissions []domain.Permission
+	if err := db.Find(&permissions).Error; err != nil {
+		return err
+	}
+
+	var userRoles []domain.UserRole
+	if err := db.Preload("Permissions").Find(&userRoles).Error; err != nil {
+		return err
+	}
+	for i := range userRoles {
+		role := &userRoles[i]
+		if len(role.Permissions) > 0 {
+			if role.RoleName == "Admin" && len(created) > 0 {
+				if err := db.Model(role).Association("Permissions").Append(created); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		granted := selectPermissions(permissions, role.RoleName, defaultUserRolePermissions)
+		if err := db.Model(role).Association("Permissions").Replace(granted); err != nil {
+			return err
+		}
+		log.Printf("[SeedPermissions] UserRole %s recebeu %d permissões\n", role.RoleName, len(granted))
+	}
+
+	var organizationRoles []domain.OrganizationRole
+	if err := db.Preload("Permissions").Find(&organizationRoles).Error; err != nil {
+		return err
+	}
+	for i := range organizationRoles {
+		role := &organizationRoles[i]
+		if len(role.Permissions) > 0 {
+			if role.RoleName == "Admin" && len(created) > 0 {
+				if err := db.Model(role).Association("Permissions").Append(created); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		granted := selectPermissions(permissions, role.RoleName, defaultOrganizationRolePermissions)
+		if err := db.Model(role).Association("Permissions").Replace(granted); err != nil {
+			return err
+		}
+		log.Printf("[SeedPermissions] OrganizationRole %s recebeu %d permissões\n", role.RoleName, len(granted))
+	}
+
+	return nil
+}
+
+// selectPermissions retorna as permissões padrão do role (todas para o Admin)
+func selectPermissions(permissions []domain.Permission, roleName string, defaults map[string][]string) []domain.Permission {
+	if roleName == "Admin" {
+		return permissions
+	}
+	names := make(map[string]bool)
+	for _, name := range defaults[roleName] {
+		names[name] = true
+	}
+	selected := make([]domain.Permission, 0, len(names))
+	for _, p := range permissions {
+		if names[p.Name] {
+			selected = append(selected, p)
+		}
+	}
+	return selected
+}
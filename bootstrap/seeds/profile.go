@@ -0,0 +1,161 @@
+package seeds
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed profiles/*.yaml
+var profileFiles embed.FS
+
+// ErrProductionProfile is returned when a profile with demo credentials is loaded with APP_ENV=production
+var ErrProductionProfile = errors.New("seed profile is not allowed in production")
+
+// Profile is a seed fixture. Every record is upserted on its natural key (role name, service name, organization
+// name, user email), so running a profile again only applies what changed in the file
+type Profile struct {
+	Name              string                `yaml:"-"`
+	Extends           string                `yaml:"extends"`          // profile applied before this one
+	AllowProduction   bool                  `yaml:"allow_production"` // only for profiles without credentials
+	UserRoles         []string              `yaml:"user_roles"`
+	OrganizationRoles []string              `yaml:"organization_roles"`
+	Services          []ServiceFixture      `yaml:"services"`
+	Organizations     []OrganizationFixture `yaml:"organizations"`
+	Users             []UserFixture         `yaml:"users"`
+}
+
+type ServiceFixture struct {
+	Name          string  `yaml:"name"`
+	MarketingName string  `yaml:"marketing_name"`
+	Description   string  `yaml:"description"`
+	AppUrl        string  `yaml:"app_url"`
+	IconUrl       string  `yaml:"icon_url"`
+	ScreenshotUrl string  `yaml:"screenshot_url"`
+	TagLine       string  `yaml:"tag_line"`
+	Benefits      string  `yaml:"benefits"`
+	Features      string  `yaml:"features"`
+	Tags          string  `yaml:"tags"`
+	LastUpdate    string  `yaml:"last_update"`
+	Status        string  `yaml:"status"`
+	Price         float64 `yaml:"price"`
+	HourlyPrice   float64 `yaml:"hourly_price"`
+	IsMarketing   bool    `yaml:"is_marketing"`
+}
+
+type OrganizationFixture struct {
+	Name     string   `yaml:"name"`
+	Nickname string   `yaml:"nickname"`
+	LogoUrl  string   `yaml:"logo_url"`
+	Role     string   `yaml:"role"`     // OrganizationRole name
+	Services []string `yaml:"services"` // names of the subscribed services, existing links are kept
+}
+
+// UserFixture has its password set only when the user is created, later runs keep the current password
+type UserFixture struct {
+	Email        string `yaml:"email"`
+	Password     string `yaml:"password"`
+	Organization string `yaml:"organization"` // Organization name
+	Role         string `yaml:"role"`         // UserRole name
+}
+
+// ProfileNames returns the names of the embedded profiles
+func ProfileNames() []string {
+	entries, _ := fs.ReadDir(profileFiles, "profiles")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+	return names
+}
+
+// LoadProfile reads an embedded profile by name or a YAML/JSON file by path (JSON is valid YAML),
+// followed by the profiles it extends, which come first in the returned chain
+func LoadProfile(nameOrPath string) ([]Profile, error) {
+	var chain []Profile
+	seen := make(map[string]bool)
+	for next := nameOrPath; next != ""; {
+		if seen[next] {
+			return nil, fmt.Errorf("seed profile %s extends itself", next)
+		}
+		seen[next] = true
+
+		profile, err := readProfile(next)
+		if err != nil {
+			return nil, err
+		}
+		chain = append([]Profile{profile}, chain...)
+		next = profile.Extends
+	}
+	return chain, nil
+}
+
+func readProfile(nameOrPath string) (Profile, error) {
+	var content []byte
+	var err error
+	if strings.ContainsAny(nameOrPath, "./\\") {
+		content, err = os.ReadFile(nameOrPath)
+	} else {
+		content, err = profileFiles.ReadFile("profiles/" + nameOrPath + ".yaml")
+		if errors.Is(err, fs.ErrNotExist) {
+			return Profile{}, fmt.Errorf("unknown seed profile %q (available: %s)", nameOrPath, strings.Join(ProfileNames(), ", "))
+		}
+	}
+	if err != nil {
+		return Profile{}, err
+	}
+
+	profile := Profile{Name: nameOrPath}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&profile); err != nil {
+		return Profile{}, fmt.Errorf("seed profile %s: %w", nameOrPath, err)
+	}
+	if err := profile.validate(); err != nil {
+		return Profile{}, fmt.Errorf("seed profile %s: %w", nameOrPath, err)
+	}
+	return profile, nil
+}
+
+// validate checks the required natural keys, references to roles, services and organizations are
+// resolved against the database when the profile is applied
+func (p Profile) validate() error {
+	for _, service := range p.Services {
+		if service.Name == "" || service.MarketingName == "" || service.AppUrl == "" {
+			return errors.New("services need name, marketing_name and app_url")
+		}
+	}
+	for _, organization := range p.Organizations {
+		if organization.Name == "" || organization.Role == "" {
+			return errors.New("organizations need name and role")
+		}
+	}
+	for _, user := range p.Users {
+		if user.Email == "" || user.Password == "" || user.Organization == "" || user.Role == "" {
+			return errors.New("users need email, password, organization and role")
+		}
+	}
+	if p.AllowProduction && len(p.Users) > 0 {
+		return errors.New("profiles allowed in production can not have users, create them with the CLI")
+	}
+	return nil
+}
+
+// CheckEnvironment refuses profiles with demo credentials in production
+func CheckEnvironment(chain []Profile, appEnv string) error {
+	if appEnv != "production" {
+		return nil
+	}
+	for _, profile := range chain {
+		if !profile.AllowProduction {
+			return fmt.Errorf("%w: %s", ErrProductionProfile, profile.Name)
+		}
+	}
+	return nil
+}
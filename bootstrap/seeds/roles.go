@@ -7,49 +7,34 @@ import (
 	"gorm.io/gorm"
 )
 
-// SeedUserRoles cria os user roles (Admin, Manager, User, Guest) se não existirem
-func SeedUserRoles(db *gorm.DB) error {
-	var count int64
-	if err := db.Model(&domain.UserRole{}).Count(&count).Error; err != nil {
-		return err
-	}
-
-	if count == 0 {
-		roles := []domain.UserRole{
-			{RoleName: "Admin"},
-			{RoleName: "Manager"},
-			{RoleName: "User"},
-			{RoleName: "Guest"},
-		}
-
-		if err := db.Create(&roles).Error; err != nil {
-			return err
+// SeedUserRoles cria os user roles do perfil que ainda não existem (um role removido não é recriado)
+func SeedUserRoles(db *gorm.DB, names []string) error {
+	var created int64
+	for _, name := range names {
+		result := db.Unscoped().Where(domain.UserRole{RoleName: name}).FirstOrCreate(&domain.UserRole{RoleName: name})
+		if result.Error != nil {
+			return result.Error
 		}
-
-		log.Printf("[SeedUserRoles] Criados %d UserRoles (Admin, Manager, User, Guest)\n", len(roles))
+		created += result.RowsAffected
+	}
+	if created > 0 {
+		log.Printf("[SeedUserRoles] Criados %d UserRoles\n", created)
 	}
 	return nil
 }
 
-// SeedOrganizationRoles cria os organization roles (Admin, Manager, User, Guest) se não existirem
-func SeedOrganizationRoles(db *gorm.DB) error {
-	var count int64
-	if err := db.Model(&domain.OrganizationRole{}).Count(&count).Error; err != nil {
-		return err
-	}
-
-	if count == 0 {
-		roles := []domain.OrganizationRole{
-			{RoleName: "Admin"},
-			{RoleName: "Hospital"},
-			{RoleName: "Guest"},
-		}
-
-		if err := db.Create(&roles).Error; err != nil {
-			return err
+// SeedOrganizationRoles cria os organization roles do perfil que ainda não existem (um role removido não é recriado)
+func SeedOrganizationRoles(db *gorm.DB, names []string) error {
+	var created int64
+	for _, name := range names {
+		result := db.Unscoped().Where(domain.OrganizationRole{RoleName: name}).FirstOrCreate(&domain.OrganizationRole{RoleName: name})
+		if result.Error != nil {
+			return result.Error
 		}
-
-		log.Printf("[SeedOrganizationRoles] Criados %d OrganizationRoles (Admin, Manager, User, Guest)\n", len(roles))
+		created += result.RowsAffected
+	}
+	if created > 0 {
+		log.Printf("[SeedOrganizationRoles] Criados %d OrganizationRoles\n", created)
 	}
 	return nil
 }
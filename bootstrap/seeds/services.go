@@ -1,44 +1,61 @@
 package seeds
 
 import (
+	"errors"
 	"log"
+	"reflect"
 
 	"github.com/gabrielfmcoelho/platform-core/domain"
 	"gorm.io/gorm"
 )
 
-// SeedServices cria os serviços padrão (Resistracker, ...) se não existirem
-func SeedServices(db *gorm.DB) error {
-	var count int64
-	if err := db.Model(&domain.Service{}).Count(&count).Error; err != nil {
-		return err
-	}
-
-	if count == 0 {
-		services := []domain.Service{
-			{
-				MarketingName: "Resistracker",
-				Name:          "Resistracker",
-				Description:   "Acompanhamento de registros",
-				AppUrl:        "https://resistracker.solude.tech",
-				IconUrl:       "https://resistracker.solude.tech/favicon.ico",
-				ScreenshotUrl: "https://resistracker.solude.tech/screenshot.png",
-				TagLine:       "Gestão Inteligente de Resistência Bacteriana",
-				Benefits:      "Redução de 40% no tempo de identificação de padrões de resistência;Aumento de 60% na eficácia do tratamento inicial;Economia de 30% nos custos com antibióticos",
-				Features:      "Monitoramento em tempo real;Análise preditiva de resistência;Suporte à decisão clínica;Relatórios personalizados",
-				Tags:          "IA;Microbiologia;Antibióticos",
-				LastUpdate:    "2021-09-01",
-				Status:        "Online",
-				Price:         0.00,
-				IsMarketing:   true,
-			},
+// SeedServices cria ou atualiza os serviços do perfil pelo nome, um serviço removido não é recriado
+func SeedServices(db *gorm.DB, fixtures []ServiceFixture) error {
+	var created, updated int
+	for _, fixture := range fixtures {
+		desired := domain.Service{
+			Name:          fixture.Name,
+			MarketingName: fixture.MarketingName,
+			Description:   fixture.Description,
+			AppUrl:        fixture.AppUrl,
+			IconUrl:       fixture.IconUrl,
+			ScreenshotUrl: fixture.ScreenshotUrl,
+			TagLine:       fixture.TagLine,
+			Benefits:      fixture.Benefits,
+			Features:      fixture.Features,
+			Tags:          fixture.Tags,
+			LastUpdate:    fixture.LastUpdate,
+			Status:        fixture.Status,
+			Price:         fixture.Price,
+			HourlyPrice:   fixture.HourlyPrice,
+			IsMarketing:   fixture.IsMarketing,
 		}
 
-		if err := db.Create(&services).Error; err != nil {
+		var existing domain.Service
+		err := db.Unscoped().Where("name = ?", fixture.Name).First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := db.Create(&desired).Error; err != nil {
+				return err
+			}
+			created++
+		case err != nil:
 			return err
+		case existing.DeletedAt.Valid:
+			log.Printf("[SeedServices] Serviço %s foi removido, ignorado\n", fixture.Name)
+		default:
+			desired.Model = existing.Model
+			if reflect.DeepEqual(desired, existing) {
+				continue
+			}
+			if err := db.Save(&desired).Error; err != nil {
+				return err
+			}
+			updated++
 		}
-
-		log.Printf("[SeedServices] Criados %d serviços (Resistracker, ...)\n", len(services))
+	}
+	if created > 0 || updated > 0 {
+		log.Printf("[SeedServices] Criados %d e atualizados %d serviços\n", created, updated)
 	}
 	return nil
 }
@@ -1,64 +1,57 @@
-// bootstrap/seeds/user_seed.go
 package seeds
 
 import (
+	"errors"
+	"fmt"
 	"log"
 
 	"github.com/gabrielfmcoelho/platform-core/domain"
-	"github.com/gabrielfmcoelho/platform-core/internal/parser"
 	"github.com/gabrielfmcoelho/platform-core/internal/password"
 	"gorm.io/gorm"
 )
 
-// SeedUsers popula a tabela de usuários com dados iniciais
-func SeedUsers(db *gorm.DB) error {
-	// Exemplo: Verificar se já existe algum usuário
-	var count int64
-	if err := db.Model(&domain.User{}).Count(&count).Error; err != nil {
-		return err
-	}
-
-	// Se não tiver usuário nenhum, cria alguns
-	if count == 0 {
-		users := []domain.CreateUser{
-			{
-				Email:          "contato@solude.tech",
-				Password:       "admin123",
-				OrganizationID: 1,
-				RoleID:         1, // Ex: Role Admin
-			},
-			{
-				Email:          "gabrielcoelho@inovadata.tech",
-				Password:       "123",
-				OrganizationID: 1,
-				RoleID:         1, // Ex: Role User
-			},
-			{
-				Email:          "contato@hsm.com",
-				Password:       "123",
-				OrganizationID: 2,
-				RoleID:         2, // Ex: Role Manager
-			},
-			{
-				Email:          "guest@solude.tech",
-				Password:       "123",
-				OrganizationID: 4,
-				RoleID:         3, // Ex: Role Guest
-			},
+// SeedUsers cria ou atualiza os usuários do perfil pelo email. A senha só é definida na criação, um usuário
+// arquivado não é recriado
+func SeedUsers(db *gorm.DB, fixtures []UserFixture) error {
+	var created, updated int
+	for _, fixture := range fixtures {
+		var organization domain.Organization
+		if err := db.Where("name = ?", fixture.Organization).First(&organization).Error; err != nil {
+			return fmt.Errorf("user %s: organization %s: %w", fixture.Email, fixture.Organization, err)
+		}
+		var role domain.UserRole
+		if err := db.Where("role_name = ?", fixture.Role).First(&role).Error; err != nil {
+			return fmt.Errorf("user %s: role %s: %w", fixture.Email, fixture.Role, err)
 		}
 
-		for _, u := range users {
-			hashedPassword, err := password.HashPassword(u.Password)
+		var user domain.User
+		err := db.Unscoped().Where("email = ?", fixture.Email).First(&user).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			hashedPassword, err := password.HashPassword(fixture.Password)
 			if err != nil {
 				return err
 			}
-			u.Password = hashedPassword
-			user := parser.ToUser(&u)
+			user = domain.User{Email: fixture.Email, Password: hashedPassword, OrganizationID: organization.ID, RoleID: role.ID}
 			if err := db.Create(&user).Error; err != nil {
 				return err
 			}
+			created++
+		case err != nil:
+			return err
+		case user.DeletedAt.Valid:
+			log.Printf("[SeedUsers] Usuário %s foi arquivado, ignorado\n", fixture.Email)
+		case user.OrganizationID != organization.ID || user.RoleID != role.ID:
+			err := db.Model(&user).Select("OrganizationID", "RoleID").
+				Updates(domain.User{OrganizationID: organization.ID, RoleID: role.ID}).Error
+			if err != nil {
+				return err
+			}
+			updated++
 		}
-		log.Printf("Users seed executado: criados %d usuários\n", len(users))
+	}
+	if created > 0 || updated > 0 {
+		log.Printf("[SeedUsers] Criados %d e atualizados %d usuários\n", created, updated)
 	}
 	return nil
 }
@@ -0,0 +1,62 @@
+package bootstrap
+
+import (
+	"context"
+	"sync"
+)
+
+// Shutdown coordinates the graceful stop of the server. Once Begin is called the readiness check fails and
+// the long lived connections (presence WebSockets), which http.Server.Shutdown does not wait for because
+// they are hijacked, are told to close; Wait blocks until the tracked ones are done
+type Shutdown struct {
+	once    sync.Once
+	done    chan struct{}
+	tracked sync.WaitGroup
+}
+
+func NewShutdown() *Shutdown {
+	return &Shutdown{done: make(chan struct{})}
+}
+
+// Begin starts the shutdown, it can be called more than once
+func (s *Shutdown) Begin() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// Done is closed when the shutdown begins
+func (s *Shutdown) Done() <-chan struct{} {
+	return s.done
+}
+
+// Stopping reports whether the shutdown has begun
+func (s *Shutdown) Stopping() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Track registers a connection or task to be waited for, release must be called when it ends
+func (s *Shutdown) Track() (release func()) {
+	s.tracked.Add(1)
+	var once sync.Once
+	return func() { once.Do(s.tracked.Done) }
+}
+
+// Wait blocks until every tracked connection or task is released or ctx is done
+func (s *Shutdown) Wait(ctx context.Context) error {
+	finished := make(chan struct{})
+	go func() {
+		s.tracked.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
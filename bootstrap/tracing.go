@@ -0,0 +1,24 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+
+	"github.com/gabrielfmcoelho/platform-core/internal/metrics"
+	"github.com/gabrielfmcoelho/platform-core/internal/tracing"
+)
+
+// NewTracing instala o tracer provider do TRACING_EXPORTER e retorna a função que envia os spans pendentes
+func NewTracing(env *Env) func(context.Context) error {
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    env.TracingExporter,
+		Endpoint:    env.TracingOTLPEndpoint,
+		SampleRatio: env.TracingSampleRatio,
+		Version:     metrics.Version,
+		Environment: env.AppEnv,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	return shutdown
+}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gabrielfmcoelho/platform-core/internal/tokenutil"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+)
+
+// operator reúne os casos de uso reaproveitados pelos comandos administrativos. As alterações entram na
+// trilha de auditoria sem autor, identificadas pelo user agent "platform-core-cli/<comando>"
+type operator struct {
+	app           bootstrap.Application
+	ctx           context.Context
+	audit         domain.AuditUsecase
+	auth          domain.AuthUsecase
+	users         domain.UserUsecase
+	organizations domain.OrganizationUsecase
+	services      domain.ServiceUsecase
+	userRoles     domain.UserRoleRepository
+	orgRoles      domain.OrganizationRoleRepository
+	userRepo      domain.UserRepository
+}
+
+func newOperator(command string) (*operator, error) {
+	app := bootstrap.App(envSource)
+	db := app.DB
+	timeout := time.Duration(app.Env.ContextTimeout) * time.Second
+
+	requestID, err := tokenutil.GenerateTokenID()
+	if err != nil {
+		return nil, err
+	}
+	ctx := domain.WithRequestMetadata(context.Background(), domain.RequestMetadata{
+		RequestID: requestID,
+		UserAgent: "platform-core-cli/" + command,
+	})
+
+	ur := repository.NewUserRepository(db)
+	osr := repository.NewOrganizationSubscriptionRepository(db)
+	orr := repository.NewOrganizationRoleRepository(db)
+	au := usecase.NewAuditUsecase(repository.NewUserLogRepository(db), timeout)
+	return &operator{
+		app:           app,
+		ctx:           ctx,
+		audit:         au,
+		auth:          usecase.NewAuthUsecase(ur, repository.NewUserLogRepository(db), repository.NewRefreshTokenRepository(db), repository.NewPasswordResetTokenRepository(db), repository.NewLoginLockoutRepository(db), bootstrap.LoginLockoutPolicy(app.Env), bootstrap.NewMailer(app.Env), app.Metrics, timeout),
+		users:         usecase.NewUserUsecase(ur, osr, repository.NewUserRoleRepository(db), au, timeout),
+		organizations: usecase.NewOrganizationUsecase(repository.NewOrganizationRepository(db), orr, au, timeout),
+		services:      usecase.NewServiceUsecase(repository.NewServiceRepository(db), repository.NewUserServiceLogRepository(db), ur, osr, app.Presence, au, app.Metrics, timeout),
+		userRoles:     repository.NewUserRoleRepository(db),
+		orgRoles:      orr,
+		userRepo:      ur,
+	}, nil
+}
+
+func (o *operator) close() {
+	o.app.CloseTracing()
+	o.app.CloseDBConnection()
+}
+
+// runUser executa user create | reset-password | set-role | unlock
+func runUser(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: user create | reset-password | set-role | unlock")
+	}
+	flags := flag.NewFlagSet("user "+args[0], flag.ContinueOnError)
+	email := flags.String("email", "", "email of the new user")
+	userFlag := flags.String("user", "", "user ID or email")
+	org := flags.String("org", "", "organization ID or name")
+	role := flags.String("role", "", "user role ID or name")
+	rawPassword := flags.String("password", "", "password, generated and printed when omitted")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "create":
+		if *email == "" || *org == "" || *role == "" {
+			return errors.New("usage: user create --email e --org o --role r [--password p]")
+		}
+	case "reset-password":
+		if *userFlag == "" {
+			return errors.New("usage: user reset-password --user u [--password p]")
+		}
+	case "set-role":
+		if *userFlag == "" || *role == "" {
+			return errors.New("usage: user set-role --user u --role r")
+		}
+	case "unlock":
+		if *userFlag == "" {
+			return errors.New("usage: user unlock --user u")
+		}
+	default:
+		return fmt.Errorf("unknown user command %q", args[0])
+	}
+
+	o, err := newOperator("user-" + args[0])
+	if err != nil {
+		return err
+	}
+	defer o.close()
+
+	switch args[0] {
+	case "create":
+		organization, err := o.organizations.GetByIdentifier(o.ctx, *org)
+		if err != nil {
+			return fmt.Errorf("organization %s: %w", *org, err)
+		}
+		roleID, err := o.userRoleID(*role)
+		if err != nil {
+			return err
+		}
+		generated, err := passwordOrGenerated(rawPassword)
+		if err != nil {
+			return err
+		}
+		err = o.users.Create(o.ctx, &domain.CreateUser{Email: *email, Password: *rawPassword, OrganizationID: organization.ID, RoleID: roleID})
+		if err != nil {
+			return err
+		}
+		user, err := o.users.GetByIdentifier(o.ctx, *email)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("user %d created: %s, organization %s, role %d\n", user.ID, user.Email, organization.Name, user.RoleID)
+		printGenerated(generated, *rawPassword)
+
+	case "reset-password":
+		user, err := o.users.GetByIdentifier(o.ctx, *userFlag)
+		if err != nil {
+			return fmt.Errorf("user %s: %w", *userFlag, err)
+		}
+		generated, err := passwordOrGenerated(rawPassword)
+		if err != nil {
+			return err
+		}
+		if err := o.users.Update(o.ctx, user.ID, &domain.UpdateUser{Password: rawPassword}); err != nil {
+			return err
+		}
+		// as sessões abertas com a senha antiga são encerradas e o bloqueio por falhas de login é removido, como na
+		// redefinição por email
+		if err := o.auth.RevokeSessions(o.ctx, user.ID); err != nil {
+			return err
+		}
+		if err := o.auth.Unlock(o.ctx, user.ID); err != nil {
+			return err
+		}
+		fmt.Printf("password of user %d (%s) reset, its sessions were revoked\n", user.ID, user.Email)
+		printGenerated(generated, *rawPassword)
+
+	case "set-role":
+		user, err := o.users.GetByIdentifier(o.ctx, *userFlag)
+		if err != nil {
+			return fmt.Errorf("user %s: %w", *userFlag, err)
+		}
+		roleID, err := o.userRoleID(*role)
+		if err != nil {
+			return err
+		}
+		if err := o.users.Update(o.ctx, user.ID, &domain.UpdateUser{RoleID: &roleID}); err != nil {
+			return err
+		}
+		fmt.Printf("user %d (%s) now has role %d\n", user.ID, user.Email, roleID)
+
+	case "unlock":
+		user, err := o.users.GetByIdentifier(o.ctx, *userFlag)
+		if err != nil {
+			return fmt.Errorf("user %s: %w", *userFlag, err)
+		}
+		if err := o.auth.Unlock(o.ctx, user.ID); err != nil {
+			return err
+		}
+		fmt.Printf("user %d (%s) unlocked, its failed logins were cleared\n", user.ID, user.Email)
+	}
+	return nil
+}
+
+// runOrg executa org create
+func runOrg(args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return errors.New("usage: org create --name n --role r [--nickname n] [--logo-url url]")
+	}
+	flags := flag.NewFlagSet("org create", flag.ContinueOnError)
+	name := flags.String("name", "", "organization name")
+	role := flags.String("role", "", "organization role ID or name")
+	nickname := flags.String("nickname", "", "short name")
+	logoURL := flags.String("logo-url", "", "logo URL")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *name == "" || *role == "" {
+		return errors.New("usage: org create --name n --role r [--nickname n] [--logo-url url]")
+	}
+
+	o, err := newOperator("org-create")
+	if err != nil {
+		return err
+	}
+	defer o.close()
+
+	roleID, err := o.organizationRoleID(*role)
+	if err != nil {
+		return err
+	}
+	organization, err := o.organizations.Create(o.ctx, &domain.CreateOrganization{Name: *name, Nickname: *nickname, LogoUrl: *logoURL, OrganizationRoleID: roleID})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("organization %d created: %s\n", organization.ID, organization.Name)
+	return nil
+}
+
+// runService executa service link
+func runService(args []string) error {
+	if len(args) == 0 || args[0] != "link" {
+		return errors.New("usage: service link --service s --org o")
+	}
+	flags := flag.NewFlagSet("service link", flag.ContinueOnError)
+	serviceFlag := flags.String("service", "", "service ID or name")
+	org := flags.String("org", "", "organization ID or name")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *serviceFlag == "" || *org == "" {
+		return errors.New("usage: service link --service s --org o")
+	}
+
+	o, err := newOperator("service-link")
+	if err != nil {
+		return err
+	}
+	defer o.close()
+
+	service, err := o.services.GetByIdentifier(o.ctx, *serviceFlag)
+	if err != nil {
+		return fmt.Errorf("service %s: %w", *serviceFlag, err)
+	}
+	organization, err := o.organizations.GetByIdentifier(o.ctx, *org)
+	if err != nil {
+		return fmt.Errorf("organization %s: %w", *org, err)
+	}
+	if err := o.services.SetAvailabilityToOrganization(o.ctx, service.ID, organization.ID); err != nil {
+		return err
+	}
+	fmt.Printf("service %s is now available to %s\n", service.Name, organization.Name)
+	return nil
+}
+
+// runToken executa token mint: emite um access token de um usuário, registrado na trilha de auditoria
+func runToken(args []string) error {
+	if len(args) == 0 || args[0] != "mint" {
+		return errors.New("usage: token mint --user u [--expiry-hours h]")
+	}
+	flags := flag.NewFlagSet("token mint", flag.ContinueOnError)
+	userFlag := flags.String("user", "", "user ID or email")
+	expiry := flags.Int("expiry-hours", 0, "validity in hours, ACCESS_TOKEN_EXPIRY_HOUR when omitted")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *userFlag == "" || *expiry < 0 {
+		return errors.New("usage: token mint --user u [--expiry-hours h]")
+	}
+
+	o, err := newOperator("token-mint")
+	if err != nil {
+		return err
+	}
+	defer o.close()
+
+	publicUser, err := o.users.GetByIdentifier(o.ctx, *userFlag)
+	if err != nil {
+		return fmt.Errorf("user %s: %w", *userFlag, err)
+	}
+	user, err := o.userRepo.GetByID(o.ctx, publicUser.ID)
+	if err != nil {
+		return err
+	}
+	if *expiry == 0 {
+		*expiry = o.app.Env.AccessTokenExpiryHour
+	}
+	accessToken, err := o.auth.CreateAccessToken(&user, o.app.Env.AccessTokenSecret, *expiry)
+	if err != nil {
+		return err
+	}
+
+	o.audit.Record(o.ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionTokenMint,
+		EntityType: domain.UserLogEntityUser,
+		EntityID:   fmt.Sprint(user.ID),
+		After:      map[string]interface{}{"expiry_hours": *expiry},
+	})
+	fmt.Println(accessToken)
+	return nil
+}
+
+// userRoleID resolve um UserRole por ID ou nome
+func (o *operator) userRoleID(identifier string) (uint, error) {
+	var role domain.UserRole
+	var err error
+	if internal.IsNumeric(identifier) {
+		id, _ := internal.ParseUint(identifier)
+		role, err = o.userRoles.GetByID(o.ctx, id)
+	} else {
+		role, err = o.userRoles.GetByRoleName(o.ctx, identifier)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("user role %s: %w", identifier, err)
+	}
+	return role.ID, nil
+}
+
+// organizationRoleID resolve um OrganizationRole por ID ou nome
+func (o *operator) organizationRoleID(identifier string) (uint, error) {
+	var role domain.OrganizationRole
+	var err error
+	if internal.IsNumeric(identifier) {
+		id, _ := internal.ParseUint(identifier)
+		role, err = o.orgRoles.GetByID(o.ctx, id)
+	} else {
+		role, err = o.orgRoles.GetByRoleName(o.ctx, identifier)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("organization role %s: %w", identifier, err)
+	}
+	return role.ID, nil
+}
+
+// passwordOrGenerated preenche a senha com uma aleatória quando ela não foi informada
+func passwordOrGenerated(rawPassword *string) (bool, error) {
+	if *rawPassword != "" {
+		return false, nil
+	}
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return false, err
+	}
+	*rawPassword = hex.EncodeToString(b)
+	return true, nil
+}
+
+func printGenerated(generated bool, rawPassword string) {
+	if generated {
+		fmt.Printf("generated password: %s\n", rawPassword)
+	}
+}
@@ -1,13 +1,14 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"log"
-	"time"
+	"os"
+	"strings"
 
-	"github.com/gabrielfmcoelho/platform-core/api/route"
 	"github.com/gabrielfmcoelho/platform-core/bootstrap"
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
 )
 
 // @title           Platform API
@@ -30,37 +31,82 @@ import (
 // @externalDocs.description  OpenAPI
 // @externalDocs.url          https://swagger.io/resources/open-api/
 func main() {
-	// Initialize the application
-	app := bootstrap.App()
-	defer app.CloseDBConnection()
-
-	// Configuration variables
-	env := app.Env
-
-	// Database instance (Gorm DB)
-	db := app.DB
-
-	// Context timeout
-	timeout := time.Duration(env.ContextTimeout) * time.Second
-
-	// Create a Gin router instance
-	router := gin.Default()
-
-	// CORS
-	router.Use(cors.New(cors.Config{
-		//AllowAllOrigins: true,
-		AllowOrigins:     []string{"http://localhost:3000"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"*"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	}))
-
-	// Route binding
-	route.Setup(env, timeout, db, router)
-
-	// Run the server
-	if err := router.Run(env.ServerAddress); err != nil {
-		log.Fatalf("Failed to run server: %v", err)
+	// Configuration given before the command, it takes precedence over the environment and the config file
+	global := flag.NewFlagSet("platform-core", flag.ExitOnError)
+	global.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	global.StringVar(&envSource.File, "config", "", "config file, CONFIG_FILE or .env when omitted")
+	global.Var(overrideFlag(envSource.Overrides), "set", "configuration value KEY=VALUE, may be repeated")
+	global.Parse(os.Args[1:])
+
+	name, args := "serve", global.Args()
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	command, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", name, usage)
+		os.Exit(2)
+	}
+	if err := command(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		log.Fatal(err)
 	}
 }
+
+// envSource é a origem da configuração de todos os comandos
+var envSource = bootstrap.EnvSource{Overrides: make(map[string]string)}
+
+// overrideFlag lê os valores de --set KEY=VALUE
+type overrideFlag map[string]string
+
+func (o overrideFlag) String() string {
+	return ""
+}
+
+func (o overrideFlag) Set(value string) error {
+	key, content, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return errors.New("expected KEY=VALUE")
+	}
+	o[strings.ToUpper(key)] = content
+	return nil
+}
+
+// commands são os subcomandos do binário, sem subcomando a API é iniciada (serve)
+var commands = map[string]func(args []string) error{
+	"serve":   runServe,
+	"migrate": runMigrate,
+	"seed":    runSeed,
+	"user":    runUser,
+	"org":     runOrg,
+	"service": runService,
+	"token":   runToken,
+	"help": func([]string) error {
+		fmt.Print(usage)
+		return nil
+	},
+}
+
+const usage = `usage: platform-core [--config file] [--set KEY=VALUE ...] <command> [arguments]
+
+commands:
+  serve [--no-migrate] [--no-seed]                  run the API (default)
+  migrate up | down [steps] | to <version> | status manage the schema migrations
+  seed [--profile name|file]                        seed the database (profiles: minimal, demo, test)
+  user create --email e --org o --role r [--password p]
+  user reset-password --user u [--password p]
+  user set-role --user u --role r
+  user unlock --user u
+  org create --name n --role r [--nickname n] [--logo-url url]
+  service link --service s --org o
+  token mint --user u [--expiry-hours h]
+
+Users, organizations, services and roles are given by ID or by email/name.
+A random password is generated and printed when --password is omitted.
+
+Configuration precedence: --set, environment variables (or KEY_FILE holding
+the value, for secrets), the config file, the defaults.
+`
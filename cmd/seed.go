@@ -0,0 +1,26 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap/seeds"
+)
+
+// runSeed executa os seeds de um perfil sem subir a API
+func runSeed(args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	profile := flags.String("profile", "", "profile name ("+strings.Join(seeds.ProfileNames(), ", ")+") or path of a YAML/JSON file, SEED_PROFILE when omitted")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	app := bootstrap.App(envSource)
+	defer app.CloseDBConnection()
+	defer app.CloseTracing()
+	if *profile == "" {
+		*profile = bootstrap.SeedProfile(app.Env)
+	}
+	return bootstrap.RunSeeds(app.DB, *profile, app.Env.AppEnv)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/api/middleware"
+	"github.com/gabrielfmcoelho/platform-core/api/route"
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/job"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// runServe inicia a API e as tarefas em segundo plano, aplicando antes as migrations pendentes e os seeds
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	noMigrate := flags.Bool("no-migrate", false, "do not apply the pending migrations (also SKIP_MIGRATIONS=true)")
+	noSeed := flags.Bool("no-seed", false, "do not run the seeds")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Initialize the application
+	app := bootstrap.App(envSource)
+	defer app.CloseDBConnection()
+	defer app.CloseTracing()
+
+	// Configuration variables
+	env := app.Env
+
+	// Database instance (Gorm DB)
+	db := app.DB
+
+	// Apply the pending migrations, skipped when the deploy runs them itself (migrate up)
+	if !*noMigrate && !env.SkipMigrations {
+		bootstrap.Migrate(db)
+	}
+	// a failed seed is logged but does not stop the API
+	if !*noSeed {
+		if err := bootstrap.RunSeeds(db, bootstrap.SeedProfile(env), env.AppEnv); err != nil {
+			log.Printf("Erro ao rodar seeds: %v", err)
+		}
+	}
+
+	// Context timeout
+	timeout := time.Duration(env.ContextTimeout) * time.Second
+
+	// Create a Gin router instance, every request is traced, logged through the application logger and measured
+	if env.AppEnv == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	router := gin.New()
+	router.Use(middleware.Tracing(), middleware.RequestLogger(app.Logger), middleware.Recovery(app.Logger), middleware.HTTPMetrics(app.Metrics))
+	// Let c.Value() fall back to the request context, where the auth middleware stores the domain.Principal
+	router.ContextWithFallback = true
+	// The client IP (audit trail, rate limits) comes from X-Forwarded-For only when sent by TRUSTED_PROXIES
+	if err := router.SetTrustedProxies(env.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+
+	// CORS
+	router.Use(cors.New(cors.Config{
+		//AllowAllOrigins: true,
+		AllowOrigins:     []string{"http://localhost:3000"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"*"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}))
+
+	// Database pool and usage session metrics, the HTTP, login and heartbeat ones are recorded as they happen
+	if sqlDB, err := db.DB(); err == nil {
+		app.Metrics.RegisterDB(sqlDB, env.DBName)
+	}
+	app.Metrics.RegisterActiveSessions(repository.NewUserServiceLogRepository(db).CountActiveByService)
+
+	// Route binding
+	route.Setup(env, timeout, db, app.Presence, app.Shutdown, app.Metrics, app.RateLimiter, router)
+
+	// Background jobs (e.g. daily deactivation of expired subscriptions)
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	job.Setup(jobsCtx, env, timeout, db, app.Presence, app.Metrics, app.Shutdown)
+
+	// Run the server until SIGTERM/SIGINT
+	server := &http.Server{
+		Addr:              env.ServerAddress,
+		Handler:           router,
+		ReadTimeout:       time.Duration(env.ServerReadTimeoutSeconds) * time.Second,
+		ReadHeaderTimeout: time.Duration(env.ServerReadTimeoutSeconds) * time.Second,
+		WriteTimeout:      time.Duration(env.ServerWriteTimeoutSeconds) * time.Second,
+		IdleTimeout:       time.Duration(env.ServerIdleTimeoutSeconds) * time.Second,
+	}
+	servers := []*http.Server{server}
+	if env.MetricsAddress != "" {
+		servers = append(servers, newMetricsServer(app))
+	}
+	serverErr := make(chan error, len(servers))
+	for _, s := range servers {
+		go func(s *http.Server) {
+			log.Printf("Listening on %s", s.Addr)
+			serverErr <- s.ListenAndServe()
+		}(s)
+	}
+
+	signals, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+	select {
+	case err := <-serverErr:
+		app.Shutdown.Begin()
+		return fmt.Errorf("failed to run server: %w", err)
+	case <-signals.Done():
+	}
+	// a second signal stops the process right away
+	stopSignals()
+
+	return shutdown(app, servers, stopJobs)
+}
+
+// newMetricsServer serve o /metrics em METRICS_ADDRESS, fora do endereço público da API. METRICS_TOKEN, quando
+// definido, também é exigido aqui
+func newMetricsServer(app bootstrap.Application) *http.Server {
+	router := gin.New()
+	router.Use(middleware.Recovery(app.Logger))
+	handlers := []gin.HandlerFunc{gin.WrapH(app.Metrics.Handler())}
+	if app.Env.MetricsToken != "" {
+		handlers = append([]gin.HandlerFunc{middleware.RequireMetricsToken(app.Env.MetricsToken)}, handlers...)
+	}
+	router.GET("/metrics", handlers...)
+
+	return &http.Server{
+		Addr:              app.Env.MetricsAddress,
+		Handler:           router,
+		ReadHeaderTimeout: time.Duration(app.Env.ServerReadTimeoutSeconds) * time.Second,
+		WriteTimeout:      time.Duration(app.Env.ServerWriteTimeoutSeconds) * time.Second,
+		IdleTimeout:       time.Duration(app.Env.ServerIdleTimeoutSeconds) * time.Second,
+	}
+}
+
+// shutdown encerra a API: o /readyz passa a falhar e os WebSockets são fechados, o servidor segue aceitando
+// requisições por SHUTDOWN_DELAY_SECONDS (até o orquestrador tirar a instância do balanceamento), as em andamento
+// terminam, as tarefas em segundo plano param e só então a conexão com o banco é fechada (pelo defer de runServe)
+func shutdown(app bootstrap.Application, servers []*http.Server, stopJobs context.CancelFunc) error {
+	app.Shutdown.Begin()
+	if delay := time.Duration(app.Env.ShutdownDelaySeconds) * time.Second; delay > 0 {
+		log.Printf("Shutting down in %s", delay)
+		time.Sleep(delay)
+	}
+
+	timeout := time.Duration(app.Env.ShutdownTimeoutSeconds) * time.Second
+	log.Printf("Shutting down, waiting up to %s for requests and jobs", timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var err error
+	for _, server := range servers {
+		if shutdownErr := server.Shutdown(ctx); shutdownErr != nil && err == nil {
+			err = fmt.Errorf("failed to drain requests: %w", shutdownErr)
+		}
+	}
+	stopJobs()
+	if waitErr := app.Shutdown.Wait(ctx); waitErr != nil && err == nil {
+		err = fmt.Errorf("failed to drain connections and jobs: %w", waitErr)
+	}
+	if err == nil {
+		log.Println("Server stopped")
+	}
+	return err
+}
@@ -23,12 +23,25 @@ type RefreshTokenResponse struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,min=8"`
+}
+
 type AuthUsecase interface {
-	LoginUserByEmail(ctx context.Context, email string, password string, accessSecret string, accessExpiry int, refreshSecret string, refreshExpiry int) (loginResponse *LoginResponse, err error)
-	LoginGuestUser(ctx context.Context, accessSecret string, accessExpiry int, refreshSecret string, refreshExpiry int) (loginResponse *LoginResponse, err error)
+	LoginUserByEmail(ctx context.Context, email string, password string, ipAddress string, accessSecret string, accessExpiry int, refreshSecret string, refreshExpiry int) (loginResponse *LoginResponse, err error)
+	LoginGuestUser(ctx context.Context, ipAddress string, accessSecret string, accessExpiry int, refreshSecret string, refreshExpiry int) (loginResponse *LoginResponse, err error)
 	CreateAccessToken(user *User, accessSecret string, accessExpiry int) (accessToken string, err error)
-	CreateRefreshToken(user *User, refreshSecret string, refreshExpiry int) (refreshToken string, err error)
+	CreateRefreshToken(ctx context.Context, user *User, familyID string, refreshSecret string, refreshExpiry int) (refreshToken string, err error)
+	RefreshToken(ctx context.Context, refreshToken string, accessSecret string, accessExpiry int, refreshSecret string, refreshExpiry int) (refreshTokenResponse *RefreshTokenResponse, err error)
 
-	ForgotPassword(ctx context.Context, email string) (err error)
-	ResetPassword(ctx context.Context, email string, newPassword string) (err error)
+	ForgotPassword(ctx context.Context, email string, resetURL string, resetExpiry int) (err error)
+	ResetPassword(ctx context.Context, token string, newPassword string) (err error)
+	RevokeSessions(ctx context.Context, userID uint) (err error)
+	// Unlock clears the failed logins of the user and the lockout they caused
+	Unlock(ctx context.Context, userID uint) (err error)
 }
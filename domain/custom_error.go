@@ -7,15 +7,42 @@ import (
 )
 
 var (
-	ErrUserEmailNotFound     = errors.New("user email not found")
-	ErrUserAlreadyExists     = errors.New("user already exists")
-	ErrUserWebUnauthorized   = errors.New("user unauthorized to login via web")
-	ErrUserPasswordNotMatch  = errors.New("password does not match")
-	ErrUnauthorized          = errors.New("unauthorized by the system")
-	ErrNotFound              = errors.New("not found")
-	ErrInternalServerError   = errors.New("internal server error")
-	ErrDataBaseInternalError = errors.New("database internal error")
-	ErrInvalidIdentifier     = errors.New("invalid identifier (email or id)")
-	ErrInvalidNumberToParse  = errors.New("invalid number to parse")
-	ErrCategoryAlreadyExists = errors.New("category already exists")
+	ErrUserEmailNotFound      = errors.New("user email not found")
+	ErrUserAlreadyExists      = errors.New("user already exists")
+	ErrUserWebUnauthorized    = errors.New("user unauthorized to login via web")
+	ErrUserPasswordNotMatch   = errors.New("password does not match")
+	ErrUnauthorized           = errors.New("unauthorized by the system")
+	ErrNotFound               = errors.New("not found")
+	ErrInternalServerError    = errors.New("internal server error")
+	ErrDataBaseInternalError  = errors.New("database internal error")
+	ErrInvalidIdentifier      = errors.New("invalid identifier (email or id)")
+	ErrInvalidNumberToParse   = errors.New("invalid number to parse")
+	ErrCategoryAlreadyExists  = errors.New("category already exists")
+	ErrInvalidRefreshToken    = errors.New("invalid refresh token")
+	ErrRefreshTokenReused     = errors.New("refresh token reuse detected")
+	ErrInvalidResetToken      = errors.New("invalid or expired password reset token")
+	ErrInvalidAPIKey          = errors.New("invalid, expired or revoked api key")
+	ErrForbidden              = errors.New("forbidden: missing permission")
+	ErrUnknownPermission      = errors.New("unknown permission")
+	ErrOutOfOrganization      = errors.New("resource belongs to another organization")
+	ErrOrganizationExists     = errors.New("organization already exists")
+	ErrInvalidOrganization    = errors.New("invalid organization role")
+	ErrInvalidUserRole        = errors.New("invalid user role")
+	ErrInvalidInvitation      = errors.New("invalid, expired or revoked invitation")
+	ErrInvitationExists       = errors.New("a pending invitation already exists for this email")
+	ErrSubscriptionExists     = errors.New("organization already has a subscription, renew it instead")
+	ErrSubscriptionInactive   = errors.New("organization subscription is inactive or expired")
+	ErrSubscriptionUsersLimit = errors.New("organization reached the users limit of its subscription")
+	ErrInvoiceExists          = errors.New("an issued invoice already exists for this organization and period")
+	ErrInvalidInvoiceStatus   = errors.New("operation not allowed for the current invoice status")
+	ErrInvalidBillingPeriod   = errors.New("invalid billing period, expected YYYY-MM")
+	ErrInvalidInvoiceFormat   = errors.New("invalid invoice format, expected html or pdf")
+	ErrUsageSessionOwner      = errors.New("usage session belongs to another user")
+	ErrUsageSessionClosed     = errors.New("usage session is already closed")
+	ErrInvalidAnalyticsQuery  = errors.New("invalid analytics query, check the dates (YYYY-MM-DD), bucket and group_by")
+	ErrInvalidExportFormat    = errors.New("invalid export format, expected csv or xlsx")
+	ErrInvalidAuditQuery      = errors.New("invalid audit query, check the cursor, limit and sort")
+	ErrGuestLoginUnavailable  = errors.New("guest login is not available, no user has the Guest role")
+	ErrTooManyRequests        = errors.New("too many requests, try again later")
+	ErrAccountLocked          = errors.New("account temporarily locked after too many failed logins, try again later")
 )
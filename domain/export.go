@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Exports stream the usage sessions (UserServiceLog) and the login history (UserLog) as spreadsheets,
+// the rows are read from the database and written to the response one by one.
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+type ExportFilter struct {
+	From           time.Time // inclusive, zero for no lower bound
+	To             time.Time // exclusive, zero for no upper bound
+	OrganizationID uint
+	UserID         uint
+	ServiceID      uint // usage only
+}
+
+type UsageExportRow struct {
+	LogID            uint
+	UserID           uint
+	UserEmail        string
+	OrganizationID   uint
+	OrganizationName string
+	ServiceID        uint
+	ServiceName      string
+	Status           string
+	StartedAt        time.Time
+	EndedAt          *time.Time
+	Duration         int // seconds
+}
+
+type LoginExportRow struct {
+	LogID            uint
+	UserID           uint
+	UserEmail        string
+	OrganizationID   uint
+	OrganizationName string
+	Action           string
+	IPAddress        string
+	CreatedAt        time.Time
+}
+
+type ExportUsecase interface {
+	// ExportUsage writes the usage sessions started in the period, oldest first
+	ExportUsage(ctx context.Context, filter ExportFilter, format string, w io.Writer) error
+	// ExportLogins writes the login history in the period, oldest first
+	ExportLogins(ctx context.Context, filter ExportFilter, format string, w io.Writer) error
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "context"
+
+// Readiness is the answer of /readyz: the instance receives traffic only when every check is ok.
+// Liveness (/healthz) has no checks, it only tells the process is serving requests
+
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+type HealthCheck struct {
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type Readiness struct {
+	Status string                 `json:"status"`
+	Checks map[string]HealthCheck `json:"checks"`
+}
+
+// Ready reports whether every check is ok
+func (r Readiness) Ready() bool {
+	return r.Status == HealthStatusOK
+}
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	// SchemaVersion returns the last applied migration, read without taking the migration lock
+	SchemaVersion(ctx context.Context) (int64, error)
+}
+
+type HealthUsecase interface {
+	Readiness(ctx context.Context) Readiness
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MANY TO ONE WITH ORGANIZATION
+// MANY TO ONE WITH USER ROLE
+// Like PasswordResetToken only the hash of the token is stored, the raw token travels exclusively in the email link.
+
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+	InvitationStatusExpired  = "expired"
+)
+
+type Invitation struct {
+	gorm.Model
+	Email          string       `gorm:"size:255;not null;Index"`
+	OrganizationID uint         `gorm:"not null;Index"`
+	Organization   Organization `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	RoleID         uint         `gorm:"not null"`
+	Role           UserRole     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	InvitedByID    uint         `gorm:"Index"` // user that sent (or last resent) the invitation
+	TokenHash      string       `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt      time.Time    `gorm:"not null"`
+	AcceptedAt     *time.Time
+	RevokedAt      *time.Time
+	UserID         *uint // user created when the invitation was accepted
+}
+
+// Status derives the state of the invitation from its timestamps
+func (i Invitation) Status() string {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationStatusAccepted
+	case i.RevokedAt != nil:
+		return InvitationStatusRevoked
+	case time.Now().After(i.ExpiresAt):
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
+
+type CreateInvitation struct {
+	Email  string `json:"email" binding:"required,email"`
+	RoleID uint   `json:"role_id" binding:"required"`
+}
+
+type AcceptInvitationRequest struct {
+	Token     string `json:"token" binding:"required"`
+	Password  string `json:"password" binding:"required,min=8"`
+	FirstName string `json:"first_name" binding:"required"`
+	SurName   string `json:"sur_name"`
+	Position  string `json:"position"`
+	Phone     string `json:"phone"`
+}
+
+type PublicInvitation struct {
+	ID               uint      `json:"id"`
+	Email            string    `json:"email"`
+	OrganizationID   uint      `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	RoleID           uint      `json:"role_id"`
+	RoleName         string    `json:"role_name"`
+	Status           string    `json:"status"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *Invitation) error
+	GetByID(ctx context.Context, id uint) (Invitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (Invitation, error)
+	GetPendingByEmail(ctx context.Context, organizationID uint, email string) (Invitation, error)
+	FetchPendingByOrganization(ctx context.Context, organizationID uint) ([]Invitation, error)
+	RenewToken(ctx context.Context, invitationID uint, invitedByID uint, tokenHash string, expiresAt time.Time) error
+	Revoke(ctx context.Context, invitationID uint) error
+	// Accept consumes the invitation and creates the user with its bio, metrics and config in a single transaction
+	Accept(ctx context.Context, invitationID uint, user *User) error
+}
+
+type InvitationUsecase interface {
+	Create(ctx context.Context, organizationID uint, invitation *CreateInvitation, inviteURL string, expiryHours int) (PublicInvitation, error)
+	FetchPending(ctx context.Context, organizationID uint) ([]PublicInvitation, error)
+	Resend(ctx context.Context, invitationID uint, inviteURL string, expiryHours int) (PublicInvitation, error)
+	Revoke(ctx context.Context, invitationID uint) error
+	Accept(ctx context.Context, request *AcceptInvitationRequest) (PublicUser, error)
+}
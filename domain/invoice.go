@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MANY TO ONE WITH ORGANIZATION
+// ONE TO MANY WITH INVOICE ITEM
+// An invoice bills one organization for one billing period (a calendar month). Each service the
+// organization is subscribed to (organization_services) becomes a line item with the flat Service.Price
+// plus, when Service.HourlyPrice is set, the hours logged in UserServiceLog during the period.
+// Lifecycle: draft -> issued -> paid, drafts and issued invoices can be voided. Drafts are recomputed
+// when the period is generated again, issued invoices are never changed.
+
+const (
+	InvoiceStatusDraft  = "draft"
+	InvoiceStatusIssued = "issued"
+	InvoiceStatusPaid   = "paid"
+	InvoiceStatusVoid   = "void"
+)
+
+const (
+	InvoiceFormatHTML = "html"
+	InvoiceFormatPDF  = "pdf"
+)
+
+type Invoice struct {
+	gorm.Model
+	OrganizationID uint         `gorm:"not null;Index"`
+	Organization   Organization `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Number         *string      `gorm:"size:32;uniqueIndex"` // assigned when the invoice is issued
+	Status         string       `gorm:"size:16;not null;default:draft;Index"`
+	PeriodStart    time.Time    `gorm:"not null;Index"`
+	PeriodEnd      time.Time    `gorm:"not null"` // exclusive
+	Total          float64      `gorm:"not null;default:0"`
+	IssuedAt       *time.Time
+	PaidAt         *time.Time
+	VoidedAt       *time.Time
+	Items          []InvoiceItem `gorm:"foreignKey:InvoiceID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type InvoiceItem struct {
+	gorm.Model
+	InvoiceID    uint    `gorm:"not null;Index"`
+	ServiceID    uint    `gorm:"not null"`
+	Description  string  `gorm:"size:255;not null"`
+	FlatPrice    float64 `gorm:"not null;default:0"`
+	UsageSeconds int64   `gorm:"not null;default:0"`
+	HourlyPrice  float64 `gorm:"not null;default:0"`
+	UsageAmount  float64 `gorm:"not null;default:0"`
+	Amount       float64 `gorm:"not null;default:0"`
+}
+
+// GenerateInvoices selects the billing period and, optionally, a single organization (all of them otherwise)
+type GenerateInvoices struct {
+	Month          string `json:"month" binding:"required" example:"2026-09"` // YYYY-MM
+	OrganizationID *uint  `json:"organization_id"`
+}
+
+type InvoiceFilter struct {
+	OrganizationID uint
+	Status         string
+}
+
+type PublicInvoiceItem struct {
+	ServiceID    uint    `json:"service_id"`
+	Description  string  `json:"description"`
+	FlatPrice    float64 `json:"flat_price"`
+	UsageSeconds int64   `json:"usage_seconds"`
+	UsageHours   float64 `json:"usage_hours"`
+	HourlyPrice  float64 `json:"hourly_price"`
+	UsageAmount  float64 `json:"usage_amount"`
+	Amount       float64 `json:"amount"`
+}
+
+type PublicInvoice struct {
+	ID               uint                `json:"id"`
+	Number           string              `json:"number"`
+	OrganizationID   uint                `json:"organization_id"`
+	OrganizationName string              `json:"organization_name"`
+	Status           string              `json:"status"`
+	PeriodStart      time.Time           `json:"period_start"`
+	PeriodEnd        time.Time           `json:"period_end"`
+	Total            float64             `json:"total"`
+	IssuedAt         *time.Time          `json:"issued_at"`
+	PaidAt           *time.Time          `json:"paid_at"`
+	VoidedAt         *time.Time          `json:"voided_at"`
+	Items            []PublicInvoiceItem `json:"items"`
+}
+
+type InvoiceRepository interface {
+	// Create stores the invoice together with its items
+	Create(ctx context.Context, invoice *Invoice) error
+	Fetch(ctx context.Context, filter InvoiceFilter) ([]Invoice, error)
+	GetByID(ctx context.Context, id uint) (Invoice, error)
+	// GetByPeriod returns the invoice of the organization for the period that was not voided
+	GetByPeriod(ctx context.Context, organizationID uint, periodStart time.Time) (Invoice, error)
+	// UpdateStatus moves the invoice to invoice.Status only if its current status is one of from
+	UpdateStatus(ctx context.Context, invoice *Invoice, from ...string) error
+	DeleteDraft(ctx context.Context, id uint) error
+}
+
+type InvoiceUsecase interface {
+	Generate(ctx context.Context, request *GenerateInvoices) ([]PublicInvoice, error)
+	Fetch(ctx context.Context, filter InvoiceFilter) ([]PublicInvoice, error)
+	GetByID(ctx context.Context, id uint) (PublicInvoice, error)
+	Issue(ctx context.Context, id uint) (PublicInvoice, error)
+	Pay(ctx context.Context, id uint) (PublicInvoice, error)
+	Void(ctx context.Context, id uint) (PublicInvoice, error)
+	// Render returns the invoice as an HTML page or a PDF document (see InvoiceFormatHTML and InvoiceFormatPDF)
+	Render(ctx context.Context, id uint, format string) ([]byte, error)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ONE TO ONE WITH USER
+// Consecutive wrong passwords of a user, the row is removed by a successful login or a password reset.
+
+type LoginLockout struct {
+	UserID         uint `gorm:"primaryKey;autoIncrement:false;not null"`
+	FailedAttempts int  `gorm:"not null;default:0"`
+	LockedUntil    *time.Time
+	UpdatedAt      time.Time
+}
+
+// LoginLockoutPolicy locks an account for Duration once it reaches Threshold consecutive wrong passwords,
+// doubling the duration on every further one up to MaxDuration. A zero Threshold disables the lockout
+type LoginLockoutPolicy struct {
+	Threshold   int
+	Duration    time.Duration
+	MaxDuration time.Duration
+}
+
+// LockFor returns how long the account is locked after failedAttempts consecutive wrong passwords
+func (p LoginLockoutPolicy) LockFor(failedAttempts int) time.Duration {
+	if p.Threshold <= 0 || failedAttempts < p.Threshold {
+		return 0
+	}
+	duration := p.Duration
+	for i := p.Threshold; i < failedAttempts && duration < p.MaxDuration; i++ {
+		duration *= 2
+	}
+	return min(duration, p.MaxDuration)
+}
+
+// AccountLockedError is ErrAccountLocked with the time left until the account accepts logins again
+type AccountLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *AccountLockedError) Error() string {
+	return ErrAccountLocked.Error()
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+type LoginLockoutRepository interface {
+	// GetByUserID returns the zero LoginLockout when the user has no wrong password recorded
+	GetByUserID(ctx context.Context, userID uint) (LoginLockout, error)
+	// RecordFailure counts a wrong password and returns the consecutive ones
+	RecordFailure(ctx context.Context, userID uint) (int, error)
+	Lock(ctx context.Context, userID uint, until time.Time) error
+	Reset(ctx context.Context, userID uint) error
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"context"
+)
+
+type MailMessage struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional emails (password reset, confirmations, ...)
+// see the implementations in internal/mailer
+type Mailer interface {
+	Send(ctx context.Context, message *MailMessage) error
+}
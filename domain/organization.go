@@ -21,14 +21,24 @@ type Organization struct {
 
 type CreateOrganization struct {
 	Name               string `json:"name" binding:"required"`
+	Nickname           string `json:"nickname"`
+	LogoUrl            string `json:"logo_url"`
 	OrganizationRoleID uint   `json:"organization_role_id" binding:"required,number"`
 }
 
+// UpdateOrganization only changes the fields that are sent
+type UpdateOrganization struct {
+	Name     *string `json:"name" binding:"omitempty,min=1"`
+	Nickname *string `json:"nickname"`
+	LogoUrl  *string `json:"logo_url"`
+}
+
 type PublicOrganization struct {
-	ID       uint   `json:"id"`
-	Name     string `json:"name"`
-	Nickname string `json:"nickname"`
-	LogoUrl  string `json:"logo_url"`
+	ID                 uint   `json:"id"`
+	Name               string `json:"name"`
+	Nickname           string `json:"nickname"`
+	LogoUrl            string `json:"logo_url"`
+	OrganizationRoleID uint   `json:"organization_role_id"`
 }
 
 type OrganizationRepository interface {
@@ -43,11 +53,11 @@ type OrganizationRepository interface {
 }
 
 type OrganizationUsecase interface {
-	Create(ctx context.Context, organization *Organization) error
+	Create(ctx context.Context, organization *CreateOrganization) (PublicOrganization, error)
 	Fetch(ctx context.Context) ([]PublicOrganization, error)
 	GetByIdentifier(ctx context.Context, identifier string) (PublicOrganization, error)
 	GetUsers(ctx context.Context, id uint) ([]PublicUser, error)
 	GetSubscribedServices(ctx context.Context, id uint) ([]PublicService, error)
-	Update(ctx context.Context, organizationID uint, organization *Organization) error
+	Update(ctx context.Context, organizationID uint, organization *UpdateOrganization) (PublicOrganization, error)
 	Delete(ctx context.Context, organizationID uint) error
 }
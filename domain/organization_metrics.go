@@ -1,10 +1,15 @@
 package domain
 
 import (
+	"context"
+	"time"
+
 	"gorm.io/gorm"
 )
 
 // ONE TO ONE WITH ORGANIZATION
+// Rolled up by the metrics job (see job.Setup) from the organization members and subscribed services.
+// The report counters stay at zero until the platform has reports.
 
 type OrganizationMetrics struct {
 	gorm.Model
@@ -17,20 +22,25 @@ type OrganizationMetrics struct {
 	NextReportDate           string `gorm:"size:255"`
 }
 
+type PublicOrganizationMetrics struct {
+	OrganizationID           uint      `json:"organization_id"`
+	TotalServices            int       `json:"total_services"`
+	TotalUsers               int       `json:"total_users"`
+	TotalReports             int       `json:"total_reports"`
+	TotalReportsCurrentMonth int       `json:"total_reports_current_month"`
+	LastReportDate           string    `json:"last_report_date"`
+	NextReportDate           string    `json:"next_report_date"`
+	UpdatedAt                time.Time `json:"updated_at"` // when the metrics were last rolled up
+}
+
 type OrganizationMetricsRepository interface {
-	Create(organizationMetrics *OrganizationMetrics) error
-	Fetch() ([]OrganizationMetrics, error)
-	GetByID(id uint) (OrganizationMetrics, error)
-	GetByOrganizationID(organizationID uint) (OrganizationMetrics, error)
-	Update(organizationMetricsID uint, organizationMetrics *OrganizationMetrics) error
-	Delete(organizationMetricsID uint) error
+	GetByOrganizationID(ctx context.Context, organizationID uint) (OrganizationMetrics, error)
+	// Recompute rolls up the metrics of every organization and returns how many organizations were updated
+	Recompute(ctx context.Context) (int, error)
 }
 
 type OrganizationMetricsUsecase interface {
-	Create(organizationMetrics *OrganizationMetrics) error
-	Fetch() ([]OrganizationMetrics, error)
-	GetByID(id uint) (OrganizationMetrics, error)
-	GetByOrganizationID(organizationID uint) (OrganizationMetrics, error)
-	Update(organizationMetricsID uint, organizationMetrics *OrganizationMetrics) error
-	Delete(organizationMetricsID uint) error
+	GetByOrganizationID(ctx context.Context, organizationID uint) (PublicOrganizationMetrics, error)
+	// Recompute rolls up the metrics of every organization, it is run periodically by the metrics job
+	Recompute(ctx context.Context) (int, error)
 }
@@ -2,38 +2,101 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 // ONE TO ONE WITH ORGANIZATION
+// Organizations without a subscription (e.g. the platform owner) are not subject to its limits,
+// once a subscription exists the organization can only add users up to SubscriptionUsersLimit
+// and use services while it is active.
+
+const (
+	SubscriptionPeriodMonthly   = "monthly"
+	SubscriptionPeriodQuarterly = "quarterly"
+	SubscriptionPeriodYearly    = "yearly"
+)
 
 type OrganizationSubscription struct {
 	gorm.Model
-	Active                   bool    `gorm:"default:false"`
-	OrganizationID           uint    `gorm:"not null;uniqueIndex"`
-	SubscriptionValue        float64 `gorm:"not null"`
-	SubscriptionPeriod       string  `gorm:"not null"`
-	SubscriptionUsersLimit   int     `gorm:"not null"`
-	SubscriptionReportsLimit int     `gorm:"not null"`
-	SubscriptionInitDate     string  `gorm:"not null"`
-	SubscriptionEndDate      string  `gorm:"not null"`
+	Active                   bool      `gorm:"default:false;Index"`
+	OrganizationID           uint      `gorm:"not null;uniqueIndex"`
+	SubscriptionValue        float64   `gorm:"not null"`
+	SubscriptionPeriod       string    `gorm:"not null"`
+	SubscriptionUsersLimit   int       `gorm:"not null"` // 0 means unlimited
+	SubscriptionReportsLimit int       `gorm:"not null"` // 0 means unlimited
+	SubscriptionInitDate     time.Time `gorm:"not null"`
+	SubscriptionEndDate      time.Time `gorm:"not null;Index"`
+	CancelledAt              *time.Time
+}
+
+// IsActive reports whether the subscription is in force at the given moment, it doesn't wait
+// for the daily job that turns off the Active flag of expired subscriptions
+func (s OrganizationSubscription) IsActive(now time.Time) bool {
+	return s.Active && !now.Before(s.SubscriptionInitDate) && now.Before(s.SubscriptionEndDate)
+}
+
+// HasUsersAvailable reports whether the organization may have one more user
+func (s OrganizationSubscription) HasUsersAvailable(users int64) bool {
+	return s.SubscriptionUsersLimit == 0 || users < int64(s.SubscriptionUsersLimit)
+}
+
+// SubscriptionPeriodEnd returns the end of a period of the subscription started at from
+func SubscriptionPeriodEnd(period string, from time.Time) time.Time {
+	switch period {
+	case SubscriptionPeriodQuarterly:
+		return from.AddDate(0, 3, 0)
+	case SubscriptionPeriodYearly:
+		return from.AddDate(1, 0, 0)
+	default:
+		return from.AddDate(0, 1, 0)
+	}
+}
+
+type CreateSubscription struct {
+	Value        float64    `json:"value" binding:"min=0"`
+	Period       string     `json:"period" binding:"required,oneof=monthly quarterly yearly"`
+	UsersLimit   int        `json:"users_limit" binding:"min=0"`
+	ReportsLimit int        `json:"reports_limit" binding:"min=0"`
+	InitDate     *time.Time `json:"init_date"` // defaults to now
+}
+
+// RenewSubscription extends the subscription by one period, the terms sent replace the current ones
+type RenewSubscription struct {
+	Value        *float64 `json:"value" binding:"omitempty,min=0"`
+	Period       *string  `json:"period" binding:"omitempty,oneof=monthly quarterly yearly"`
+	UsersLimit   *int     `json:"users_limit" binding:"omitempty,min=0"`
+	ReportsLimit *int     `json:"reports_limit" binding:"omitempty,min=0"`
+}
+
+type PublicSubscription struct {
+	ID             uint       `json:"id"`
+	OrganizationID uint       `json:"organization_id"`
+	Active         bool       `json:"active"`
+	Value          float64    `json:"value"`
+	Period         string     `json:"period"`
+	UsersLimit     int        `json:"users_limit"`
+	ReportsLimit   int        `json:"reports_limit"`
+	InitDate       time.Time  `json:"init_date"`
+	EndDate        time.Time  `json:"end_date"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
 }
 
 type OrganizationSubscriptionRepository interface {
 	Create(ctx context.Context, organizationSubscription *OrganizationSubscription) error
 	Fetch(ctx context.Context) ([]OrganizationSubscription, error)
-	GetByID(ctx context.Context, id uint) (OrganizationSubscription, error)
 	GetByOrganizationID(ctx context.Context, organizationID uint) (OrganizationSubscription, error)
-	Update(ctx context.Context, organizationSubscriptionID uint, organizationSubscription *OrganizationSubscription) error
-	Delete(ctx context.Context, organizationSubscriptionID uint) error
+	Update(ctx context.Context, organizationSubscription *OrganizationSubscription) error
+	// DeactivateExpired turns off the subscriptions that ended before now and returns how many were changed
+	DeactivateExpired(ctx context.Context, now time.Time) (int64, error)
 }
 
 type OrganizationSubscriptionUsecase interface {
-	Create(ctx context.Context, organizationSubscription *OrganizationSubscription) error
-	Fetch(ctx context.Context) ([]OrganizationSubscription, error)
-	GetByID(ctx context.Context, id uint) (OrganizationSubscription, error)
-	GetByOrganizationID(ctx context.Context, organizationID uint) (OrganizationSubscription, error)
-	Update(ctx context.Context, organizationSubscriptionID uint, organizationSubscription *OrganizationSubscription) error
-	Delete(ctx context.Context, organizationSubscriptionID uint) error
+	Create(ctx context.Context, organizationID uint, subscription *CreateSubscription) (PublicSubscription, error)
+	Fetch(ctx context.Context) ([]PublicSubscription, error)
+	GetByOrganizationID(ctx context.Context, organizationID uint) (PublicSubscription, error)
+	Renew(ctx context.Context, organizationID uint, subscription *RenewSubscription) (PublicSubscription, error)
+	Cancel(ctx context.Context, organizationID uint) (PublicSubscription, error)
+	DeactivateExpired(ctx context.Context) (int64, error)
 }
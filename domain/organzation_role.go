@@ -12,6 +12,7 @@ type OrganizationRole struct {
 	gorm.Model
 	RoleName      string         `gorm:"size:255;uniqueIndex;not null"`
 	Organizations []Organization `gorm:"foreignKey:RoleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Permissions   []Permission   `gorm:"many2many:organization_role_permissions;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 type OrganizationRoleRepository interface {
@@ -20,6 +21,7 @@ type OrganizationRoleRepository interface {
 	GetByID(ctx context.Context, id uint) (OrganizationRole, error)
 	GetByRoleName(ctx context.Context, roleName string) (OrganizationRole, error)
 	Update(ctx context.Context, organizationRoleID uint, organizationRole *OrganizationRole) error
+	ReplacePermissions(ctx context.Context, organizationRoleID uint, permissions []Permission) error
 	Delete(ctx context.Context, organizationRoleID uint) error
 }
 
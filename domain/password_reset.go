@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MANY TO ONE WITH USER
+// Only the hash of the token is stored, the raw token travels exclusively in the email link.
+
+type PasswordResetToken struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;Index"`
+	TokenHash string    `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, passwordResetToken *PasswordResetToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	MarkUsed(ctx context.Context, passwordResetTokenID uint) error
+	InvalidateByUserID(ctx context.Context, userID uint) error
+}
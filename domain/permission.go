@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// MANY TO MANY WITH USER ROLE (user_role_permissions)
+// MANY TO MANY WITH ORGANIZATION ROLE (organization_role_permissions)
+// A user is granted a permission only when both its UserRole and the OrganizationRole of its
+// organization have it, so the organization role caps what any of its users can do.
+
+const (
+	PermServiceRead          = "service:read"
+	PermServiceWrite         = "service:write"
+	PermServiceLink          = "service:link"
+	PermServiceUse           = "service:use"
+	PermUserRead             = "user:read"
+	PermUserWrite            = "user:write"
+	PermUserArchive          = "user:archive"
+	PermOrgRead              = "org:read"
+	PermOrgManage            = "org:manage"
+	PermRoleManage           = "role:manage"
+	PermServiceAccountManage = "service-account:manage"
+	PermUserServiceLogRead   = "log:read"
+	PermSubscriptionRead     = "subscription:read"
+	PermSubscriptionManage   = "subscription:manage"
+	PermInvoiceRead          = "invoice:read"
+	PermInvoiceManage        = "invoice:manage"
+	PermPresenceRead         = "presence:read"
+	PermDataExport           = "data:export"
+	PermAuditRead            = "audit:read"
+	// PermPlatformAdmin lifts the organization scope applied by the repositories (see repository/tenant_scope.go)
+	PermPlatformAdmin = "platform:admin"
+)
+
+type Permission struct {
+	gorm.Model
+	Name        string `gorm:"size:255;uniqueIndex;not null"`
+	Description string `gorm:"size:255"`
+}
+
+type PublicPermission struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type PublicRole struct {
+	ID          uint     `json:"id"`
+	RoleName    string   `json:"role_name"`
+	Permissions []string `json:"permissions"`
+}
+
+type SetRolePermissions struct {
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+type PermissionRepository interface {
+	Fetch(ctx context.Context) ([]Permission, error)
+	GetByNames(ctx context.Context, names []string) ([]Permission, error)
+	GetNamesByUserID(ctx context.Context, userID uint) ([]string, error)
+	GetNamesByOrganizationID(ctx context.Context, organizationID uint) ([]string, error)
+}
+
+type AuthorizationUsecase interface {
+	GetPrincipalPermissions(ctx context.Context, principal Principal) ([]string, error)
+	FetchPermissions(ctx context.Context) ([]PublicPermission, error)
+	FetchUserRoles(ctx context.Context) ([]PublicRole, error)
+	FetchOrganizationRoles(ctx context.Context) ([]PublicRole, error)
+	SetUserRolePermissions(ctx context.Context, userRoleID uint, permissions []string) (PublicRole, error)
+	SetOrganizationRolePermissions(ctx context.Context, organizationRoleID uint, permissions []string) (PublicRole, error)
+}
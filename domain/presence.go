@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Presence is the live view of the active usage sessions (UserServiceLog) of an organization: who is using
+// which service right now. Every time a session starts, ends or expires a new PresenceSnapshot of the
+// organization is published to the PresenceBroker, which fans it out to the subscribed connections.
+
+type ActiveUsageSession struct {
+	LogID           uint
+	UserID          uint
+	UserEmail       string
+	ServiceID       uint
+	ServiceName     string
+	StartedAt       time.Time
+	LastHeartbeatAt time.Time
+}
+
+type PresenceUser struct {
+	UserID          uint      `json:"user_id"`
+	Email           string    `json:"email"`
+	LogID           uint      `json:"log_id"`
+	Since           time.Time `json:"since"`
+	LastHeartbeatAt time.Time `json:"last_heartbeat_at"`
+}
+
+type ServicePresence struct {
+	ServiceID   uint           `json:"service_id"`
+	ServiceName string         `json:"service_name"`
+	ActiveUsers int            `json:"active_users"`
+	Users       []PresenceUser `json:"users"`
+}
+
+type PresenceSnapshot struct {
+	OrganizationID uint              `json:"organization_id"`
+	Services       []ServicePresence `json:"services"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// PresenceMessage is the envelope of the presence WebSocket, in both directions.
+// client -> server: {"type": "heartbeat" | "end", "log_id": 1}
+// server -> client: {"type": "presence", "presence": {...}}, {"type": "session", "session": {...}} or {"type": "error", "message": "..."}
+type PresenceMessage struct {
+	Type     string                `json:"type"`
+	LogID    uint                  `json:"log_id,omitempty"`
+	Presence *PresenceSnapshot     `json:"presence,omitempty"`
+	Session  *PublicUserServiceLog `json:"session,omitempty"`
+	Message  string                `json:"message,omitempty"`
+}
+
+const (
+	PresenceMessageHeartbeat = "heartbeat"
+	PresenceMessageEnd       = "end"
+	PresenceMessagePresence  = "presence"
+	PresenceMessageSession   = "session"
+	PresenceMessageError     = "error"
+)
+
+// PresenceBroker distributes presence snapshots per organization. The in-process implementation
+// (internal/pubsub) only reaches the connections of this instance, running several instances requires
+// an implementation backed by an external broker (e.g. Redis pub/sub)
+type PresenceBroker interface {
+	Publish(snapshot PresenceSnapshot)
+	// Subscribe returns the channel of snapshots of the organization, only the latest snapshot is kept
+	// for slow subscribers. unsubscribe must be called once the subscriber is gone
+	Subscribe(organizationID uint) (snapshots <-chan PresenceSnapshot, unsubscribe func())
+}
+
+type PresenceUsecase interface {
+	GetByOrganizationID(ctx context.Context, organizationID uint) (PresenceSnapshot, error)
+	Subscribe(organizationID uint) (snapshots <-chan PresenceSnapshot, unsubscribe func())
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+)
+
+// Principal is the authenticated caller of a request: a user authenticated by JWT
+// or a service account authenticated by API key. It is stored in the request context
+// by the auth middleware (see api/middleware/jwt_auth_middleware.go).
+type Principal struct {
+	UserID           uint
+	ServiceAccountID uint
+	OrganizationID   uint
+	Scopes           []string
+	Permissions      []string // resolved by middleware.LoadPermissions
+}
+
+type principalContextKey struct{}
+
+func (p Principal) IsServiceAccount() bool {
+	return p.ServiceAccountID != 0
+}
+
+func (p Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Principal) HasPermission(permission string) bool {
+	for _, perm := range p.Permissions {
+		if perm == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPlatformAdmin reports whether the principal may read and write data of every organization
+func (p Principal) IsPlatformAdmin() bool {
+	return p.HasPermission(PermPlatformAdmin)
+}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal of the request, ok is false for unauthenticated or internal calls
+func PrincipalFromContext(ctx context.Context) (principal Principal, ok bool) {
+	principal, ok = ctx.Value(principalContextKey{}).(Principal)
+	return principal, ok
+}
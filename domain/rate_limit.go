@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimit allows Requests per Period, in bursts of up to Requests. A limit without requests is disabled
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// RateLimitResult is the state of the bucket after a Take, exposed in the RateLimit-* headers
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next request is allowed, zero when Allowed
+}
+
+// RateLimitStore keeps the token buckets. The memory implementation (internal/ratelimit) limits each instance
+// on its own, several instances behind a load balancer need a shared store
+type RateLimitStore interface {
+	// Take removes a token from the bucket of key, a new bucket starts full
+	Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MANY TO ONE WITH USER
+// Every login starts a new family; each refresh rotates the token inside the same family.
+// Replaying an already rotated token revokes the whole family.
+
+type RefreshToken struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;Index"`
+	FamilyID  string    `gorm:"size:64;not null;Index"`
+	TokenID   string    `gorm:"size:64;uniqueIndex;not null"` // jti claim of the signed refresh token
+	ExpiresAt time.Time `gorm:"not null"`
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, refreshToken *RefreshToken) error
+	GetByTokenID(ctx context.Context, tokenID string) (RefreshToken, error)
+	RotateAndCreate(ctx context.Context, tokenID string, next *RefreshToken) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeByUserID(ctx context.Context, userID uint) error
+}
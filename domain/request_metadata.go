@@ -0,0 +1,24 @@
+package domain
+
+import "context"
+
+// RequestMetadata identifies the HTTP request being served, it is stored in the request context by
+// middleware.RequestMetadata and recorded in the audit trail
+type RequestMetadata struct {
+	RequestID string
+	IPAddress string
+	UserAgent string
+}
+
+type requestMetadataContextKey struct{}
+
+// WithRequestMetadata returns a copy of ctx carrying the request metadata
+func WithRequestMetadata(ctx context.Context, metadata RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataContextKey{}, metadata)
+}
+
+// RequestMetadataFromContext returns the metadata of the request, empty for internal calls (e.g. jobs)
+func RequestMetadataFromContext(ctx context.Context) RequestMetadata {
+	metadata, _ := ctx.Value(requestMetadataContextKey{}).(RequestMetadata)
+	return metadata
+}
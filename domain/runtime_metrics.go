@@ -0,0 +1,11 @@
+package domain
+
+// RuntimeMetrics receives the business events exposed on /metrics (see internal/metrics), the HTTP and
+// database metrics are collected outside the usecases
+type RuntimeMetrics interface {
+	// LoginAttempt counts a login by method ("password", "guest" or "refresh"), a nil err is a success
+	LoginAttempt(method string, err error)
+	UsageSessionStarted(serviceID uint)
+	// UsageHeartbeat counts the heartbeats and ends accepted for the sessions of the service
+	UsageHeartbeat(serviceID uint)
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -22,7 +23,8 @@ type Service struct {
 	Tags          string         `gorm:"size:255"`
 	LastUpdate    string         `gorm:"size:255"`
 	Status        string         `gorm:"size:255"`
-	Price         float64        `gorm:"not null"`
+	Price         float64        `gorm:"not null"`           // flat price per billing period
+	HourlyPrice   float64        `gorm:"not null;default:0"` // optional price per hour of usage, 0 disables usage billing
 	IsMarketing   bool           `gorm:"not null;default:false"`
 	Organization  []Organization `gorm:"many2many:organization_services;"`
 }
@@ -44,6 +46,7 @@ type HubService struct {
 	LastUpdate    string  `json:"last_update"`
 	Status        string  `json:"status"`
 	Price         float64 `json:"price"`
+	HourlyPrice   float64 `json:"hourly_price"`
 }
 
 type MarketingService struct {
@@ -62,9 +65,9 @@ type UseService struct {
 	LogID   uint          `json:"log_id"`
 }
 
+// Heartbeat identifies the usage session being kept alive or ended, the duration is computed by the server
 type Heartbeat struct {
-	LogID    uint `json:"log_id"`
-	Duration int  `json:"duration"`
+	LogID uint `json:"log_id" binding:"required"`
 }
 
 type ServiceRepository interface {
@@ -73,8 +76,10 @@ type ServiceRepository interface {
 	GetByID(ctx context.Context, id uint) (Service, error)
 	GetByName(ctx context.Context, name string) (Service, error)
 	GetByOrganization(ctx context.Context, organizationID uint) ([]Service, error)
+	IsAvailableToOrganization(ctx context.Context, serviceID uint, organizationID uint) (bool, error)
 	GetMarketing(ctx context.Context) ([]Service, error)
 	SetAvailabilityToOrganization(ctx context.Context, serviceID uint, organizationID uint) error
+	RemoveAvailabilityFromOrganization(ctx context.Context, serviceID uint, organizationID uint) error
 	Update(ctx context.Context, serviceID uint, service *Service) error
 	Delete(ctx context.Context, serviceID uint) error
 }
@@ -86,8 +91,11 @@ type ServiceUsecase interface {
 	GetByOrganization(ctx context.Context, organizationID uint) ([]HubService, error)
 	GetMarketing(ctx context.Context) ([]MarketingService, error)
 	SetAvailabilityToOrganization(ctx context.Context, serviceID uint, organizationID uint) error
+	RemoveAvailabilityFromOrganization(ctx context.Context, serviceID uint, organizationID uint) error
 	Use(ctx context.Context, userID uint, serviceID uint) (UseService, uint, error)
-	Heartbeat(ctx context.Context, logID uint, duration int) error
+	Heartbeat(ctx context.Context, userID uint, logID uint, maxGap time.Duration) (PublicUserServiceLog, error)
+	EndUsage(ctx context.Context, userID uint, logID uint, maxGap time.Duration) (PublicUserServiceLog, error)
+	CloseIdleSessions(ctx context.Context, idle time.Duration) (int64, error)
 	Update(ctx context.Context, serviceID uint, service *Service) error
 	Delete(ctx context.Context, serviceID uint) error
 }
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MANY TO ONE WITH ORGANIZATION
+// Machine-to-machine identity used by integration scripts, authenticated with "Authorization: ApiKey <key>"
+
+type ServiceAccount struct {
+	gorm.Model
+	Name           string              `gorm:"size:255;uniqueIndex;not null"`
+	Description    string              `gorm:"size:255"`
+	OrganizationID uint                `gorm:"not null;Index"`
+	Organization   Organization        `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Scopes         string              `gorm:"size:1024"` // semicolon-delimited, e.g. "service:read;user:read"
+	Active         bool                `gorm:"not null"`
+	Keys           []ServiceAccountKey `gorm:"foreignKey:ServiceAccountID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// MANY TO ONE WITH SERVICE ACCOUNT
+// The key handed to the client is "<prefix>.<secret>", only the prefix and the hash of the secret are stored
+
+type ServiceAccountKey struct {
+	gorm.Model
+	ServiceAccountID uint   `gorm:"not null;Index"`
+	Prefix           string `gorm:"size:32;uniqueIndex;not null"`
+	SecretHash       string `gorm:"size:64;not null"`
+	ExpiresAt        *time.Time
+	LastUsedAt       *time.Time
+	RevokedAt        *time.Time
+}
+
+type CreateServiceAccount struct {
+	Name           string   `json:"name" binding:"required"`
+	Description    string   `json:"description"`
+	OrganizationID uint     `json:"organization_id" binding:"required"`
+	Scopes         []string `json:"scopes"`
+}
+
+type UpdateServiceAccount struct {
+	Description *string  `json:"description"`
+	Scopes      []string `json:"scopes"`
+	Active      *bool    `json:"active"`
+}
+
+type CreateServiceAccountKey struct {
+	ExpiresInDays int `json:"expires_in_days" binding:"omitempty,min=1"`
+}
+
+type PublicServiceAccount struct {
+	ID             uint                      `json:"id"`
+	Name           string                    `json:"name"`
+	Description    string                    `json:"description"`
+	OrganizationID uint                      `json:"organization_id"`
+	Scopes         []string                  `json:"scopes"`
+	Active         bool                      `json:"active"`
+	Keys           []PublicServiceAccountKey `json:"keys"`
+}
+
+type PublicServiceAccountKey struct {
+	ID         uint       `json:"id"`
+	Prefix     string     `json:"prefix"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// IssuedServiceAccountKey is returned only once, when the key is created
+type IssuedServiceAccountKey struct {
+	ID        uint       `json:"id"`
+	Key       string     `json:"key"`
+	Prefix    string     `json:"prefix"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type ServiceAccountRepository interface {
+	Create(ctx context.Context, serviceAccount *ServiceAccount) error
+	Fetch(ctx context.Context) ([]ServiceAccount, error)
+	GetByID(ctx context.Context, id uint) (ServiceAccount, error)
+	Update(ctx context.Context, serviceAccountID uint, serviceAccount *ServiceAccount) error
+	Delete(ctx context.Context, serviceAccountID uint) error
+	CreateKey(ctx context.Context, key *ServiceAccountKey) error
+	GetKeyByPrefix(ctx context.Context, prefix string) (ServiceAccountKey, error)
+	RevokeKey(ctx context.Context, serviceAccountID uint, keyID uint) error
+	TouchKey(ctx context.Context, keyID uint, usedAt time.Time) error
+}
+
+type ServiceAccountUsecase interface {
+	Create(ctx context.Context, serviceAccount *CreateServiceAccount) (PublicServiceAccount, error)
+	Fetch(ctx context.Context) ([]PublicServiceAccount, error)
+	GetByID(ctx context.Context, id uint) (PublicServiceAccount, error)
+	Update(ctx context.Context, serviceAccountID uint, serviceAccount *UpdateServiceAccount) (PublicServiceAccount, error)
+	Delete(ctx context.Context, serviceAccountID uint) error
+	IssueKey(ctx context.Context, serviceAccountID uint, expiresInDays int) (IssuedServiceAccountKey, error)
+	RevokeKey(ctx context.Context, serviceAccountID uint, keyID uint) error
+	Authenticate(ctx context.Context, rawKey string) (ServiceAccount, error)
+}
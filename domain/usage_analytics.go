@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Usage analytics aggregate the usage sessions (UserServiceLog) in SQL, a session counts in the bucket
+// of the day it started. Buckets are identified by their first day ("2026-10-01"), weeks start on Monday.
+
+const (
+	UsageBucketDay   = "day"
+	UsageBucketWeek  = "week"
+	UsageBucketMonth = "month"
+)
+
+const (
+	UsageGroupService      = "service"
+	UsageGroupUser         = "user"
+	UsageGroupOrganization = "organization"
+)
+
+const (
+	DefaultUsageAnalyticsDays = 30
+	DefaultTopServicesLimit   = 10
+	MaxTopServicesLimit       = 100
+)
+
+type UsageAnalyticsFilter struct {
+	From           time.Time // inclusive
+	To             time.Time // exclusive
+	Bucket         string    // "", day, week or month
+	GroupBy        []string  // service, user and/or organization
+	ServiceID      uint
+	UserID         uint
+	OrganizationID uint
+}
+
+// UsageAggregation is the query built by the usecase for the repository
+type UsageAggregation struct {
+	UsageAnalyticsFilter
+	OrderByDuration bool // biggest usage first instead of bucket and ids
+	Limit           int
+}
+
+type UsageAnalyticsRow struct {
+	Bucket           string `json:"bucket,omitempty"`
+	ServiceID        uint   `json:"service_id,omitempty"`
+	ServiceName      string `json:"service_name,omitempty"`
+	UserID           uint   `json:"user_id,omitempty"`
+	UserEmail        string `json:"user_email,omitempty"`
+	OrganizationID   uint   `json:"organization_id,omitempty"`
+	OrganizationName string `json:"organization_name,omitempty"`
+	Sessions         int64  `json:"sessions"`
+	DurationSeconds  int64  `json:"duration_seconds"`
+	ActiveUsers      int64  `json:"active_users"` // distinct users
+}
+
+type UsageAnalytics struct {
+	From    string              `json:"from"`
+	To      string              `json:"to"` // inclusive
+	Bucket  string              `json:"bucket,omitempty"`
+	GroupBy []string            `json:"group_by"`
+	Rows    []UsageAnalyticsRow `json:"rows"`
+}
+
+type UsageAnalyticsRepository interface {
+	Aggregate(ctx context.Context, aggregation UsageAggregation) ([]UsageAnalyticsRow, error)
+}
+
+type UsageAnalyticsUsecase interface {
+	// Usage aggregates the usage by the requested groups and time bucket
+	Usage(ctx context.Context, filter UsageAnalyticsFilter) (UsageAnalytics, error)
+	// TopServices ranks the services by usage duration
+	TopServices(ctx context.Context, filter UsageAnalyticsFilter, limit int) (UsageAnalytics, error)
+	// ActiveUsers counts the distinct users per bucket, daily by default
+	ActiveUsers(ctx context.Context, filter UsageAnalyticsFilter) (UsageAnalytics, error)
+}
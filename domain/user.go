@@ -30,6 +30,15 @@ type CreateUser struct {
 	RoleID         uint   `json:"role" binding:"required"`
 }
 
+// UpdateUser holds the fields of a user that can be changed, omitted fields are kept. The password is hashed
+// by the usecase and a role can only be given by someone holding all of its permissions
+type UpdateUser struct {
+	Email          *string `json:"email" binding:"omitempty,email"`
+	Password       *string `json:"password" binding:"omitempty,min=8"`
+	OrganizationID *uint   `json:"organization_id"`
+	RoleID         *uint   `json:"role"`
+}
+
 type PublicUser struct {
 	ID               uint   `json:"id"`
 	Email            string `json:"email"`
@@ -44,15 +53,18 @@ type UserRepository interface {
 	Fetch(ctx context.Context) ([]User, error)
 	GetByID(ctx context.Context, id uint) (User, error)
 	GetByEmail(ctx context.Context, email string) (User, error)
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	GetFirstByRoleName(ctx context.Context, roleName string) (User, error)
 	Update(ctx context.Context, userID uint, user *User) error
 	Archive(ctx context.Context, userID uint) error
+	CountByOrganization(ctx context.Context, organizationID uint) (int64, error)
 }
 
 type UserUsecase interface {
 	Create(ctx context.Context, user *CreateUser) error
 	Fetch(ctx context.Context) ([]PublicUser, error)
 	GetByIdentifier(ctx context.Context, identifier string) (PublicUser, error)
-	Update(ctx context.Context, userID uint, user *User) error
+	Update(ctx context.Context, userID uint, user *UpdateUser) error
 	Archive(ctx context.Context, userID uint) error
 }
 
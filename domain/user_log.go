@@ -2,6 +2,11 @@ package domain
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
 	"time"
 
 	"gorm.io/gorm"
@@ -9,19 +14,171 @@ import (
 
 // MANY TO ONE WITH USER
 
+// UserLog is the audit trail of the platform: logins and every mutation made through the API, with the actor,
+// the target entity and the before/after diff. Each row is hash-chained to the previous one (see ChainHash),
+// so editing or deleting a row breaks the chain and is detected by the audit verification.
 type UserLog struct {
 	gorm.Model
-	UserID    uint   `gorm:"not null;Index"`
-	IPAddress string `gorm:"size:255"`
-	Action    string `gorm:"size:255;not null"`
+	UserID           *uint  `gorm:"Index"` // acting user, nil when a service account or the system acted
+	ServiceAccountID *uint  `gorm:"Index"`
+	OrganizationID   *uint  `gorm:"Index"` // organization of the actor
+	IPAddress        string `gorm:"size:255"`
+	UserAgent        string `gorm:"size:512"`
+	RequestID        string `gorm:"size:64;Index"`
+	Action           string `gorm:"size:255;not null;Index"`
+	EntityType       string `gorm:"size:64;Index"`
+	EntityID         string `gorm:"size:255"`
+	Changes          string `gorm:"type:text"` // JSON object {"field": {"before": ..., "after": ...}}
+	PrevHash         string `gorm:"size:64"`
+	Hash             string `gorm:"size:64;Index"` // empty on rows written before the chain existed
+}
+
+const (
+	UserLogActionLogin                       = "login"
+	UserLogActionUserCreate                  = "user.create"
+	UserLogActionUserUpdate                  = "user.update"
+	UserLogActionUserArchive                 = "user.archive"
+	UserLogActionServiceCreate               = "service.create"
+	UserLogActionServiceUpdate               = "service.update"
+	UserLogActionServiceDelete               = "service.delete"
+	UserLogActionServiceLink                 = "service.link"
+	UserLogActionServiceUnlink               = "service.unlink"
+	UserLogActionOrganizationCreate          = "organization.create"
+	UserLogActionOrganizationUpdate          = "organization.update"
+	UserLogActionOrganizationDelete          = "organization.delete"
+	UserLogActionUserRolePermissions         = "user_role.permissions"
+	UserLogActionOrganizationRolePermissions = "organization_role.permissions"
+	UserLogActionTokenMint                   = "token.mint"
+)
+
+const (
+	UserLogEntityUser             = "user"
+	UserLogEntityService          = "service"
+	UserLogEntityOrganization     = "organization"
+	UserLogEntityUserRole         = "user_role"
+	UserLogEntityOrganizationRole = "organization_role"
+)
+
+// ChainHash returns the SHA-256 (hex) of the previous hash followed by the audited fields of the row. The ID is
+// not part of it (it is only known after the insert), the order is kept by PrevHash instead. CreatedAt must be
+// set by the caller with microsecond precision, the finest one every supported database stores
+func (l UserLog) ChainHash() string {
+	content, _ := json.Marshal(struct {
+		CreatedAt        string `json:"created_at"`
+		UserID           *uint  `json:"user_id"`
+		ServiceAccountID *uint  `json:"service_account_id"`
+		OrganizationID   *uint  `json:"organization_id"`
+		IPAddress        string `json:"ip_address"`
+		UserAgent        string `json:"user_agent"`
+		RequestID        string `json:"request_id"`
+		Action           string `json:"action"`
+		EntityType       string `json:"entity_type"`
+		EntityID         string `json:"entity_id"`
+		Changes          string `json:"changes"`
+	}{
+		l.CreatedAt.UTC().Format(time.RFC3339Nano), l.UserID, l.ServiceAccountID, l.OrganizationID,
+		l.IPAddress, l.UserAgent, l.RequestID, l.Action, l.EntityType, l.EntityID, l.Changes,
+	})
+	sum := sha256.Sum256(append([]byte(l.PrevHash+"\n"), content...))
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditEntry is a mutation to be recorded, Before and After are snapshots of the entity (nil on create and
+// delete respectively) and only the fields that differ are stored
+type AuditEntry struct {
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     interface{}
+	After      interface{}
+}
+
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 200
+)
+
+// AuditFilter selects the logs listed by GET /audit. Pages follow the ID (the chain order), Cursor is the
+// ID of the last log of the previous page and only logs after it, in the Descending direction, are returned
+type AuditFilter struct {
+	UserID         uint
+	OrganizationID uint
+	Action         string
+	EntityType     string
+	EntityID       string
+	IPAddress      string
+	From           time.Time
+	To             time.Time // exclusive
+	Cursor         uint
+	Limit          int
+	Descending     bool
+}
+
+type PublicUserLog struct {
+	ID               uint            `json:"id"`
+	UserID           *uint           `json:"user_id"`
+	ServiceAccountID *uint           `json:"service_account_id"`
+	OrganizationID   *uint           `json:"organization_id"`
+	IPAddress        string          `json:"ip_address"`
+	UserAgent        string          `json:"user_agent"`
+	RequestID        string          `json:"request_id"`
+	Action           string          `json:"action"`
+	EntityType       string          `json:"entity_type"`
+	EntityID         string          `json:"entity_id"`
+	Changes          json.RawMessage `json:"changes" swaggertype:"object"`
+	Hash             string          `json:"hash"`
+	CreatedAt        time.Time       `json:"created_at"`
+}
+
+type AuditPage struct {
+	Items      []PublicUserLog `json:"items"`
+	NextCursor string          `json:"next_cursor,omitempty"` // absent on the last page
+}
+
+// EncodeAuditCursor returns the opaque cursor of the page that starts after the log id
+func EncodeAuditCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+// DecodeAuditCursor returns the log id of a cursor made by EncodeAuditCursor
+func DecodeAuditCursor(cursor string) (uint, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidAuditQuery
+	}
+	id, err := strconv.ParseUint(string(decoded), 10, 64)
+	if err != nil || id == 0 {
+		return 0, ErrInvalidAuditQuery
+	}
+	return uint(id), nil
+}
+
+// AuditVerification is the result of walking the hash chain. Rows written before the chain existed are
+// counted as legacy. HeadHash is the hash of the last row: keeping a copy outside the database also
+// detects the removal of the most recent rows, which the chain alone can't
+type AuditVerification struct {
+	Valid      bool      `json:"valid"`
+	Checked    int64     `json:"checked"`
+	Legacy     int64     `json:"legacy"`
+	BrokenAt   *uint     `json:"broken_at,omitempty"` // ID of the first row that does not match the chain
+	Reason     string    `json:"reason,omitempty"`
+	HeadHash   string    `json:"head_hash"`
+	VerifiedAt time.Time `json:"verified_at"`
 }
 
 type UserLogRepository interface {
+	// Create appends the log to the hash chain, filling CreatedAt, PrevHash and Hash
 	Create(ctx context.Context, userLog *UserLog) error
 	Fetch(ctx context.Context) ([]UserLog, error)
 	GetByUserID(ctx context.Context, userID uint) ([]UserLog, error)
 	GetByDate(ctx context.Context, userID uint, date time.Time) ([]UserLog, error)
 	DeleteByID(ctx context.Context, userLogID uint) error
+	// StreamForExport calls fn for each login of the filter, reading one row at a time
+	StreamForExport(ctx context.Context, filter ExportFilter, fn func(row LoginExportRow) error) error
+	// FetchPage returns up to filter.Limit logs of the filter, restricted to the organization of the caller
+	FetchPage(ctx context.Context, filter AuditFilter) ([]UserLog, error)
+	// StreamChain calls fn for every log (archived ones included) in chain order, without the organization scope
+	StreamChain(ctx context.Context, fn func(userLog UserLog) error) error
 }
 
 type UserLogUsecase interface {
@@ -32,3 +189,11 @@ type UserLogUsecase interface {
 	Update(ctx context.Context, userLogID uint, userLog *UserLog) error
 	Delete(ctx context.Context, userLogID uint) error
 }
+
+type AuditUsecase interface {
+	// Record appends the mutation to the audit trail with the actor and the request metadata of ctx.
+	// A failure is logged and does not fail the mutation, which has already been applied
+	Record(ctx context.Context, entry AuditEntry)
+	Fetch(ctx context.Context, filter AuditFilter) (AuditPage, error)
+	Verify(ctx context.Context) (AuditVerification, error)
+}
@@ -2,36 +2,42 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 // ONE TO ONE WITH USER
+// Rolled up by the metrics job (see job.Setup) from the login UserLogs and the UserServiceLog heartbeats.
 
 type UserMetrics struct {
 	gorm.Model
 	UserID             uint   `gorm:"not null;uniqueIndex"`
-	FavoriteServiceID  uint   `gorm:""`
+	FavoriteServiceID  uint   `gorm:""` // service with the longest total usage
 	LastIP             string `gorm:"size:255"`
-	LastLogin          string `gorm:"size:255"`
-	TotalLogins        int    `gorm:"size:255"`
-	TotalUsageDuration int    `gorm:"size:255"`
+	LastLogin          string `gorm:"size:255"` // RFC 3339
+	TotalLogins        int    `gorm:"default:0"`
+	TotalUsageDuration int    `gorm:"default:0"` // seconds
+}
+
+type PublicUserMetrics struct {
+	UserID             uint      `json:"user_id"`
+	FavoriteServiceID  uint      `json:"favorite_service_id"`
+	LastIP             string    `json:"last_ip"`
+	LastLogin          string    `json:"last_login"`
+	TotalLogins        int       `json:"total_logins"`
+	TotalUsageDuration int       `json:"total_usage_duration"` // seconds
+	UpdatedAt          time.Time `json:"updated_at"`           // when the metrics were last rolled up
 }
 
 type UserMetricsRepository interface {
-	Create(ctx context.Context, userMetrics *UserMetrics) error
-	Fetch(ctx context.Context) ([]UserMetrics, error)
-	GetByID(ctx context.Context, id uint) (UserMetrics, error)
 	GetByUserID(ctx context.Context, userID uint) (UserMetrics, error)
-	Update(ctx context.Context, userMetricsID uint, userMetrics *UserMetrics) error
-	Delete(ctx context.Context, userMetricsID uint) error
+	// Recompute rolls up the metrics of every user from the logs and returns how many users were updated
+	Recompute(ctx context.Context) (int, error)
 }
 
 type UserMetricsUsecase interface {
-	Create(ctx context.Context, userMetrics *UserMetrics) error
-	Fetch(ctx context.Context) ([]UserMetrics, error)
-	GetByID(ctx context.Context, id uint) (UserMetrics, error)
-	GetByUserID(ctx context.Context, userID uint) (UserMetrics, error)
-	Update(ctx context.Context, userMetricsID uint, userMetrics *UserMetrics) error
-	Delete(ctx context.Context, userMetricsID uint) error
+	GetByUserID(ctx context.Context, userID uint) (PublicUserMetrics, error)
+	// Recompute rolls up the metrics of every user, it is run periodically by the metrics job
+	Recompute(ctx context.Context) (int, error)
 }
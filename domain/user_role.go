@@ -9,10 +9,14 @@ import (
 // ONE TO MANY WITH USER
 // Admin, Manager, User, Guest
 
+// GuestRoleName is the UserRole of the account used by the guest login
+const GuestRoleName = "Guest"
+
 type UserRole struct {
 	gorm.Model
-	RoleName string `gorm:"size:255;uniqueIndex;not null"`
-	Users    []User `gorm:"foreignKey:RoleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	RoleName    string       `gorm:"size:255;uniqueIndex;not null"`
+	Users       []User       `gorm:"foreignKey:RoleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Permissions []Permission `gorm:"many2many:user_role_permissions;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 type UserRoleRepository interface {
@@ -21,6 +25,7 @@ type UserRoleRepository interface {
 	GetByID(ctx context.Context, id uint) (UserRole, error)
 	GetByRoleName(ctx context.Context, roleName string) (UserRole, error)
 	Update(ctx context.Context, userRoleID uint, userRole *UserRole) error
+	ReplacePermissions(ctx context.Context, userRoleID uint, permissions []Permission) error
 	Delete(ctx context.Context, userRoleID uint) error
 }
 
@@ -2,7 +2,6 @@ package domain
 
 import (
 	"context"
-
 	"time"
 
 	"gorm.io/gorm"
@@ -10,19 +9,40 @@ import (
 
 // MANY TO ONE WITH USER
 // MANY TO ONE WITH SERVICE
+// Each log is a usage session: started by ServiceUsecase.Use, kept alive by heartbeats and closed by the user
+// or, when the heartbeats stop, by the usage session job. The duration is computed by the server from the
+// heartbeat timestamps, a gap longer than the max gap only counts as the max gap.
 
 type UserServiceLog struct {
 	gorm.Model
-	UserID    uint          `gorm:"not null;Index"`
-	ServiceID uint          `gorm:"not null;Index"`
-	Duration  time.Duration `gorm:"default:0"` // Duration in seconds;
+	UserID          uint       `gorm:"not null;Index"`
+	ServiceID       uint       `gorm:"not null;Index"`
+	Status          string     `gorm:"size:20;not null;default:ended;Index"`
+	Duration        int        `gorm:"default:0"` // Duration in seconds
+	LastHeartbeatAt time.Time  `gorm:""`
+	EndedAt         *time.Time `gorm:""`
 }
 
+const (
+	UsageSessionActive  = "active"
+	UsageSessionEnded   = "ended"   // closed by the user
+	UsageSessionExpired = "expired" // closed by the usage session job after the heartbeats stopped
+)
+
+const (
+	DefaultUsageHeartbeatMaxGap = 2 * time.Minute
+	DefaultUsageSessionIdle     = 5 * time.Minute
+)
+
 type PublicUserServiceLog struct {
-	ID        uint `json:"id"`
-	UserID    uint `json:"user_id"`
-	ServiceID uint `json:"service_id"`
-	Duration  int  `json:"duration"`
+	ID              uint       `json:"id"`
+	UserID          uint       `json:"user_id"`
+	ServiceID       uint       `json:"service_id"`
+	Status          string     `json:"status"`
+	Duration        int        `json:"duration"` // seconds
+	StartedAt       time.Time  `json:"started_at"`
+	LastHeartbeatAt time.Time  `json:"last_heartbeat_at"`
+	EndedAt         *time.Time `json:"ended_at"`
 }
 
 type UserServiceLogRepository interface {
@@ -31,7 +51,16 @@ type UserServiceLogRepository interface {
 	GetByID(ctx context.Context, id uint) (UserServiceLog, error)
 	GetByUserID(ctx context.Context, userID uint) (UserServiceLog, error)
 	GetByServiceID(ctx context.Context, serviceID uint) (UserServiceLog, error)
-	UpdateDuration(ctx context.Context, UserServiceLogID uint, duration int) error
+	// UpdateSession saves the duration, heartbeat and status of an active session, ErrUsageSessionClosed when it is no longer active
+	UpdateSession(ctx context.Context, userServiceLog *UserServiceLog) error
+	// CloseIdle expires the active sessions without heartbeats since before, returns how many were closed and the organizations of their users
+	CloseIdle(ctx context.Context, before time.Time) (int64, []uint, error)
+	FetchActiveByOrganization(ctx context.Context, organizationID uint) ([]ActiveUsageSession, error)
+	CountActiveByService(ctx context.Context) (map[uint]int64, error)
+	// StreamForExport calls fn for each session of the filter, reading one row at a time
+	StreamForExport(ctx context.Context, filter ExportFilter, fn func(row UsageExportRow) error) error
+	// SumDurationByOrganization returns the seconds of usage of each service by the users of the organization in [start, end)
+	SumDurationByOrganization(ctx context.Context, organizationID uint, start time.Time, end time.Time) (map[uint]int64, error)
 	Delete(ctx context.Context, UserServiceLogID uint) error
 }
 
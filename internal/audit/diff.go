@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Redacted replaces the values of sensitive fields in the diff, a change is still recorded
+const Redacted = "[redacted]"
+
+// fields whose values never reach the audit trail
+var sensitiveFields = map[string]bool{"password": true}
+
+// Change is the before/after pair of a field, a side is omitted when the field did not exist
+type Change struct {
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// Diff compares two snapshots of an entity (structs with json tags or maps, nil for a missing side) and
+// returns the fields that differ as a JSON object {"field": {"before": ..., "after": ...}}
+func Diff(before interface{}, after interface{}) (string, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return "", err
+	}
+	afterFields, err := toFields(after)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(beforeFields)+len(afterFields))
+	for name := range beforeFields {
+		names = append(names, name)
+	}
+	for name := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := make(map[string]Change)
+	for _, name := range names {
+		beforeValue, inBefore := beforeFields[name]
+		afterValue, inAfter := afterFields[name]
+		if inBefore && inAfter && reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+		if sensitiveFields[name] {
+			if inBefore {
+				beforeValue = Redacted
+			}
+			if inAfter {
+				afterValue = Redacted
+			}
+		}
+		changes[name] = Change{Before: beforeValue, After: afterValue}
+	}
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	// encoding/json sorts map keys, the output is stable and can be hashed
+	content, err := json.Marshal(changes)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// toFields converts a snapshot to its JSON fields
+func toFields(snapshot interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	if snapshot == nil || (reflect.ValueOf(snapshot).Kind() == reflect.Ptr && reflect.ValueOf(snapshot).IsNil()) {
+		return fields, nil
+	}
+	content, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
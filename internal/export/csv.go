@@ -0,0 +1,37 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// newCSVWriter grava o BOM UTF-8 para que o Excel reconheça os acentos
+func newCSVWriter(w io.Writer) (Writer, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	return &csvWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (cw *csvWriter) WriteRow(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		text, numeric := formatValue(value)
+		// textos que começam como fórmula são prefixados para não serem executados pela planilha
+		if !numeric && text != "" && strings.ContainsRune("=+-@", rune(text[0])) {
+			text = "'" + text
+		}
+		record[i] = text
+	}
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+// Writer grava uma planilha linha a linha, sem manter as linhas em memória.
+// Os valores podem ser string, inteiros, float64, time.Time ou *time.Time (nil vira célula vazia)
+type Writer interface {
+	WriteRow(values ...interface{}) error
+	// Close finaliza o documento, nada é gravado depois dele
+	Close() error
+}
+
+// NewWriter escolhe o formato, sheet é o nome da aba no XLSX
+func NewWriter(format string, w io.Writer, sheet string) (Writer, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		return newCSVWriter(w)
+	case domain.ExportFormatXLSX:
+		return newXLSXWriter(w, sheet)
+	default:
+		return nil, domain.ErrInvalidExportFormat
+	}
+}
+
+// formatValue converte um valor para texto, datas no formato "2006-01-02 15:04:05" em UTC
+func formatValue(value interface{}) (text string, numeric bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, false
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case time.Time:
+		if v.IsZero() {
+			return "", false
+		}
+		return v.UTC().Format("2006-01-02 15:04:05"), false
+	case *time.Time:
+		if v == nil {
+			return "", false
+		}
+		return formatValue(*v)
+	default:
+		return "", false
+	}
+}
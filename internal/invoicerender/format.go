@@ -0,0 +1,62 @@
+package invoicerender
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+// formatMoney formata um valor em reais, e.g. 1234.5 -> "R$ 1.234,50"
+func formatMoney(value float64) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	cents := int64(math.Round(value * 100))
+	integer := fmt.Sprintf("%d", cents/100)
+
+	var groups []string
+	for len(integer) > 3 {
+		groups = append([]string{integer[len(integer)-3:]}, groups...)
+		integer = integer[:len(integer)-3]
+	}
+	groups = append([]string{integer}, groups...)
+
+	return fmt.Sprintf("%sR$ %s,%02d", sign, strings.Join(groups, "."), cents%100)
+}
+
+// formatHours formata horas com duas casas decimais, e.g. 1.5 -> "1,50"
+func formatHours(hours float64) string {
+	return strings.Replace(fmt.Sprintf("%.2f", hours), ".", ",", 1)
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("02/01/2006")
+}
+
+// formatPeriod formata o mês de referência, e.g. "09/2026"
+func formatPeriod(t time.Time) string {
+	return t.Format("01/2006")
+}
+
+func statusLabel(status string) string {
+	switch status {
+	case domain.InvoiceStatusDraft:
+		return "Rascunho"
+	case domain.InvoiceStatusIssued:
+		return "Emitida"
+	case domain.InvoiceStatusPaid:
+		return "Paga"
+	case domain.InvoiceStatusVoid:
+		return "Cancelada"
+	default:
+		return status
+	}
+}
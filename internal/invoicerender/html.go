@@ -0,0 +1,58 @@
+package invoicerender
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+var htmlTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"money":  formatMoney,
+	"hours":  formatHours,
+	"date":   formatDate,
+	"period": formatPeriod,
+	"status": statusLabel,
+}).Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>Fatura {{.Number}} - {{.OrganizationName}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; color: #222; margin: 40px; }
+h1 { margin-bottom: 4px; }
+.muted { color: #666; }
+table { width: 100%; border-collapse: collapse; margin-top: 24px; }
+th, td { padding: 8px; border-bottom: 1px solid #ddd; text-align: left; }
+td.num, th.num { text-align: right; }
+tfoot td { font-weight: bold; border-bottom: none; }
+</style>
+</head>
+<body>
+<h1>Fatura {{if .Number}}{{.Number}}{{else}}(rascunho){{end}}</h1>
+<p class="muted">Status: {{status .Status}}{{if .IssuedAt}} &middot; Emitida em {{date .IssuedAt}}{{end}}{{if .PaidAt}} &middot; Paga em {{date .PaidAt}}{{end}}{{if .VoidedAt}} &middot; Cancelada em {{date .VoidedAt}}{{end}}</p>
+<p><strong>{{.OrganizationName}}</strong><br>Período de referência: {{period .PeriodStart}}</p>
+<table>
+<thead>
+<tr><th>Serviço</th><th class="num">Valor fixo</th><th class="num">Horas de uso</th><th class="num">Valor/hora</th><th class="num">Valor de uso</th><th class="num">Total</th></tr>
+</thead>
+<tbody>
+{{range .Items}}<tr><td>{{.Description}}</td><td class="num">{{money .FlatPrice}}</td><td class="num">{{hours .UsageHours}}</td><td class="num">{{if .HourlyPrice}}{{money .HourlyPrice}}{{else}}-{{end}}</td><td class="num">{{money .UsageAmount}}</td><td class="num">{{money .Amount}}</td></tr>
+{{else}}<tr><td colspan="6" class="muted">Nenhum serviço no período</td></tr>
+{{end}}</tbody>
+<tfoot>
+<tr><td colspan="5">Total</td><td class="num">{{money .Total}}</td></tr>
+</tfoot>
+</table>
+</body>
+</html>
+`))
+
+// HTML renderiza a fatura como uma página HTML autocontida
+func HTML(invoice domain.PublicInvoice) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, invoice); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,172 @@
+package invoicerender
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+// Gerador de PDF mínimo (PDF 1.4, fontes padrão Helvetica/Courier e codificação WinAnsi), suficiente para
+// uma fatura de texto sem depender de bibliotecas ou serviços externos. A tabela usa Courier (monoespaçada)
+// para que as colunas fiquem alinhadas apenas com preenchimento de espaços.
+
+const (
+	pageWidth    = 595.0 // A4 em pontos
+	pageHeight   = 842.0
+	pageMargin   = 50.0
+	tableSize    = 8.5
+	linesPerPage = 60
+)
+
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+	fontMono    = "F3"
+)
+
+type pdfLine struct {
+	font string
+	size float64
+	text string
+}
+
+// PDF renderiza a fatura como um documento PDF A4
+func PDF(invoice domain.PublicInvoice) ([]byte, error) {
+	number := invoice.Number
+	if number == "" {
+		number = "(rascunho)"
+	}
+
+	status := "Status: " + statusLabel(invoice.Status)
+	if invoice.IssuedAt != nil {
+		status += " - Emitida em " + formatDate(invoice.IssuedAt)
+	}
+	if invoice.PaidAt != nil {
+		status += " - Paga em " + formatDate(invoice.PaidAt)
+	}
+	if invoice.VoidedAt != nil {
+		status += " - Cancelada em " + formatDate(invoice.VoidedAt)
+	}
+
+	lines := []pdfLine{
+		{fontBold, 18, "Fatura " + number},
+		{fontRegular, 10, status},
+		{fontRegular, 10, ""},
+		{fontBold, 12, invoice.OrganizationName},
+		{fontRegular, 10, "Período de referência: " + formatPeriod(invoice.PeriodStart)},
+		{fontRegular, 10, ""},
+		{fontMono, tableSize, tableRow("Serviço", "Valor fixo", "Horas", "Valor/hora", "Valor de uso", "Total")},
+		{fontMono, tableSize, strings.Repeat("-", 93)},
+	}
+	for _, item := range invoice.Items {
+		hourlyPrice := "-"
+		if item.HourlyPrice != 0 {
+			hourlyPrice = formatMoney(item.HourlyPrice)
+		}
+		lines = append(lines, pdfLine{fontMono, tableSize, tableRow(
+			item.Description, formatMoney(item.FlatPrice), formatHours(item.UsageHours),
+			hourlyPrice, formatMoney(item.UsageAmount), formatMoney(item.Amount),
+		)})
+	}
+	if len(invoice.Items) == 0 {
+		lines = append(lines, pdfLine{fontMono, tableSize, "Nenhum serviço no período"})
+	}
+	lines = append(lines,
+		pdfLine{fontMono, tableSize, strings.Repeat("-", 93)},
+		pdfLine{fontBold, 11, "Total: " + formatMoney(invoice.Total)},
+	)
+
+	return writePDF(lines), nil
+}
+
+// tableRow alinha a descrição à esquerda e os valores à direita
+func tableRow(description, flatPrice, hours, hourlyPrice, usageAmount, total string) string {
+	runes := []rune(description)
+	if len(runes) > 26 {
+		description = string(runes[:25]) + "…"
+	}
+	return fmt.Sprintf("%-26s %13s %8s %13s %14s %14s", description, flatPrice, hours, hourlyPrice, usageAmount, total)
+}
+
+// writePDF monta o documento: catálogo, árvore de páginas, fontes e uma página (com o seu conteúdo) a cada linesPerPage linhas
+func writePDF(lines []pdfLine) []byte {
+	var pages [][]pdfLine
+	for start := 0; start < len(lines); start += linesPerPage {
+		end := start + linesPerPage
+		if end > len(lines) {
+			end = len(lines)
+		}
+		pages = append(pages, lines[start:end])
+	}
+
+	// objetos 1-5 são fixos, cada página ocupa dois objetos (página e conteúdo)
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // árvore de páginas, preenchida depois de conhecer os ids das páginas
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+	}
+	var kids []string
+	for _, page := range pages {
+		pageID := len(objects) + 1
+		contentID := pageID + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageID))
+
+		content := pageContent(page)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /%s 3 0 R /%s 4 0 R /%s 5 0 R >> >> /Contents %d 0 R >>",
+				pageWidth, pageHeight, fontRegular, fontBold, fontMono, contentID),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// pageContent escreve as linhas de cima para baixo, o espaçamento acompanha o tamanho da fonte
+func pageContent(lines []pdfLine) string {
+	var content strings.Builder
+	y := pageHeight - pageMargin
+	for _, line := range lines {
+		y -= line.size * 1.4
+		fmt.Fprintf(&content, "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n", line.font, line.size, pageMargin, y, escapePDFText(line.text))
+	}
+	return content.String()
+}
+
+// escapePDFText converte o texto para WinAnsi (Latin-1 na prática) e escapa os caracteres especiais de strings PDF
+func escapePDFText(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			out.WriteByte('\\')
+			out.WriteByte(byte(r))
+		case r == '…':
+			out.WriteByte(0x85)
+		case r < 256:
+			out.WriteByte(byte(r))
+		default:
+			out.WriteByte('?')
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,78 @@
+// Package logger builds the slog.Logger of the application: JSON in production, text elsewhere, every line
+// written with a context carries the request id, user, service account, organization and trace found in it, and
+// every attribute and message goes through ScrubString so tokens, passwords and secrets are never written
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ParseLevel converts debug, info, warn or error to a slog.Level
+func ParseLevel(level string) (slog.Level, bool) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	}
+	return slog.LevelInfo, false
+}
+
+// New returns the logger writing to w, json selects the JSON output
+func New(w io.Writer, level slog.Level, json bool) *slog.Logger {
+	options := &slog.HandlerOptions{Level: level, ReplaceAttr: scrubAttr}
+	var handler slog.Handler
+	if json {
+		handler = slog.NewJSONHandler(w, options)
+	} else {
+		handler = slog.NewTextHandler(w, options)
+	}
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// contextHandler adds the request attributes found in the context and scrubs the message
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	record.Message = ScrubString(record.Message)
+	if ctx != nil {
+		if metadata := domain.RequestMetadataFromContext(ctx); metadata.RequestID != "" {
+			record.AddAttrs(slog.String("request_id", metadata.RequestID))
+		}
+		if principal, ok := domain.PrincipalFromContext(ctx); ok {
+			if principal.UserID != 0 {
+				record.AddAttrs(slog.Uint64("user_id", uint64(principal.UserID)))
+			}
+			if principal.ServiceAccountID != 0 {
+				record.AddAttrs(slog.Uint64("service_account_id", uint64(principal.ServiceAccountID)))
+			}
+			if principal.OrganizationID != 0 {
+				record.AddAttrs(slog.Uint64("organization_id", uint64(principal.OrganizationID)))
+			}
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[redacted]"
+
+// attribute keys whose values are always redacted, matched as substrings of the lowercased key
+var sensitiveKeys = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "api_key", "apikey", "credential"}
+
+// values that are redacted wherever they appear in a message or string attribute
+var sensitivePatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	// "Authorization: Bearer <jwt>", "ApiKey <key>"
+	{regexp.MustCompile(`(?i)\b(bearer|apikey|basic)\s+[^\s"',;]+`), "$1 " + redacted},
+	// password=..., "access_token": "...", secret: ...
+	{regexp.MustCompile(`(?i)((?:password|passwd|secret|token|api_?key)[a-z_]*["']?\s*[:=]\s*["']?)[^\s"'&,;}]+`), "${1}" + redacted},
+	// JWTs
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`), redacted},
+	// service account keys, the public prefix is kept
+	{regexp.MustCompile(`\b(pck_[0-9a-f]+)\.[0-9a-f]+`), "$1." + redacted},
+	// bcrypt hashes
+	{regexp.MustCompile(`\$2[aby]?\$\d{2}\$[./A-Za-z0-9]{53}`), redacted},
+	// opaque tokens of password reset and invitation links
+	{regexp.MustCompile(`\b[0-9a-f]{64}\b`), redacted},
+}
+
+// ScrubString redacts the tokens, keys, hashes and key=value secrets found in s
+func ScrubString(s string) string {
+	for _, p := range sensitivePatterns {
+		s = p.pattern.ReplaceAllString(s, p.replacement)
+	}
+	return s
+}
+
+// IsSensitiveKey reports whether values under key must never be logged
+func IsSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// scrubAttr is the slog.HandlerOptions.ReplaceAttr of the loggers: sensitive keys are redacted and the
+// strings, errors and fmt.Stringers are scrubbed
+func scrubAttr(groups []string, attr slog.Attr) slog.Attr {
+	if IsSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, ScrubString(attr.Value.String()))
+	case slog.KindAny:
+		switch value := attr.Value.Any().(type) {
+		case error:
+			return slog.String(attr.Key, ScrubString(value.Error()))
+		case fmt.Stringer:
+			return slog.String(attr.Key, ScrubString(value.String()))
+		}
+	}
+	return attr
+}
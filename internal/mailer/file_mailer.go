@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+type fileMailer struct {
+	outputDir string
+	from      string
+	sequence  atomic.Uint64
+}
+
+// NewFileMailer returns a Mailer that writes every message as an .eml file into outputDir instead of sending it.
+// It is meant for local development and tests, where the reset links can be read straight from disk.
+func NewFileMailer(outputDir string, from string) domain.Mailer {
+	return &fileMailer{
+		outputDir: outputDir,
+		from:      from,
+	}
+}
+
+func (m *fileMailer) Send(ctx context.Context, message *domain.MailMessage) error {
+	if err := os.MkdirAll(m.outputDir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%04d.eml", time.Now().Format("20060102T150405"), m.sequence.Add(1))
+	path := filepath.Join(m.outputDir, name)
+	if err := os.WriteFile(path, buildMessage(m.from, message), 0o600); err != nil {
+		return err
+	}
+
+	log.Printf("[FileMailer] %q to %v written to %s\n", message.Subject, message.To, path)
+	return nil
+}
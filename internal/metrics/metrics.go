@@ -0,0 +1,191 @@
+// Package metrics exposes the runtime metrics of the application in the Prometheus text format: HTTP requests
+// per route template, the database pool, logins, usage sessions and the build. The Registry implements
+// domain.RuntimeMetrics, through which the usecases record their events
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "platform_core"
+
+// Version is the version of the build, set with -ldflags "-X github.com/gabrielfmcoelho/platform-core/internal/metrics.Version=..."
+var Version = "dev"
+
+// Login outcomes of the platform_core_logins_total counter
+const (
+	LoginSuccess            = "success"
+	LoginInvalidCredentials = "invalid_credentials"
+	LoginLocked             = "locked"
+	LoginUnavailable        = "unavailable"
+	LoginError              = "error"
+)
+
+type Registry struct {
+	registry        *prometheus.Registry
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	logins          *prometheus.CounterVec
+	sessionsStarted *prometheus.CounterVec
+	heartbeats      *prometheus.CounterVec
+}
+
+// New creates the registry with the Go runtime, process and build metrics
+func New() *Registry {
+	r := &Registry{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of the HTTP requests, by method and route template.",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"method", "route"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Login attempts, by method (password, guest, refresh) and outcome.",
+		}, []string{"method", "outcome"}),
+		sessionsStarted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "usage_sessions_started_total",
+			Help:      "Usage sessions started, by service.",
+		}, []string{"service_id"}),
+		heartbeats: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "usage_heartbeats_total",
+			Help:      "Heartbeats and ends of usage sessions accepted, by service.",
+		}, []string{"service_id"}),
+	}
+
+	revision, goVersion := buildRevision()
+	buildInfo := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "build_info",
+		Help:        "Always 1, labeled with the version, VCS revision and Go version of the binary.",
+		ConstLabels: prometheus.Labels{"version": Version, "revision": revision, "goversion": goVersion},
+	})
+	buildInfo.Set(1)
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		buildInfo,
+		r.httpRequests,
+		r.httpDuration,
+		r.logins,
+		r.sessionsStarted,
+		r.heartbeats,
+	)
+	return r
+}
+
+// buildRevision returns the VCS revision embedded by go build and the Go version
+func buildRevision() (string, string) {
+	revision := "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				revision = setting.Value
+			}
+		}
+	}
+	return revision, runtime.Version()
+}
+
+// RegisterDB exposes the connection pool stats of db (go_sql_* metrics labeled db_name)
+func (r *Registry) RegisterDB(db *sql.DB, name string) {
+	r.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterActiveSessions exposes the active usage sessions per service, counted by count on every scrape
+func (r *Registry) RegisterActiveSessions(count func(ctx context.Context) (map[uint]int64, error)) {
+	r.registry.MustRegister(&activeSessionsCollector{count: count})
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
+
+// ObserveRequest records a served HTTP request, route is the route template ("/user/:identifier")
+func (r *Registry) ObserveRequest(method string, route string, status int, elapsed time.Duration) {
+	r.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	r.httpDuration.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+func (r *Registry) LoginAttempt(method string, err error) {
+	r.logins.WithLabelValues(method, loginOutcome(err)).Inc()
+}
+
+func (r *Registry) UsageSessionStarted(serviceID uint) {
+	r.sessionsStarted.WithLabelValues(strconv.FormatUint(uint64(serviceID), 10)).Inc()
+}
+
+func (r *Registry) UsageHeartbeat(serviceID uint) {
+	r.heartbeats.WithLabelValues(strconv.FormatUint(uint64(serviceID), 10)).Inc()
+}
+
+func loginOutcome(err error) string {
+	switch {
+	case err == nil:
+		return LoginSuccess
+	case errors.Is(err, domain.ErrUserEmailNotFound), errors.Is(err, domain.ErrUserPasswordNotMatch),
+		errors.Is(err, domain.ErrInvalidRefreshToken), errors.Is(err, domain.ErrRefreshTokenReused):
+		return LoginInvalidCredentials
+	case errors.Is(err, domain.ErrAccountLocked):
+		return LoginLocked
+	case errors.Is(err, domain.ErrGuestLoginUnavailable):
+		return LoginUnavailable
+	default:
+		return LoginError
+	}
+}
+
+// activeSessionsCollector queries the active usage sessions when scraped, a gauge kept in memory would drift
+// from the database with restarts, several instances and the sessions expired by the job
+type activeSessionsCollector struct {
+	count func(ctx context.Context) (map[uint]int64, error)
+}
+
+var activeSessionsDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "usage_sessions_active"),
+	"Usage sessions active right now, by service.",
+	[]string{"service_id"}, nil,
+)
+
+func (c *activeSessionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeSessionsDesc
+}
+
+func (c *activeSessionsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	counts, err := c.count(ctx)
+	if err != nil {
+		slog.Error("metrics: counting active usage sessions failed", "error", err)
+		ch <- prometheus.NewInvalidMetric(activeSessionsDesc, err)
+		return
+	}
+	for serviceID, count := range counts {
+		ch <- prometheus.MustNewConstMetric(activeSessionsDesc, prometheus.GaugeValue, float64(count), strconv.FormatUint(uint64(serviceID), 10))
+	}
+}
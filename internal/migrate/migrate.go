@@ -0,0 +1,385 @@
+// Package migrate applies the versioned SQL migrations embedded in the binary and keeps their history in the
+// schema_migrations table. Every step runs in its own transaction holding a database wide lock, so concurrent
+// runners (e.g. several replicas starting at once) apply each version exactly once
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// advisory lock key shared by every runner on postgres
+const lockKey = 7411201700
+
+// Migration states reported by Status
+const (
+	StatePending  = "pending"
+	StateApplied  = "applied"
+	StateModified = "modified" // applied, but the file changed since then
+	StateMissing  = "missing"  // applied, but the binary has no file for it
+)
+
+var (
+	// ErrChecksumMismatch means an applied migration file was edited after it ran
+	ErrChecksumMismatch = errors.New("applied migration was modified")
+	// ErrUnknownVersion means the database has a version the binary does not know or a target version does not exist
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var createTable = map[string]string{
+	"postgres": `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name text NOT NULL,
+	checksum char(64) NOT NULL,
+	applied_at timestamptz NOT NULL
+)`,
+	"sqlite": `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version integer PRIMARY KEY,
+	name text NOT NULL,
+	checksum text NOT NULL,
+	applied_at datetime NOT NULL
+)`,
+}
+
+// Migration is one version of the schema, Checksum is the sha256 of the up file
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Status is the state of a migration in the database
+type Status struct {
+	Version   int64
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+}
+
+// New loads the migrations of the dialect of db from the <dialect>/ directory of files
+func New(db *gorm.DB, files fs.FS) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	if _, ok := createTable[dialect]; !ok {
+		return nil, fmt.Errorf("migrations are not supported on %s", dialect)
+	}
+	migrations, err := load(files, dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// load reads and pairs the up and down files, sorted by version
+func load(files fs.FS, dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+		content, err := fs.ReadFile(files, dialect+"/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files with different names", version)
+		}
+		if match[3] == "up" {
+			sum := sha256.Sum256(content)
+			migration.Up = string(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrations returns the migrations known by the binary, oldest first
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up applies every pending migration and returns how many were applied
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the last steps applied migrations and returns how many were reverted
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	for reverted < steps {
+		done := false
+		err := m.locked(ctx, func(tx *gorm.DB, applied map[int64]appliedMigration) error {
+			current := currentVersion(applied)
+			if current == 0 {
+				done = true
+				return nil
+			}
+			return m.revert(ctx, tx, m.find(current))
+		})
+		if err != nil || done {
+			return reverted, err
+		}
+		reverted++
+	}
+	return reverted, nil
+}
+
+// To applies or reverts migrations until version is the last one applied (0 reverts everything) and returns
+// how many steps were taken
+func (m *Migrator) To(ctx context.Context, version int64) (int, error) {
+	if version != 0 && m.find(version) == nil {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	steps := 0
+	for {
+		done := false
+		err := m.locked(ctx, func(tx *gorm.DB, applied map[int64]appliedMigration) error {
+			if current := currentVersion(applied); current > version {
+				return m.revert(ctx, tx, m.find(current))
+			}
+			for i := range m.migrations {
+				migration := &m.migrations[i]
+				if migration.Version > version {
+					break
+				}
+				if _, ok := applied[migration.Version]; !ok {
+					return m.apply(ctx, tx, migration, true)
+				}
+			}
+			done = true
+			return nil
+		})
+		if err != nil || done {
+			return steps, err
+		}
+		steps++
+	}
+}
+
+// Baseline records the migrations up to version as applied without running them, for databases whose
+// schema was created before the migrations existed
+func (m *Migrator) Baseline(ctx context.Context, version int64) error {
+	return m.locked(ctx, func(tx *gorm.DB, applied map[int64]appliedMigration) error {
+		for i := range m.migrations {
+			migration := &m.migrations[i]
+			if migration.Version > version {
+				break
+			}
+			if _, ok := applied[migration.Version]; !ok {
+				if err := m.apply(ctx, tx, migration, false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Status lists the migrations known by the binary and those only found in the database
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var applied map[int64]appliedMigration
+	err := m.locked(ctx, func(tx *gorm.DB, current map[int64]appliedMigration) error {
+		applied = current
+		return nil
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name, State: StatePending}
+		if row, ok := applied[migration.Version]; ok {
+			status.State = StateApplied
+			if row.Checksum != migration.Checksum {
+				status.State = StateModified
+			}
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	for _, row := range applied {
+		if m.find(row.Version) == nil {
+			appliedAt := row.AppliedAt
+			statuses = append(statuses, Status{Version: row.Version, Name: row.Name, State: StateMissing, AppliedAt: &appliedAt})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Version returns the last applied version, 0 when none was applied
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var version int64
+	err := m.locked(ctx, func(tx *gorm.DB, applied map[int64]appliedMigration) error {
+		version = currentVersion(applied)
+		return nil
+	}, true)
+	return version, err
+}
+
+// apply runs the up file (unless run is false) and records the version
+func (m *Migrator) apply(ctx context.Context, tx *gorm.DB, migration *Migration, run bool) error {
+	if run {
+		if _, err := tx.Statement.ConnPool.ExecContext(ctx, migration.Up); err != nil {
+			return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return tx.Exec(
+		"INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)",
+		migration.Version, migration.Name, migration.Checksum, time.Now().UTC(),
+	).Error
+}
+
+// revert runs the down file and forgets the version
+func (m *Migrator) revert(ctx context.Context, tx *gorm.DB, migration *Migration) error {
+	if _, err := tx.Statement.ConnPool.ExecContext(ctx, migration.Down); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version).Error
+}
+
+// locked runs fn in a transaction holding the migration lock, with the applied migrations read inside it.
+// The applied migrations are validated against the files unless skipValidation is set (Status reports instead)
+func (m *Migrator) locked(ctx context.Context, fn func(tx *gorm.DB, applied map[int64]appliedMigration) error, skipValidation ...bool) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) (err error) {
+		// BEGIN IMMEDIATE takes the sqlite write lock upfront, two deferred transactions would deadlock on upgrade
+		begin := "BEGIN"
+		if m.dialect == "sqlite" {
+			begin = "BEGIN IMMEDIATE"
+		}
+		if err := conn.Exec(begin).Error; err != nil {
+			return err
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				conn.Exec("ROLLBACK")
+				panic(r)
+			}
+			if err != nil {
+				conn.Exec("ROLLBACK")
+				return
+			}
+			err = conn.Exec("COMMIT").Error
+		}()
+
+		if m.dialect == "postgres" {
+			if err := conn.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+				return err
+			}
+		}
+		if err := conn.Exec(createTable[m.dialect]).Error; err != nil {
+			return err
+		}
+
+		var rows []appliedMigration
+		if err := conn.Table("schema_migrations").Order("version").Find(&rows).Error; err != nil {
+			return err
+		}
+		applied := make(map[int64]appliedMigration, len(rows))
+		for _, row := range rows {
+			applied[row.Version] = row
+		}
+		if len(skipValidation) == 0 || !skipValidation[0] {
+			if err := m.validate(applied); err != nil {
+				return err
+			}
+		}
+		return fn(conn, applied)
+	})
+}
+
+// validate refuses to migrate when an applied migration was edited or is unknown to the binary
+func (m *Migrator) validate(applied map[int64]appliedMigration) error {
+	for _, row := range applied {
+		migration := m.find(row.Version)
+		if migration == nil {
+			return fmt.Errorf("%w: %d_%s is applied but has no file", ErrUnknownVersion, row.Version, row.Name)
+		}
+		if migration.Checksum != row.Checksum {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, row.Version, row.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// Latest returns the last version known by the binary
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func currentVersion(applied map[int64]appliedMigration) int64 {
+	var current int64
+	for version := range applied {
+		if version > current {
+			current = version
+		}
+	}
+	return current
+}
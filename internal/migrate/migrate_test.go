@@ -0,0 +1,143 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gabrielfmcoelho/platform-core/migrations"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	return db
+}
+
+func testFiles() fstest.MapFS {
+	return fstest.MapFS{
+		"sqlite/0001_things.up.sql":     {Data: []byte("CREATE TABLE things (id integer PRIMARY KEY);")},
+		"sqlite/0001_things.down.sql":   {Data: []byte("DROP TABLE things;")},
+		"sqlite/0002_widgets.up.sql":    {Data: []byte("CREATE TABLE widgets (id integer PRIMARY KEY);")},
+		"sqlite/0002_widgets.down.sql":  {Data: []byte("DROP TABLE widgets;")},
+		"sqlite/README.md":              {Data: []byte("ignored")},
+		"postgres/0001_things.up.sql":   {Data: []byte("CREATE TABLE things (id bigint PRIMARY KEY);")},
+		"postgres/0001_things.down.sql": {Data: []byte("DROP TABLE things;")},
+	}
+}
+
+func TestUp(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fs.FS
+	}{
+		{name: "test files", files: testFiles()},
+		{name: "embedded migrations", files: migrations.Files},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := openSQLite(t)
+			migrator, err := New(db, tt.files)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			applied, err := migrator.Up(ctx)
+			if err != nil {
+				t.Fatalf("Up: %v", err)
+			}
+			if applied != len(migrator.Migrations()) {
+				t.Errorf("Up applied %d migrations, want %d", applied, len(migrator.Migrations()))
+			}
+			version, err := migrator.Version(ctx)
+			if err != nil {
+				t.Fatalf("Version: %v", err)
+			}
+			if version != migrator.Latest() {
+				t.Errorf("Version = %d, want %d", version, migrator.Latest())
+			}
+
+			applied, err = migrator.Up(ctx)
+			if err != nil {
+				t.Fatalf("second Up: %v", err)
+			}
+			if applied != 0 {
+				t.Errorf("second Up applied %d migrations, want 0", applied)
+			}
+		})
+	}
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(files fstest.MapFS)
+		wantErr error
+		state   string
+	}{
+		{
+			name:  "unchanged files",
+			edit:  func(files fstest.MapFS) {},
+			state: StateApplied,
+		},
+		{
+			name: "edited up file",
+			edit: func(files fstest.MapFS) {
+				files["sqlite/0001_things.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE things (id integer PRIMARY KEY, name text);")}
+			},
+			wantErr: ErrChecksumMismatch,
+			state:   StateModified,
+		},
+		{
+			name: "edited down file",
+			edit: func(files fstest.MapFS) {
+				files["sqlite/0001_things.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE IF EXISTS things;")}
+			},
+			state: StateApplied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := openSQLite(t)
+			files := testFiles()
+			migrator, err := New(db, files)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if _, err := migrator.Up(ctx); err != nil {
+				t.Fatalf("Up: %v", err)
+			}
+
+			tt.edit(files)
+			migrator, err = New(db, files)
+			if err != nil {
+				t.Fatalf("New after edit: %v", err)
+			}
+			if _, err := migrator.Up(ctx); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Up error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := migrator.Down(ctx, 1); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Down error = %v, want %v", err, tt.wantErr)
+			}
+
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				t.Fatalf("Status: %v", err)
+			}
+			if len(statuses) == 0 || statuses[0].State != tt.state {
+				t.Errorf("Status of 0001 = %+v, want state %s", statuses, tt.state)
+			}
+		})
+	}
+}
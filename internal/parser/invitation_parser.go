@@ -0,0 +1,20 @@
+package parser
+
+import (
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+// Parse Invitation to PublicInvitation
+func ToPublicInvitation(i domain.Invitation) domain.PublicInvitation {
+	return domain.PublicInvitation{
+		ID:               i.ID,
+		Email:            i.Email,
+		OrganizationID:   i.OrganizationID,
+		OrganizationName: i.Organization.Name,
+		RoleID:           i.RoleID,
+		RoleName:         i.Role.RoleName,
+		Status:           i.Status(),
+		ExpiresAt:        i.ExpiresAt,
+		CreatedAt:        i.CreatedAt,
+	}
+}
@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+// Parse Invoice to PublicInvoice
+func ToPublicInvoice(i domain.Invoice) domain.PublicInvoice {
+	number := ""
+	if i.Number != nil {
+		number = *i.Number
+	}
+
+	items := make([]domain.PublicInvoiceItem, 0, len(i.Items))
+	for _, item := range i.Items {
+		items = append(items, domain.PublicInvoiceItem{
+			ServiceID:    item.ServiceID,
+			Description:  item.Description,
+			FlatPrice:    item.FlatPrice,
+			UsageSeconds: item.UsageSeconds,
+			UsageHours:   float64(item.UsageSeconds) / 3600,
+			HourlyPrice:  item.HourlyPrice,
+			UsageAmount:  item.UsageAmount,
+			Amount:       item.Amount,
+		})
+	}
+
+	return domain.PublicInvoice{
+		ID:               i.ID,
+		Number:           number,
+		OrganizationID:   i.OrganizationID,
+		OrganizationName: i.Organization.Name,
+		Status:           i.Status,
+		PeriodStart:      i.PeriodStart,
+		PeriodEnd:        i.PeriodEnd,
+		Total:            i.Total,
+		IssuedAt:         i.IssuedAt,
+		PaidAt:           i.PaidAt,
+		VoidedAt:         i.VoidedAt,
+		Items:            items,
+	}
+}
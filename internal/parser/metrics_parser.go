@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+// Parse UserMetrics to PublicUserMetrics
+func ToPublicUserMetrics(m domain.UserMetrics) domain.PublicUserMetrics {
+	return domain.PublicUserMetrics{
+		UserID:             m.UserID,
+		FavoriteServiceID:  m.FavoriteServiceID,
+		LastIP:             m.LastIP,
+		LastLogin:          m.LastLogin,
+		TotalLogins:        m.TotalLogins,
+		TotalUsageDuration: m.TotalUsageDuration,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+// Parse OrganizationMetrics to PublicOrganizationMetrics
+func ToPublicOrganizationMetrics(m domain.OrganizationMetrics) domain.PublicOrganizationMetrics {
+	return domain.PublicOrganizationMetrics{
+		OrganizationID:           m.OrganizationID,
+		TotalServices:            m.TotalServices,
+		TotalUsers:               m.TotalUsers,
+		TotalReports:             m.TotalReports,
+		TotalReportsCurrentMonth: m.TotalReportsCurrentMonth,
+		LastReportDate:           m.LastReportDate,
+		NextReportDate:           m.NextReportDate,
+		UpdatedAt:                m.UpdatedAt,
+	}
+}
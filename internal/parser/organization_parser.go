@@ -7,9 +7,20 @@ import (
 // Parse Organization to PublicOrganization
 func ToPublicOrganization(org domain.Organization) domain.PublicOrganization {
 	return domain.PublicOrganization{
-		ID:       org.ID,
-		Name:     org.Name,
-		Nickname: org.Nickname,
-		LogoUrl:  org.LogoUrl,
+		ID:                 org.ID,
+		Name:               org.Name,
+		Nickname:           org.Nickname,
+		LogoUrl:            org.LogoUrl,
+		OrganizationRoleID: org.RoleID,
+	}
+}
+
+// Parse CreateOrganization to Organization
+func ToOrganization(co *domain.CreateOrganization) *domain.Organization {
+	return &domain.Organization{
+		Name:     co.Name,
+		Nickname: co.Nickname,
+		LogoUrl:  co.LogoUrl,
+		RoleID:   co.OrganizationRoleID,
 	}
 }
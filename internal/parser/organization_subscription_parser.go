@@ -0,0 +1,21 @@
+package parser
+
+import (
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+// Parse OrganizationSubscription to PublicSubscription
+func ToPublicSubscription(s domain.OrganizationSubscription) domain.PublicSubscription {
+	return domain.PublicSubscription{
+		ID:             s.ID,
+		OrganizationID: s.OrganizationID,
+		Active:         s.Active,
+		Value:          s.SubscriptionValue,
+		Period:         s.SubscriptionPeriod,
+		UsersLimit:     s.SubscriptionUsersLimit,
+		ReportsLimit:   s.SubscriptionReportsLimit,
+		InitDate:       s.SubscriptionInitDate,
+		EndDate:        s.SubscriptionEndDate,
+		CancelledAt:    s.CancelledAt,
+	}
+}
@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+// Parse Permission to PublicPermission
+func ToPublicPermission(p domain.Permission) domain.PublicPermission {
+	return domain.PublicPermission{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+	}
+}
+
+// Parse UserRole to PublicRole
+func ToPublicUserRole(r domain.UserRole) domain.PublicRole {
+	return domain.PublicRole{
+		ID:          r.ID,
+		RoleName:    r.RoleName,
+		Permissions: toPermissionNames(r.Permissions),
+	}
+}
+
+// Parse OrganizationRole to PublicRole
+func ToPublicOrganizationRole(r domain.OrganizationRole) domain.PublicRole {
+	return domain.PublicRole{
+		ID:          r.ID,
+		RoleName:    r.RoleName,
+		Permissions: toPermissionNames(r.Permissions),
+	}
+}
+
+func toPermissionNames(permissions []domain.Permission) []string {
+	names := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		names = append(names, p.Name)
+	}
+	return names
+}
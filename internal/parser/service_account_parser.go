@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+)
+
+// Parse ServiceAccount to PublicServiceAccount
+func ToPublicServiceAccount(sa domain.ServiceAccount) domain.PublicServiceAccount {
+	keys := make([]domain.PublicServiceAccountKey, 0, len(sa.Keys))
+	for _, k := range sa.Keys {
+		keys = append(keys, ToPublicServiceAccountKey(k))
+	}
+	return domain.PublicServiceAccount{
+		ID:             sa.ID,
+		Name:           sa.Name,
+		Description:    sa.Description,
+		OrganizationID: sa.OrganizationID,
+		Scopes:         internal.ParseDelimitedStrings(sa.Scopes),
+		Active:         sa.Active,
+		Keys:           keys,
+	}
+}
+
+// Parse ServiceAccountKey to PublicServiceAccountKey
+func ToPublicServiceAccountKey(k domain.ServiceAccountKey) domain.PublicServiceAccountKey {
+	return domain.PublicServiceAccountKey{
+		ID:         k.ID,
+		Prefix:     k.Prefix,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
@@ -27,6 +27,7 @@ func ToHubService(s domain.Service) domain.HubService {
 		LastUpdate:    s.LastUpdate,
 		Status:        s.Status,
 		Price:         s.Price,
+		HourlyPrice:   s.HourlyPrice,
 	}
 }
 
@@ -0,0 +1,30 @@
+package parser
+
+import (
+	"encoding/json"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+// Parse UserLog to PublicUserLog, the changes are returned as a JSON object (null when nothing changed)
+func ToPublicUserLog(log domain.UserLog) domain.PublicUserLog {
+	changes := json.RawMessage("null")
+	if log.Changes != "" {
+		changes = json.RawMessage(log.Changes)
+	}
+	return domain.PublicUserLog{
+		ID:               log.ID,
+		UserID:           log.UserID,
+		ServiceAccountID: log.ServiceAccountID,
+		OrganizationID:   log.OrganizationID,
+		IPAddress:        log.IPAddress,
+		UserAgent:        log.UserAgent,
+		RequestID:        log.RequestID,
+		Action:           log.Action,
+		EntityType:       log.EntityType,
+		EntityID:         log.EntityID,
+		Changes:          changes,
+		Hash:             log.Hash,
+		CreatedAt:        log.CreatedAt,
+	}
+}
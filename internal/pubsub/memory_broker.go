@@ -0,0 +1,57 @@
+package pubsub
+
+import (
+	"sync"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+type memoryBroker struct {
+	mu          sync.Mutex
+	subscribers map[uint]map[chan domain.PresenceSnapshot]struct{}
+}
+
+// NewMemoryBroker returns a PresenceBroker that fans out the snapshots to the subscribers of this process
+func NewMemoryBroker() domain.PresenceBroker {
+	return &memoryBroker{
+		subscribers: make(map[uint]map[chan domain.PresenceSnapshot]struct{}),
+	}
+}
+
+// Publish never blocks: a subscriber that did not read the previous snapshot yet has it replaced by the new one
+func (b *memoryBroker) Publish(snapshot domain.PresenceSnapshot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[snapshot.OrganizationID] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- snapshot
+	}
+}
+
+func (b *memoryBroker) Subscribe(organizationID uint) (<-chan domain.PresenceSnapshot, func()) {
+	ch := make(chan domain.PresenceSnapshot, 1)
+
+	b.mu.Lock()
+	if b.subscribers[organizationID] == nil {
+		b.subscribers[organizationID] = make(map[chan domain.PresenceSnapshot]struct{})
+	}
+	b.subscribers[organizationID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers[organizationID], ch)
+			if len(b.subscribers[organizationID]) == 0 {
+				delete(b.subscribers, organizationID)
+			}
+		})
+	}
+	return ch, unsubscribe
+}
@@ -1,8 +1,12 @@
 package tokenutil
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gabrielfmcoelho/platform-core/domain"
@@ -14,11 +18,69 @@ func parseUintToHex(id uint) string {
 	return fmt.Sprintf("%x", id)
 }
 
+// parse the hex string stored in the subject claim back to a gorm ID
+func parseHexToUint(s string) (uint, error) {
+	id, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, domain.ErrInvalidNumberToParse
+	}
+	return uint(id), nil
+}
+
+// GenerateTokenID returns a random hex identifier used for the jti claim and refresh token families
+func GenerateTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// GenerateOpaqueToken returns a random url-safe token for links sent by email (password reset, ...)
+func GenerateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashOpaqueToken returns the sha256 hex digest stored in the database in place of the raw token
+func HashOpaqueToken(rawToken string) string {
+	sum := sha256.Sum256([]byte(rawToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateAPIKey returns the public prefix and the secret of a new service account key,
+// the key handed to the client is "<prefix>.<secret>"
+func GenerateAPIKey() (prefix string, secret string, err error) {
+	b := make([]byte, 6)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	secret, err = GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	return "pck_" + hex.EncodeToString(b), secret, nil
+}
+
+// SplitAPIKey separates a raw key into its prefix and secret
+func SplitAPIKey(rawKey string) (prefix string, secret string, ok bool) {
+	prefix, secret, ok = strings.Cut(rawKey, ".")
+	if !ok || prefix == "" || secret == "" {
+		return "", "", false
+	}
+	return prefix, secret, true
+}
+
 func CreateAccessToken(user *domain.User, secret string, expiry int) (accessToken string, err error) {
 	nowTime := time.Now()
 	expireTime := nowTime.Add(time.Hour * time.Duration(expiry))
 	claims := &domain.JwtCustomClaims{
-		UserRoleID: user.Role.ID,
+		OrganizationID:     user.OrganizationID,
+		OrganizationRoleID: user.Organization.RoleID,
+		UserRoleID:         user.RoleID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   parseUintToHex(user.ID),
 			ExpiresAt: jwt.NewNumericDate(expireTime),
@@ -32,12 +94,15 @@ func CreateAccessToken(user *domain.User, secret string, expiry int) (accessToke
 	return t, err
 }
 
-func CreateRefreshToken(user *domain.User, secret string, expiry int) (refreshToken string, err error) {
+func CreateRefreshToken(user *domain.User, secret string, expiry int, tokenID string) (refreshToken string, err error) {
 	nowTime := time.Now()
 	expireTime := nowTime.Add(time.Hour * time.Duration(expiry))
 	claimsRefresh := &domain.JwtCustomRefreshClaims{
-		UserRoleID: user.Role.ID,
+		OrganizationID:     user.OrganizationID,
+		OrganizationRoleID: user.Organization.RoleID,
+		UserRoleID:         user.RoleID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			Subject:   parseUintToHex(user.ID),
 			ExpiresAt: jwt.NewNumericDate(expireTime),
 		},
@@ -75,9 +140,7 @@ func ExtractIDFromToken(requestToken string, secret string) (string, error) {
 		return "", err
 	}
 
-	log.Default().Println("Extracting ID from token", token)
 	claims, ok := token.Claims.(jwt.MapClaims)
-	log.Default().Println("Extracting ID from token", claims)
 
 	if !ok && !token.Valid {
 		return "", fmt.Errorf("invalid token")
@@ -86,14 +149,43 @@ func ExtractIDFromToken(requestToken string, secret string) (string, error) {
 	return claims["sub"].(string), nil
 }
 
-func SkipTokenValidation(requestToken string) (bool, error) {
-	token, _, err := new(jwt.Parser).ParseUnverified(requestToken, jwt.MapClaims{})
+// ExtractAccessClaims validates the access token signature and expiry and returns its claims
+func ExtractAccessClaims(requestToken string, secret string) (*domain.JwtCustomClaims, error) {
+	claims := &domain.JwtCustomClaims{}
+	token, err := jwt.ParseWithClaims(requestToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+	return claims, nil
+}
+
+// ExtractRefreshClaims validates the refresh token signature and expiry and returns its claims
+func ExtractRefreshClaims(requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
+	claims := &domain.JwtCustomRefreshClaims{}
+	token, err := jwt.ParseWithClaims(requestToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	// if token has claim "admin" and it is true, return the true
-	if token.Claims.(jwt.MapClaims)["apiAdmin"] == true {
-		return true, nil
+	if !token.Valid || claims.ID == "" {
+		return nil, fmt.Errorf("invalid token")
 	}
-	return false, nil
+	return claims, nil
+}
+
+// ExtractUserIDFromSubject converts the hex subject claim to the user ID
+func ExtractUserIDFromSubject(subject string) (uint, error) {
+	return parseHexToUint(subject)
 }
@@ -0,0 +1,113 @@
+// Package tracing sets up OpenTelemetry: the tracer provider with its exporter, the W3C trace-context
+// propagation, the naming of the usecase spans (CallerName) and the spans of the GORM queries (Plugin).
+// The HTTP server spans are started by middleware.Tracing
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the service.name of the spans
+const ServiceName = "platform-core"
+
+const instrumentationName = "github.com/gabrielfmcoelho/platform-core"
+
+// Exporters accepted by Config.Exporter
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+type Config struct {
+	Exporter     string
+	Endpoint     string  // OTLP/HTTP endpoint URL, empty uses OTEL_EXPORTER_OTLP_ENDPOINT or http://localhost:4318
+	SampleRatio  float64 // share of the new traces recorded, the decision of the caller is kept for propagated ones
+	Version      string
+	Environment  string
+	StdoutWriter io.Writer // where the stdout exporter writes, os.Stdout when nil
+}
+
+// Setup installs the global tracer provider and propagator and returns the function that flushes the pending
+// spans and stops the exporter. With ExporterNone the spans are still created, so the trace context is
+// propagated, but never exported
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch config.Exporter {
+	case ExporterNone, "":
+	case ExporterOTLP:
+		var options []otlptracehttp.Option
+		if config.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(config.Endpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, options...)
+	case ExporterStdout:
+		writer := config.StdoutWriter
+		if writer == nil {
+			writer = os.Stdout
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(writer))
+	default:
+		err = fmt.Errorf("unknown tracing exporter %q", config.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(config.Version),
+		semconv.DeploymentEnvironment(config.Environment),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	}
+	if exporter != nil {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the application, from the global provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// CallerName returns the receiver type and method ("serviceUsecase.Use") of the function skip frames above
+// the one calling CallerName, to name the span after it
+func CallerName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	name := runtime.FuncForPC(pc).Name() // github.com/.../usecase.(*serviceUsecase).Use
+	name = name[strings.LastIndex(name, "/")+1:]
+	if dot := strings.Index(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
@@ -40,6 +40,19 @@ func ParseDelimitedStrings(s string) []string {
 	return parts
 }
 
+// JoinDelimitedStrings is the inverse of ParseDelimitedStrings, it trims each value,
+// drops the empty ones and joins the rest with semicolons
+func JoinDelimitedStrings(values []string) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
 // Parse time.Duration to int (seconds)
 func ToSeconds(d time.Duration) int {
 	return int(d.Seconds())
@@ -0,0 +1,28 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/bootstrap"
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+// Setup inicia as tarefas periódicas da aplicação, elas param quando ctx é cancelado (a execução em andamento
+// termina, ela não recebe o cancelamento) e são aguardadas pelo shutdown
+func Setup(ctx context.Context, env *bootstrap.Env, timeout time.Duration, db *gorm.DB, presence domain.PresenceBroker, runtimeMetrics domain.RuntimeMetrics, shutdown *bootstrap.Shutdown) {
+	start(shutdown, func() { NewSubscriptionExpirationJob(timeout, db).Run(ctx, 24*time.Hour) })
+	start(shutdown, func() { NewMetricsJob(timeout, db).Run(ctx, time.Hour) })
+	start(shutdown, func() {
+		NewUsageSessionJob(time.Duration(env.UsageSessionIdleSeconds)*time.Second, timeout, db, presence, runtimeMetrics).Run(ctx, time.Minute)
+	})
+}
+
+func start(shutdown *bootstrap.Shutdown, run func()) {
+	release := shutdown.Track()
+	go func() {
+		defer release()
+		run()
+	}()
+}
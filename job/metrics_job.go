@@ -0,0 +1,53 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"gorm.io/gorm"
+)
+
+// MetricsJob consolida as métricas de usuários e organizações a partir dos logs e dos vínculos atuais
+type MetricsJob struct {
+	UserMetricsUsecase         domain.UserMetricsUsecase
+	OrganizationMetricsUsecase domain.OrganizationMetricsUsecase
+}
+
+func NewMetricsJob(timeout time.Duration, db *gorm.DB) *MetricsJob {
+	umr := repository.NewUserMetricsRepository(db)
+	omr := repository.NewOrganizationMetricsRepository(db)
+	ur := repository.NewUserRepository(db)
+	or := repository.NewOrganizationRepository(db)
+	return &MetricsJob{
+		UserMetricsUsecase:         usecase.NewUserMetricsUsecase(umr, ur, timeout),
+		OrganizationMetricsUsecase: usecase.NewOrganizationMetricsUsecase(omr, or, timeout),
+	}
+}
+
+// Run executa o job na inicialização e depois a cada interval, até ctx ser cancelado
+func (j *MetricsJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		j.recompute(context.WithoutCancel(ctx))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *MetricsJob) recompute(ctx context.Context) {
+	if _, err := j.UserMetricsUsecase.Recompute(ctx); err != nil {
+		log.Printf("[MetricsJob] Erro ao consolidar as métricas de usuário: %v", err)
+	}
+	if _, err := j.OrganizationMetricsUsecase.Recompute(ctx); err != nil {
+		log.Printf("[MetricsJob] Erro ao consolidar as métricas de organização: %v", err)
+	}
+}
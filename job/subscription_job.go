@@ -0,0 +1,51 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"gorm.io/gorm"
+)
+
+// SubscriptionExpirationJob desativa as assinaturas de organização cujo período terminou
+type SubscriptionExpirationJob struct {
+	OrganizationSubscriptionUsecase domain.OrganizationSubscriptionUsecase
+}
+
+func NewSubscriptionExpirationJob(timeout time.Duration, db *gorm.DB) *SubscriptionExpirationJob {
+	osr := repository.NewOrganizationSubscriptionRepository(db)
+	or := repository.NewOrganizationRepository(db)
+	return &SubscriptionExpirationJob{
+		OrganizationSubscriptionUsecase: usecase.NewOrganizationSubscriptionUsecase(osr, or, timeout),
+	}
+}
+
+// Run executa o job na inicialização e depois a cada interval, até ctx ser cancelado
+func (j *SubscriptionExpirationJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		j.deactivateExpired(context.WithoutCancel(ctx))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *SubscriptionExpirationJob) deactivateExpired(ctx context.Context) {
+	deactivated, err := j.OrganizationSubscriptionUsecase.DeactivateExpired(ctx)
+	if err != nil {
+		log.Printf("[SubscriptionExpirationJob] Erro ao desativar assinaturas expiradas: %v", err)
+		return
+	}
+	if deactivated > 0 {
+		log.Printf("[SubscriptionExpirationJob] %d assinaturas expiradas foram desativadas", deactivated)
+	}
+}
@@ -0,0 +1,56 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"github.com/gabrielfmcoelho/platform-core/usecase"
+	"gorm.io/gorm"
+)
+
+// UsageSessionJob expira as sessões de uso de serviços cujos heartbeats pararam (aba fechada, queda de conexão)
+type UsageSessionJob struct {
+	ServiceUsecase domain.ServiceUsecase
+	Idle           time.Duration
+}
+
+func NewUsageSessionJob(idle time.Duration, timeout time.Duration, db *gorm.DB, presence domain.PresenceBroker, runtimeMetrics domain.RuntimeMetrics) *UsageSessionJob {
+	sr := repository.NewServiceRepository(db)
+	uslr := repository.NewUserServiceLogRepository(db)
+	ur := repository.NewUserRepository(db)
+	osr := repository.NewOrganizationSubscriptionRepository(db)
+	au := usecase.NewAuditUsecase(repository.NewUserLogRepository(db), timeout)
+	return &UsageSessionJob{
+		ServiceUsecase: usecase.NewServiceUsecase(sr, uslr, ur, osr, presence, au, runtimeMetrics, timeout),
+		Idle:           idle,
+	}
+}
+
+// Run executa o job na inicialização e depois a cada interval, até ctx ser cancelado
+func (j *UsageSessionJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		j.closeIdle(context.WithoutCancel(ctx))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *UsageSessionJob) closeIdle(ctx context.Context) {
+	closed, err := j.ServiceUsecase.CloseIdleSessions(ctx, j.Idle)
+	if err != nil {
+		log.Printf("[UsageSessionJob] Erro ao expirar sessões de uso inativas: %v", err)
+		return
+	}
+	if closed > 0 {
+		log.Printf("[UsageSessionJob] %d sessões de uso inativas foram expiradas", closed)
+	}
+}
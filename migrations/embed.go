@@ -0,0 +1,9 @@
+// Package migrations embeds the versioned SQL migrations of each supported database dialect.
+// Files are named NNNN_name.up.sql and NNNN_name.down.sql and, once released, must never be edited:
+// schema changes always go in a new version
+package migrations
+
+import "embed"
+
+//go:embed postgres/*.sql sqlite/*.sql
+var Files embed.FS
@@ -0,0 +1,404 @@
+-- Baseline: the schema that bootstrap.AutoMigrate created before versioned migrations.
+-- Databases created by AutoMigrate adopt this version without running it (see internal/migrate).
+
+CREATE TABLE "user_roles" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "role_name" varchar(255) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_user_roles_role_name" ON "user_roles" ("role_name");
+CREATE INDEX "idx_user_roles_deleted_at" ON "user_roles" ("deleted_at");
+
+CREATE TABLE "organization_roles" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "role_name" varchar(255) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_organization_roles_role_name" ON "organization_roles" ("role_name");
+CREATE INDEX "idx_organization_roles_deleted_at" ON "organization_roles" ("deleted_at");
+
+CREATE TABLE "organizations" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(255) NOT NULL,
+    "nickname" varchar(255),
+    "logo_url" varchar(255),
+    "role_id" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_organization_roles_organizations" FOREIGN KEY ("role_id") REFERENCES "organization_roles"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_organizations_name" ON "organizations" ("name");
+CREATE INDEX "idx_organizations_deleted_at" ON "organizations" ("deleted_at");
+
+CREATE TABLE "users" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "email" varchar(255) NOT NULL,
+    "password" varchar(255) NOT NULL,
+    "organization_id" bigint,
+    "role_id" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user_roles_users" FOREIGN KEY ("role_id") REFERENCES "user_roles"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_organizations_users" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_users_email" ON "users" ("email");
+CREATE INDEX "idx_users_deleted_at" ON "users" ("deleted_at");
+CREATE INDEX "idx_users_role_id" ON "users" ("role_id");
+CREATE INDEX "idx_users_organization_id" ON "users" ("organization_id");
+
+CREATE TABLE "user_bios" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "first_name" varchar(255),
+    "sur_name" varchar(255),
+    "position" varchar(255),
+    "phone" varchar(255),
+    "sex" varchar(255),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_bio" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_user_bios_user_id" ON "user_bios" ("user_id");
+CREATE INDEX "idx_user_bios_deleted_at" ON "user_bios" ("deleted_at");
+
+CREATE TABLE "user_metrics" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "favorite_service_id" bigint,
+    "last_ip" varchar(255),
+    "last_login" varchar(255),
+    "total_logins" bigint DEFAULT 0,
+    "total_usage_duration" bigint DEFAULT 0,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_metrics" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_user_metrics_user_id" ON "user_metrics" ("user_id");
+CREATE INDEX "idx_user_metrics_deleted_at" ON "user_metrics" ("deleted_at");
+
+CREATE TABLE "organization_metrics" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "organization_id" bigint NOT NULL,
+    "total_services" bigint DEFAULT 0,
+    "total_users" bigint DEFAULT 0,
+    "total_reports" bigint DEFAULT 0,
+    "total_reports_current_month" bigint DEFAULT 0,
+    "last_report_date" varchar(255),
+    "next_report_date" varchar(255),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_organizations_metrics" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_organization_metrics_organization_id" ON "organization_metrics" ("organization_id");
+CREATE INDEX "idx_organization_metrics_deleted_at" ON "organization_metrics" ("deleted_at");
+
+CREATE TABLE "user_configs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_configs" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_user_configs_user_id" ON "user_configs" ("user_id");
+CREATE INDEX "idx_user_configs_deleted_at" ON "user_configs" ("deleted_at");
+
+CREATE TABLE "user_service_configs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "user_config_id" bigint NOT NULL,
+    "service_id" bigint NOT NULL,
+    "is_pinned" boolean DEFAULT false,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user_configs_services_configs" FOREIGN KEY ("user_config_id") REFERENCES "user_configs"("id")
+);
+CREATE INDEX "idx_user_service_configs_service_id" ON "user_service_configs" ("service_id");
+CREATE INDEX "idx_user_service_configs_user_config_id" ON "user_service_configs" ("user_config_id");
+CREATE INDEX "idx_user_service_configs_user_id" ON "user_service_configs" ("user_id");
+CREATE INDEX "idx_user_service_configs_deleted_at" ON "user_service_configs" ("deleted_at");
+
+CREATE TABLE "user_logs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint,
+    "service_account_id" bigint,
+    "organization_id" bigint,
+    "ip_address" varchar(255),
+    "user_agent" varchar(512),
+    "request_id" varchar(64),
+    "action" varchar(255) NOT NULL,
+    "entity_type" varchar(64),
+    "entity_id" varchar(255),
+    "changes" text,
+    "prev_hash" varchar(64),
+    "hash" varchar(64),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_logs" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_user_logs_entity_type" ON "user_logs" ("entity_type");
+CREATE INDEX "idx_user_logs_action" ON "user_logs" ("action");
+CREATE INDEX "idx_user_logs_request_id" ON "user_logs" ("request_id");
+CREATE INDEX "idx_user_logs_organization_id" ON "user_logs" ("organization_id");
+CREATE INDEX "idx_user_logs_service_account_id" ON "user_logs" ("service_account_id");
+CREATE INDEX "idx_user_logs_user_id" ON "user_logs" ("user_id");
+CREATE INDEX "idx_user_logs_deleted_at" ON "user_logs" ("deleted_at");
+CREATE INDEX "idx_user_logs_hash" ON "user_logs" ("hash");
+
+CREATE TABLE "user_service_logs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "service_id" bigint NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'ended',
+    "duration" bigint DEFAULT 0,
+    "last_heartbeat_at" timestamptz,
+    "ended_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_service_logs" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_user_service_logs_status" ON "user_service_logs" ("status");
+CREATE INDEX "idx_user_service_logs_service_id" ON "user_service_logs" ("service_id");
+CREATE INDEX "idx_user_service_logs_user_id" ON "user_service_logs" ("user_id");
+CREATE INDEX "idx_user_service_logs_deleted_at" ON "user_service_logs" ("deleted_at");
+
+CREATE TABLE "services" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "marketing_name" varchar(255) NOT NULL,
+    "name" varchar(255) NOT NULL,
+    "description" varchar(255) NOT NULL,
+    "app_url" varchar(255) NOT NULL,
+    "icon_url" varchar(255),
+    "screenshot_url" varchar(255),
+    "tag_line" varchar(255),
+    "benefits" varchar(255),
+    "features" varchar(255),
+    "tags" varchar(255),
+    "last_update" varchar(255),
+    "status" varchar(255),
+    "price" decimal NOT NULL,
+    "hourly_price" decimal NOT NULL DEFAULT 0,
+    "is_marketing" boolean NOT NULL DEFAULT false,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_services_name" ON "services" ("name");
+CREATE UNIQUE INDEX "idx_services_marketing_name" ON "services" ("marketing_name");
+CREATE INDEX "idx_services_deleted_at" ON "services" ("deleted_at");
+
+CREATE TABLE "organization_services" (
+    "organization_id" bigint,
+    "service_id" bigint,
+    PRIMARY KEY ("organization_id","service_id"),
+    CONSTRAINT "fk_organization_services_organization" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_organization_services_service" FOREIGN KEY ("service_id") REFERENCES "services"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE "refresh_tokens" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "family_id" varchar(64) NOT NULL,
+    "token_id" varchar(64) NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "rotated_at" timestamptz,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_refresh_tokens_token_id" ON "refresh_tokens" ("token_id");
+CREATE INDEX "idx_refresh_tokens_family_id" ON "refresh_tokens" ("family_id");
+CREATE INDEX "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
+CREATE INDEX "idx_refresh_tokens_deleted_at" ON "refresh_tokens" ("deleted_at");
+
+CREATE TABLE "password_reset_tokens" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint NOT NULL,
+    "token_hash" varchar(64) NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "used_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_password_reset_tokens_token_hash" ON "password_reset_tokens" ("token_hash");
+CREATE INDEX "idx_password_reset_tokens_user_id" ON "password_reset_tokens" ("user_id");
+CREATE INDEX "idx_password_reset_tokens_deleted_at" ON "password_reset_tokens" ("deleted_at");
+
+CREATE TABLE "service_accounts" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(255) NOT NULL,
+    "description" varchar(255),
+    "organization_id" bigint NOT NULL,
+    "scopes" varchar(1024),
+    "active" boolean NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_service_accounts_organization" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_service_accounts_organization_id" ON "service_accounts" ("organization_id");
+CREATE UNIQUE INDEX "idx_service_accounts_name" ON "service_accounts" ("name");
+CREATE INDEX "idx_service_accounts_deleted_at" ON "service_accounts" ("deleted_at");
+
+CREATE TABLE "service_account_keys" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "service_account_id" bigint NOT NULL,
+    "prefix" varchar(32) NOT NULL,
+    "secret_hash" varchar(64) NOT NULL,
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_service_accounts_keys" FOREIGN KEY ("service_account_id") REFERENCES "service_accounts"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_service_account_keys_deleted_at" ON "service_account_keys" ("deleted_at");
+CREATE UNIQUE INDEX "idx_service_account_keys_prefix" ON "service_account_keys" ("prefix");
+CREATE INDEX "idx_service_account_keys_service_account_id" ON "service_account_keys" ("service_account_id");
+
+CREATE TABLE "permissions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(255) NOT NULL,
+    "description" varchar(255),
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_permissions_name" ON "permissions" ("name");
+CREATE INDEX "idx_permissions_deleted_at" ON "permissions" ("deleted_at");
+
+CREATE TABLE "user_role_permissions" (
+    "user_role_id" bigint,
+    "permission_id" bigint,
+    PRIMARY KEY ("user_role_id","permission_id"),
+    CONSTRAINT "fk_user_role_permissions_user_role" FOREIGN KEY ("user_role_id") REFERENCES "user_roles"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_user_role_permissions_permission" FOREIGN KEY ("permission_id") REFERENCES "permissions"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE "organization_role_permissions" (
+    "organization_role_id" bigint,
+    "permission_id" bigint,
+    PRIMARY KEY ("organization_role_id","permission_id"),
+    CONSTRAINT "fk_organization_role_permissions_organization_role" FOREIGN KEY ("organization_role_id") REFERENCES "organization_roles"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_organization_role_permissions_permission" FOREIGN KEY ("permission_id") REFERENCES "permissions"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE "invitations" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "email" varchar(255) NOT NULL,
+    "organization_id" bigint NOT NULL,
+    "role_id" bigint NOT NULL,
+    "invited_by_id" bigint,
+    "token_hash" varchar(64) NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "accepted_at" timestamptz,
+    "revoked_at" timestamptz,
+    "user_id" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invitations_organization" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_invitations_role" FOREIGN KEY ("role_id") REFERENCES "user_roles"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_invitations_deleted_at" ON "invitations" ("deleted_at");
+CREATE UNIQUE INDEX "idx_invitations_token_hash" ON "invitations" ("token_hash");
+CREATE INDEX "idx_invitations_invited_by_id" ON "invitations" ("invited_by_id");
+CREATE INDEX "idx_invitations_organization_id" ON "invitations" ("organization_id");
+CREATE INDEX "idx_invitations_email" ON "invitations" ("email");
+
+CREATE TABLE "organization_subscriptions" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "active" boolean DEFAULT false,
+    "organization_id" bigint NOT NULL,
+    "subscription_value" decimal NOT NULL,
+    "subscription_period" text NOT NULL,
+    "subscription_users_limit" bigint NOT NULL,
+    "subscription_reports_limit" bigint NOT NULL,
+    "subscription_init_date" timestamptz NOT NULL,
+    "subscription_end_date" timestamptz NOT NULL,
+    "cancelled_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_organizations_subscription" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_organization_subscriptions_subscription_end_date" ON "organization_subscriptions" ("subscription_end_date");
+CREATE UNIQUE INDEX "idx_organization_subscriptions_organization_id" ON "organization_subscriptions" ("organization_id");
+CREATE INDEX "idx_organization_subscriptions_active" ON "organization_subscriptions" ("active");
+CREATE INDEX "idx_organization_subscriptions_deleted_at" ON "organization_subscriptions" ("deleted_at");
+
+CREATE TABLE "invoices" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "organization_id" bigint NOT NULL,
+    "number" varchar(32),
+    "status" varchar(16) NOT NULL DEFAULT 'draft',
+    "period_start" timestamptz NOT NULL,
+    "period_end" timestamptz NOT NULL,
+    "total" decimal NOT NULL DEFAULT 0,
+    "issued_at" timestamptz,
+    "paid_at" timestamptz,
+    "voided_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invoices_organization" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_invoices_deleted_at" ON "invoices" ("deleted_at");
+CREATE INDEX "idx_invoices_period_start" ON "invoices" ("period_start");
+CREATE INDEX "idx_invoices_status" ON "invoices" ("status");
+CREATE UNIQUE INDEX "idx_invoices_number" ON "invoices" ("number");
+CREATE INDEX "idx_invoices_organization_id" ON "invoices" ("organization_id");
+
+CREATE TABLE "invoice_items" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "invoice_id" bigint NOT NULL,
+    "service_id" bigint NOT NULL,
+    "description" varchar(255) NOT NULL,
+    "flat_price" decimal NOT NULL DEFAULT 0,
+    "usage_seconds" bigint NOT NULL DEFAULT 0,
+    "hourly_price" decimal NOT NULL DEFAULT 0,
+    "usage_amount" decimal NOT NULL DEFAULT 0,
+    "amount" decimal NOT NULL DEFAULT 0,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invoices_items" FOREIGN KEY ("invoice_id") REFERENCES "invoices"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_invoice_items_deleted_at" ON "invoice_items" ("deleted_at");
+CREATE INDEX "idx_invoice_items_invoice_id" ON "invoice_items" ("invoice_id");
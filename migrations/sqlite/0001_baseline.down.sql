@@ -0,0 +1,24 @@
+DROP TABLE IF EXISTS "invoice_items";
+DROP TABLE IF EXISTS "invoices";
+DROP TABLE IF EXISTS "organization_subscriptions";
+DROP TABLE IF EXISTS "invitations";
+DROP TABLE IF EXISTS "organization_role_permissions";
+DROP TABLE IF EXISTS "user_role_permissions";
+DROP TABLE IF EXISTS "permissions";
+DROP TABLE IF EXISTS "service_account_keys";
+DROP TABLE IF EXISTS "service_accounts";
+DROP TABLE IF EXISTS "password_reset_tokens";
+DROP TABLE IF EXISTS "refresh_tokens";
+DROP TABLE IF EXISTS "organization_services";
+DROP TABLE IF EXISTS "services";
+DROP TABLE IF EXISTS "user_service_logs";
+DROP TABLE IF EXISTS "user_logs";
+DROP TABLE IF EXISTS "user_service_configs";
+DROP TABLE IF EXISTS "user_configs";
+DROP TABLE IF EXISTS "organization_metrics";
+DROP TABLE IF EXISTS "user_metrics";
+DROP TABLE IF EXISTS "user_bios";
+DROP TABLE IF EXISTS "users";
+DROP TABLE IF EXISTS "user_roles";
+DROP TABLE IF EXISTS "organizations";
+DROP TABLE IF EXISTS "organization_roles";
@@ -0,0 +1,383 @@
+-- Baseline: the schema that bootstrap.AutoMigrate created before versioned migrations.
+-- Databases created by AutoMigrate adopt this version without running it (see internal/migrate).
+
+CREATE TABLE "organization_roles" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "role_name" text NOT NULL
+);
+CREATE UNIQUE INDEX "idx_organization_roles_role_name" ON "organization_roles" ("role_name");
+CREATE INDEX "idx_organization_roles_deleted_at" ON "organization_roles" ("deleted_at");
+
+CREATE TABLE "organizations" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "name" text NOT NULL,
+    "nickname" text,
+    "logo_url" text,
+    "role_id" integer NOT NULL,
+    CONSTRAINT "fk_organization_roles_organizations" FOREIGN KEY ("role_id") REFERENCES "organization_roles"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_organizations_name" ON "organizations" ("name");
+CREATE INDEX "idx_organizations_deleted_at" ON "organizations" ("deleted_at");
+
+CREATE TABLE "user_roles" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "role_name" text NOT NULL
+);
+CREATE UNIQUE INDEX "idx_user_roles_role_name" ON "user_roles" ("role_name");
+CREATE INDEX "idx_user_roles_deleted_at" ON "user_roles" ("deleted_at");
+
+CREATE TABLE "users" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "email" text NOT NULL,
+    "password" text NOT NULL,
+    "organization_id" integer,
+    "role_id" integer NOT NULL,
+    CONSTRAINT "fk_organizations_users" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_user_roles_users" FOREIGN KEY ("role_id") REFERENCES "user_roles"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_users_role_id" ON "users" ("role_id");
+CREATE INDEX "idx_users_organization_id" ON "users" ("organization_id");
+CREATE UNIQUE INDEX "idx_users_email" ON "users" ("email");
+CREATE INDEX "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE "user_bios" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "user_id" integer NOT NULL,
+    "first_name" text,
+    "sur_name" text,
+    "position" text,
+    "phone" text,
+    "sex" text,
+    CONSTRAINT "fk_users_bio" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_user_bios_user_id" ON "user_bios" ("user_id");
+CREATE INDEX "idx_user_bios_deleted_at" ON "user_bios" ("deleted_at");
+
+CREATE TABLE "user_metrics" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "user_id" integer NOT NULL,
+    "favorite_service_id" integer,
+    "last_ip" text,
+    "last_login" text,
+    "total_logins" integer DEFAULT 0,
+    "total_usage_duration" integer DEFAULT 0,
+    CONSTRAINT "fk_users_metrics" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_user_metrics_user_id" ON "user_metrics" ("user_id");
+CREATE INDEX "idx_user_metrics_deleted_at" ON "user_metrics" ("deleted_at");
+
+CREATE TABLE "organization_metrics" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "organization_id" integer NOT NULL,
+    "total_services" integer DEFAULT 0,
+    "total_users" integer DEFAULT 0,
+    "total_reports" integer DEFAULT 0,
+    "total_reports_current_month" integer DEFAULT 0,
+    "last_report_date" text,
+    "next_report_date" text,
+    CONSTRAINT "fk_organizations_metrics" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_organization_metrics_deleted_at" ON "organization_metrics" ("deleted_at");
+CREATE UNIQUE INDEX "idx_organization_metrics_organization_id" ON "organization_metrics" ("organization_id");
+
+CREATE TABLE "user_configs" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "user_id" integer NOT NULL,
+    CONSTRAINT "fk_users_configs" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_user_configs_user_id" ON "user_configs" ("user_id");
+CREATE INDEX "idx_user_configs_deleted_at" ON "user_configs" ("deleted_at");
+
+CREATE TABLE "user_service_configs" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "user_id" integer NOT NULL,
+    "user_config_id" integer NOT NULL,
+    "service_id" integer NOT NULL,
+    "is_pinned" numeric DEFAULT false,
+    CONSTRAINT "fk_user_configs_services_configs" FOREIGN KEY ("user_config_id") REFERENCES "user_configs"("id")
+);
+CREATE INDEX "idx_user_service_configs_service_id" ON "user_service_configs" ("service_id");
+CREATE INDEX "idx_user_service_configs_user_config_id" ON "user_service_configs" ("user_config_id");
+CREATE INDEX "idx_user_service_configs_user_id" ON "user_service_configs" ("user_id");
+CREATE INDEX "idx_user_service_configs_deleted_at" ON "user_service_configs" ("deleted_at");
+
+CREATE TABLE "user_logs" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "user_id" integer,
+    "service_account_id" integer,
+    "organization_id" integer,
+    "ip_address" text,
+    "user_agent" text,
+    "request_id" text,
+    "action" text NOT NULL,
+    "entity_type" text,
+    "entity_id" text,
+    "changes" text,
+    "prev_hash" text,
+    "hash" text,
+    CONSTRAINT "fk_users_logs" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_user_logs_request_id" ON "user_logs" ("request_id");
+CREATE INDEX "idx_user_logs_organization_id" ON "user_logs" ("organization_id");
+CREATE INDEX "idx_user_logs_service_account_id" ON "user_logs" ("service_account_id");
+CREATE INDEX "idx_user_logs_user_id" ON "user_logs" ("user_id");
+CREATE INDEX "idx_user_logs_deleted_at" ON "user_logs" ("deleted_at");
+CREATE INDEX "idx_user_logs_hash" ON "user_logs" ("hash");
+CREATE INDEX "idx_user_logs_entity_type" ON "user_logs" ("entity_type");
+CREATE INDEX "idx_user_logs_action" ON "user_logs" ("action");
+
+CREATE TABLE "user_service_logs" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "user_id" integer NOT NULL,
+    "service_id" integer NOT NULL,
+    "status" text NOT NULL DEFAULT "ended",
+    "duration" integer DEFAULT 0,
+    "last_heartbeat_at" datetime,
+    "ended_at" datetime,
+    CONSTRAINT "fk_users_service_logs" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_user_service_logs_status" ON "user_service_logs" ("status");
+CREATE INDEX "idx_user_service_logs_service_id" ON "user_service_logs" ("service_id");
+CREATE INDEX "idx_user_service_logs_user_id" ON "user_service_logs" ("user_id");
+CREATE INDEX "idx_user_service_logs_deleted_at" ON "user_service_logs" ("deleted_at");
+
+CREATE TABLE "services" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "marketing_name" text NOT NULL,
+    "name" text NOT NULL,
+    "description" text NOT NULL,
+    "app_url" text NOT NULL,
+    "icon_url" text,
+    "screenshot_url" text,
+    "tag_line" text,
+    "benefits" text,
+    "features" text,
+    "tags" text,
+    "last_update" text,
+    "status" text,
+    "price" real NOT NULL,
+    "hourly_price" real NOT NULL DEFAULT 0,
+    "is_marketing" numeric NOT NULL DEFAULT false
+);
+CREATE UNIQUE INDEX "idx_services_name" ON "services" ("name");
+CREATE UNIQUE INDEX "idx_services_marketing_name" ON "services" ("marketing_name");
+CREATE INDEX "idx_services_deleted_at" ON "services" ("deleted_at");
+
+CREATE TABLE "organization_services" (
+    "organization_id" integer,
+    "service_id" integer,
+    PRIMARY KEY ("organization_id","service_id"),
+    CONSTRAINT "fk_organization_services_organization" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_organization_services_service" FOREIGN KEY ("service_id") REFERENCES "services"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE "refresh_tokens" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "user_id" integer NOT NULL,
+    "family_id" text NOT NULL,
+    "token_id" text NOT NULL,
+    "expires_at" datetime NOT NULL,
+    "rotated_at" datetime,
+    "revoked_at" datetime
+);
+CREATE UNIQUE INDEX "idx_refresh_tokens_token_id" ON "refresh_tokens" ("token_id");
+CREATE INDEX "idx_refresh_tokens_family_id" ON "refresh_tokens" ("family_id");
+CREATE INDEX "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
+CREATE INDEX "idx_refresh_tokens_deleted_at" ON "refresh_tokens" ("deleted_at");
+
+CREATE TABLE "password_reset_tokens" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "user_id" integer NOT NULL,
+    "token_hash" text NOT NULL,
+    "expires_at" datetime NOT NULL,
+    "used_at" datetime
+);
+CREATE UNIQUE INDEX "idx_password_reset_tokens_token_hash" ON "password_reset_tokens" ("token_hash");
+CREATE INDEX "idx_password_reset_tokens_user_id" ON "password_reset_tokens" ("user_id");
+CREATE INDEX "idx_password_reset_tokens_deleted_at" ON "password_reset_tokens" ("deleted_at");
+
+CREATE TABLE "service_accounts" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "name" text NOT NULL,
+    "description" text,
+    "organization_id" integer NOT NULL,
+    "scopes" text,
+    "active" numeric NOT NULL,
+    CONSTRAINT "fk_service_accounts_organization" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_service_accounts_name" ON "service_accounts" ("name");
+CREATE INDEX "idx_service_accounts_deleted_at" ON "service_accounts" ("deleted_at");
+CREATE INDEX "idx_service_accounts_organization_id" ON "service_accounts" ("organization_id");
+
+CREATE TABLE "service_account_keys" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "service_account_id" integer NOT NULL,
+    "prefix" text NOT NULL,
+    "secret_hash" text NOT NULL,
+    "expires_at" datetime,
+    "last_used_at" datetime,
+    "revoked_at" datetime,
+    CONSTRAINT "fk_service_accounts_keys" FOREIGN KEY ("service_account_id") REFERENCES "service_accounts"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_service_account_keys_prefix" ON "service_account_keys" ("prefix");
+CREATE INDEX "idx_service_account_keys_service_account_id" ON "service_account_keys" ("service_account_id");
+CREATE INDEX "idx_service_account_keys_deleted_at" ON "service_account_keys" ("deleted_at");
+
+CREATE TABLE "permissions" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "name" text NOT NULL,
+    "description" text
+);
+CREATE UNIQUE INDEX "idx_permissions_name" ON "permissions" ("name");
+CREATE INDEX "idx_permissions_deleted_at" ON "permissions" ("deleted_at");
+
+CREATE TABLE "user_role_permissions" (
+    "user_role_id" integer,
+    "permission_id" integer,
+    PRIMARY KEY ("user_role_id","permission_id"),
+    CONSTRAINT "fk_user_role_permissions_permission" FOREIGN KEY ("permission_id") REFERENCES "permissions"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_user_role_permissions_user_role" FOREIGN KEY ("user_role_id") REFERENCES "user_roles"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE "organization_role_permissions" (
+    "organization_role_id" integer,
+    "permission_id" integer,
+    PRIMARY KEY ("organization_role_id","permission_id"),
+    CONSTRAINT "fk_organization_role_permissions_organization_role" FOREIGN KEY ("organization_role_id") REFERENCES "organization_roles"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_organization_role_permissions_permission" FOREIGN KEY ("permission_id") REFERENCES "permissions"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE "invitations" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "email" text NOT NULL,
+    "organization_id" integer NOT NULL,
+    "role_id" integer NOT NULL,
+    "invited_by_id" integer,
+    "token_hash" text NOT NULL,
+    "expires_at" datetime NOT NULL,
+    "accepted_at" datetime,
+    "revoked_at" datetime,
+    "user_id" integer,
+    CONSTRAINT "fk_invitations_organization" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_invitations_role" FOREIGN KEY ("role_id") REFERENCES "user_roles"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX "idx_invitations_token_hash" ON "invitations" ("token_hash");
+CREATE INDEX "idx_invitations_invited_by_id" ON "invitations" ("invited_by_id");
+CREATE INDEX "idx_invitations_organization_id" ON "invitations" ("organization_id");
+CREATE INDEX "idx_invitations_email" ON "invitations" ("email");
+CREATE INDEX "idx_invitations_deleted_at" ON "invitations" ("deleted_at");
+
+CREATE TABLE "organization_subscriptions" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "active" numeric DEFAULT false,
+    "organization_id" integer NOT NULL,
+    "subscription_value" real NOT NULL,
+    "subscription_period" text NOT NULL,
+    "subscription_users_limit" integer NOT NULL,
+    "subscription_reports_limit" integer NOT NULL,
+    "subscription_init_date" datetime NOT NULL,
+    "subscription_end_date" datetime NOT NULL,
+    "cancelled_at" datetime,
+    CONSTRAINT "fk_organizations_subscription" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_organization_subscriptions_subscription_end_date" ON "organization_subscriptions" ("subscription_end_date");
+CREATE UNIQUE INDEX "idx_organization_subscriptions_organization_id" ON "organization_subscriptions" ("organization_id");
+CREATE INDEX "idx_organization_subscriptions_active" ON "organization_subscriptions" ("active");
+CREATE INDEX "idx_organization_subscriptions_deleted_at" ON "organization_subscriptions" ("deleted_at");
+
+CREATE TABLE "invoices" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "organization_id" integer NOT NULL,
+    "number" text,
+    "status" text NOT NULL DEFAULT "draft",
+    "period_start" datetime NOT NULL,
+    "period_end" datetime NOT NULL,
+    "total" real NOT NULL DEFAULT 0,
+    "issued_at" datetime,
+    "paid_at" datetime,
+    "voided_at" datetime,
+    CONSTRAINT "fk_invoices_organization" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_invoices_period_start" ON "invoices" ("period_start");
+CREATE INDEX "idx_invoices_status" ON "invoices" ("status");
+CREATE UNIQUE INDEX "idx_invoices_number" ON "invoices" ("number");
+CREATE INDEX "idx_invoices_organization_id" ON "invoices" ("organization_id");
+CREATE INDEX "idx_invoices_deleted_at" ON "invoices" ("deleted_at");
+
+CREATE TABLE "invoice_items" (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "created_at" datetime,
+    "updated_at" datetime,
+    "deleted_at" datetime,
+    "invoice_id" integer NOT NULL,
+    "service_id" integer NOT NULL,
+    "description" text NOT NULL,
+    "flat_price" real NOT NULL DEFAULT 0,
+    "usage_seconds" integer NOT NULL DEFAULT 0,
+    "hourly_price" real NOT NULL DEFAULT 0,
+    "usage_amount" real NOT NULL DEFAULT 0,
+    "amount" real NOT NULL DEFAULT 0,
+    CONSTRAINT "fk_invoices_items" FOREIGN KEY ("invoice_id") REFERENCES "invoices"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "idx_invoice_items_invoice_id" ON "invoice_items" ("invoice_id");
+CREATE INDEX "idx_invoice_items_deleted_at" ON "invoice_items" ("deleted_at");
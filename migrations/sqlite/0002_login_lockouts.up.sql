@@ -0,0 +1,8 @@
+CREATE TABLE "login_lockouts" (
+    "user_id" integer NOT NULL,
+    "failed_attempts" integer NOT NULL DEFAULT 0,
+    "locked_until" datetime,
+    "updated_at" datetime,
+    PRIMARY KEY ("user_id"),
+    CONSTRAINT "fk_users_login_lockout" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+// exportScope applies the period, organization and user filters of an export, column is the timestamp
+// of the exported table and the query must join users
+func exportScope(filter domain.ExportFilter, column string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !filter.From.IsZero() {
+			db = db.Where(column+" >= ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			db = db.Where(column+" < ?", filter.To)
+		}
+		if filter.OrganizationID != 0 {
+			db = db.Where("users.organization_id = ?", filter.OrganizationID)
+		}
+		if filter.UserID != 0 {
+			db = db.Where("users.id = ?", filter.UserID)
+		}
+		return db
+	}
+}
+
+// streamRows runs the query and calls fn for each row, only the current row is kept in memory
+func streamRows[T any](query *gorm.DB, fn func(row T) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row T
+		if err := query.ScanRows(rows, &row); err != nil {
+			return domain.ErrDataBaseInternalError
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
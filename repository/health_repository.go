@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+type healthRepository struct {
+	db *gorm.DB
+}
+
+// NewHealthRepository retorna uma instância que implementa a interface HealthRepository
+func NewHealthRepository(db *gorm.DB) domain.HealthRepository {
+	return &healthRepository{
+		db: db,
+	}
+}
+
+// Ping verifica se o banco responde
+func (r *healthRepository) Ping(ctx context.Context) error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// SchemaVersion retorna a última migration aplicada, 0 quando nenhuma foi aplicada
+func (r *healthRepository) SchemaVersion(ctx context.Context) (int64, error) {
+	var version int64
+	err := r.db.WithContext(ctx).Raw("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version).Error
+	return version, err
+}
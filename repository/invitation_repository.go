@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationRepository retorna uma instância que implementa a interface InvitationRepository
+func NewInvitationRepository(db *gorm.DB) domain.InvitationRepository {
+	return &invitationRepository{
+		db: db,
+	}
+}
+
+// pendingInvitation filtra convites que ainda podem ser aceitos
+func pendingInvitation(db *gorm.DB) *gorm.DB {
+	return db.Where("invitations.accepted_at IS NULL AND invitations.revoked_at IS NULL AND invitations.expires_at > ?", time.Now())
+}
+
+// Create registra um novo convite dentro da organização de quem fez a requisição
+func (r *invitationRepository) Create(ctx context.Context, invitation *domain.Invitation) error {
+	if organizationID, scoped := scopedOrganization(ctx); scoped && invitation.OrganizationID != organizationID {
+		return domain.ErrOutOfOrganization
+	}
+	if err := r.db.WithContext(ctx).Create(invitation).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
+
+// GetByID retorna um convite pelo ID
+func (r *invitationRepository) GetByID(ctx context.Context, id uint) (domain.Invitation, error) {
+	var invitation domain.Invitation
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "invitations.organization_id")).
+		Preload("Organization").Preload("Role").
+		First(&invitation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invitation, domain.ErrNotFound
+		}
+		return invitation, domain.ErrDataBaseInternalError
+	}
+	return invitation, nil
+}
+
+// GetByTokenHash retorna um convite pelo hash do token
+func (r *invitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (domain.Invitation, error) {
+	var invitation domain.Invitation
+	if err := r.db.WithContext(ctx).
+		Preload("Organization").Preload("Role").
+		Where("token_hash = ?", tokenHash).
+		First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invitation, domain.ErrNotFound
+		}
+		return invitation, domain.ErrDataBaseInternalError
+	}
+	return invitation, nil
+}
+
+// GetPendingByEmail retorna o convite pendente de um email em uma organização
+func (r *invitationRepository) GetPendingByEmail(ctx context.Context, organizationID uint, email string) (domain.Invitation, error) {
+	var invitation domain.Invitation
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "invitations.organization_id"), pendingInvitation).
+		Where("organization_id = ? AND email = ?", organizationID, email).
+		First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invitation, domain.ErrNotFound
+		}
+		return invitation, domain.ErrDataBaseInternalError
+	}
+	return invitation, nil
+}
+
+// FetchPendingByOrganization retorna os convites pendentes de uma organização
+func (r *invitationRepository) FetchPendingByOrganization(ctx context.Context, organizationID uint) ([]domain.Invitation, error) {
+	var invitations []domain.Invitation
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "invitations.organization_id"), pendingInvitation).
+		Preload("Organization").Preload("Role").
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC").
+		Find(&invitations).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return invitations, nil
+}
+
+// RenewToken troca o token de um convite pendente (o link anterior deixa de funcionar) e estende a sua validade
+func (r *invitationRepository) RenewToken(ctx context.Context, invitationID uint, invitedByID uint, tokenHash string, expiresAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Invitation{}).
+		Scopes(organizationScope(ctx, "invitations.organization_id")).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitationID).
+		Updates(map[string]interface{}{
+			"token_hash":    tokenHash,
+			"expires_at":    expiresAt,
+			"invited_by_id": invitedByID,
+		})
+	if result.Error != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Revoke cancela um convite que ainda não foi aceito
+func (r *invitationRepository) Revoke(ctx context.Context, invitationID uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Invitation{}).
+		Scopes(organizationScope(ctx, "invitations.organization_id")).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitationID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Accept consome o convite e cria o usuário com UserBio, UserMetrics e UserConfig em uma única transação.
+// A atualização do convite é condicional para que ele só possa ser aceito uma vez
+func (r *invitationRepository) Accept(ctx context.Context, invitationID uint, user *domain.User) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Invitation{}).
+			Scopes(pendingInvitation).
+			Where("id = ?", invitationID).
+			Update("accepted_at", time.Now())
+		if result.Error != nil {
+			return domain.ErrDataBaseInternalError
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrInvalidInvitation
+		}
+
+		if err := tx.Omit(clause.Associations).Create(user).Error; err != nil {
+			return domain.ErrDataBaseInternalError
+		}
+
+		user.Bio.UserID = user.ID
+		if err := tx.Create(&user.Bio).Error; err != nil {
+			return domain.ErrDataBaseInternalError
+		}
+
+		user.Metrics = domain.UserMetrics{UserID: user.ID}
+		if err := tx.Create(&user.Metrics).Error; err != nil {
+			return domain.ErrDataBaseInternalError
+		}
+
+		user.Configs = domain.UserConfig{UserID: user.ID}
+		if err := tx.Create(&user.Configs).Error; err != nil {
+			return domain.ErrDataBaseInternalError
+		}
+
+		if err := tx.Model(&domain.Invitation{}).Where("id = ?", invitationID).Update("user_id", user.ID).Error; err != nil {
+			return domain.ErrDataBaseInternalError
+		}
+		return nil
+	})
+}
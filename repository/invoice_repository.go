@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+type invoiceRepository struct {
+	db *gorm.DB
+}
+
+// NewInvoiceRepository retorna uma instância que implementa a interface InvoiceRepository
+func NewInvoiceRepository(db *gorm.DB) domain.InvoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+// Create registra a fatura e os seus itens, apenas um platform admin pode gerar faturas
+func (r *invoiceRepository) Create(ctx context.Context, invoice *domain.Invoice) error {
+	if _, scoped := scopedOrganization(ctx); scoped {
+		return domain.ErrOutOfOrganization
+	}
+	if err := r.db.WithContext(ctx).Omit("Organization").Create(invoice).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
+
+// Fetch retorna as faturas visíveis para quem fez a requisição, das mais recentes para as mais antigas
+func (r *invoiceRepository) Fetch(ctx context.Context, filter domain.InvoiceFilter) ([]domain.Invoice, error) {
+	var invoices []domain.Invoice
+	query := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "invoices.organization_id")).
+		Preload("Organization").Preload("Items")
+	if filter.OrganizationID != 0 {
+		query = query.Where("organization_id = ?", filter.OrganizationID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if err := query.Order("period_start DESC, organization_id").Find(&invoices).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return invoices, nil
+}
+
+// GetByID retorna uma fatura com os seus itens
+func (r *invoiceRepository) GetByID(ctx context.Context, id uint) (domain.Invoice, error) {
+	var invoice domain.Invoice
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "invoices.organization_id")).
+		Preload("Organization").Preload("Items").
+		First(&invoice, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invoice, domain.ErrNotFound
+		}
+		return invoice, domain.ErrDataBaseInternalError
+	}
+	return invoice, nil
+}
+
+// GetByPeriod retorna a fatura não cancelada da organização para o período
+func (r *invoiceRepository) GetByPeriod(ctx context.Context, organizationID uint, periodStart time.Time) (domain.Invoice, error) {
+	var invoice domain.Invoice
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "invoices.organization_id")).
+		Where("organization_id = ? AND period_start = ? AND status <> ?", organizationID, periodStart, domain.InvoiceStatusVoid).
+		First(&invoice).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invoice, domain.ErrNotFound
+		}
+		return invoice, domain.ErrDataBaseInternalError
+	}
+	return invoice, nil
+}
+
+// UpdateStatus muda o status da fatura (com número e datas) somente se o status atual for um dos permitidos,
+// assim duas requisições concorrentes não conseguem, por exemplo, pagar uma fatura cancelada
+func (r *invoiceRepository) UpdateStatus(ctx context.Context, invoice *domain.Invoice, from ...string) error {
+	if _, scoped := scopedOrganization(ctx); scoped {
+		return domain.ErrOutOfOrganization
+	}
+	result := r.db.WithContext(ctx).
+		Model(&domain.Invoice{}).
+		Where("id = ? AND status IN ?", invoice.ID, from).
+		Updates(map[string]interface{}{
+			"status":    invoice.Status,
+			"number":    invoice.Number,
+			"issued_at": invoice.IssuedAt,
+			"paid_at":   invoice.PaidAt,
+			"voided_at": invoice.VoidedAt,
+		})
+	if result.Error != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidInvoiceStatus
+	}
+	return nil
+}
+
+// DeleteDraft remove definitivamente um rascunho e os seus itens para que o período seja recalculado
+func (r *invoiceRepository) DeleteDraft(ctx context.Context, id uint) error {
+	if _, scoped := scopedOrganization(ctx); scoped {
+		return domain.ErrOutOfOrganization
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("invoice_id IN (SELECT id FROM invoices WHERE id = ? AND status = ?)", id, domain.InvoiceStatusDraft).
+			Delete(&domain.InvoiceItem{}).Error; err != nil {
+			return domain.ErrDataBaseInternalError
+		}
+		result := tx.Unscoped().Where("id = ? AND status = ?", id, domain.InvoiceStatusDraft).Delete(&domain.Invoice{})
+		if result.Error != nil {
+			return domain.ErrDataBaseInternalError
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrInvalidInvoiceStatus
+		}
+		return nil
+	})
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type loginLockoutRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginLockoutRepository retorna uma instância que implementa a interface LoginLockoutRepository
+func NewLoginLockoutRepository(db *gorm.DB) domain.LoginLockoutRepository {
+	return &loginLockoutRepository{
+		db: db,
+	}
+}
+
+// GetByUserID retorna as falhas de login de um usuário, vazio quando ele não errou a senha
+func (r *loginLockoutRepository) GetByUserID(ctx context.Context, userID uint) (domain.LoginLockout, error) {
+	var lockout domain.LoginLockout
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&lockout).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.LoginLockout{UserID: userID}, nil
+		}
+		return lockout, domain.ErrDataBaseInternalError
+	}
+	return lockout, nil
+}
+
+// RecordFailure incrementa as falhas consecutivas no próprio banco, logins simultâneos não perdem contagens
+func (r *loginLockoutRepository) RecordFailure(ctx context.Context, userID uint) (int, error) {
+	var lockout domain.LoginLockout
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"failed_attempts": gorm.Expr("login_lockouts.failed_attempts + 1"),
+				"updated_at":      now,
+			}),
+		}).Create(&domain.LoginLockout{UserID: userID, FailedAttempts: 1, UpdatedAt: now}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).First(&lockout).Error
+	})
+	if err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+	return lockout.FailedAttempts, nil
+}
+
+// Lock bloqueia o login do usuário até until
+func (r *loginLockoutRepository) Lock(ctx context.Context, userID uint, until time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Model(&domain.LoginLockout{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{"locked_until": until, "updated_at": time.Now()}).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
+
+// Reset apaga as falhas e o bloqueio do usuário
+func (r *loginLockoutRepository) Reset(ctx context.Context, userID uint) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.LoginLockout{}).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
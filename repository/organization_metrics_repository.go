@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type organizationMetricsRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationMetricsRepository(db *gorm.DB) domain.OrganizationMetricsRepository {
+	return &organizationMetricsRepository{
+		db: db,
+	}
+}
+
+// GetByOrganizationID returns the rolled up metrics of an organization
+func (r *organizationMetricsRepository) GetByOrganizationID(ctx context.Context, organizationID uint) (domain.OrganizationMetrics, error) {
+	var metrics domain.OrganizationMetrics
+	if err := r.db.WithContext(ctx).Scopes(organizationScope(ctx, "organization_metrics.organization_id")).Where("organization_id = ?", organizationID).First(&metrics).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return metrics, domain.ErrNotFound
+		}
+		return metrics, domain.ErrDataBaseInternalError
+	}
+	return metrics, nil
+}
+
+// Recompute counts the active members and the subscribed services of every organization
+// and upserts one OrganizationMetrics row per organization
+func (r *organizationMetricsRepository) Recompute(ctx context.Context) (int, error) {
+	db := r.db.WithContext(ctx)
+
+	var organizationIDs []uint
+	if err := db.Model(&domain.Organization{}).Pluck("id", &organizationIDs).Error; err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+	if len(organizationIDs) == 0 {
+		return 0, nil
+	}
+
+	type count struct {
+		OrganizationID uint
+		Total          int
+	}
+	var users []count
+	if err := db.Model(&domain.User{}).
+		Select("organization_id, COUNT(*) AS total").
+		Group("organization_id").
+		Scan(&users).Error; err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+	var services []count
+	if err := db.Table("organization_services").
+		Select("organization_id, COUNT(*) AS total").
+		Group("organization_id").
+		Scan(&services).Error; err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+
+	metrics := make(map[uint]*domain.OrganizationMetrics, len(organizationIDs))
+	for _, organizationID := range organizationIDs {
+		metrics[organizationID] = &domain.OrganizationMetrics{OrganizationID: organizationID}
+	}
+	for _, row := range users {
+		if m, ok := metrics[row.OrganizationID]; ok {
+			m.TotalUsers = row.Total
+		}
+	}
+	for _, row := range services {
+		if m, ok := metrics[row.OrganizationID]; ok {
+			m.TotalServices = row.Total
+		}
+	}
+
+	rows := make([]domain.OrganizationMetrics, 0, len(metrics))
+	for _, organizationID := range organizationIDs {
+		rows = append(rows, *metrics[organizationID])
+	}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"total_services", "total_users", "updated_at", "deleted_at"}),
+	}).CreateInBatches(&rows, 100).Error; err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+	return len(rows), nil
+}
@@ -17,22 +17,27 @@ func NewOrganizationRepository(db *gorm.DB) domain.OrganizationRepository {
 	return &organizationRepository{db: db}
 }
 
-// Create cria uma nova Organização no banco de dados
+// Create cria uma nova Organização no banco de dados, apenas um platform admin pode criar organizações
 func (r *organizationRepository) Create(ctx context.Context, organization *domain.Organization) error {
+	if _, scoped := scopedOrganization(ctx); scoped {
+		return domain.ErrOutOfOrganization
+	}
 	if err := r.db.WithContext(ctx).Create(organization).Error; err != nil {
-		return err
+		return domain.ErrDataBaseInternalError
 	}
 	return nil
 }
 
-// Fetch retorna todas as Organizações do banco de dados
+// Fetch retorna todas as Organizações visíveis para quem fez a requisição
 func (r *organizationRepository) Fetch(ctx context.Context) ([]domain.Organization, error) {
 	var orgs []domain.Organization
-	if err := r.db.WithContext(ctx).Preload("Role").
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "organizations.id")).
+		Preload("Role").
 		Preload("Users").
 		Preload("SubscribedServices").
 		Find(&orgs).Error; err != nil {
-		return nil, err
+		return nil, domain.ErrDataBaseInternalError
 	}
 	return orgs, nil
 }
@@ -41,6 +46,7 @@ func (r *organizationRepository) Fetch(ctx context.Context) ([]domain.Organizati
 func (r *organizationRepository) GetByID(ctx context.Context, id uint) (domain.Organization, error) {
 	var org domain.Organization
 	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "organizations.id")).
 		Preload("Role").
 		Preload("Users").
 		Preload("SubscribedServices").
@@ -48,7 +54,7 @@ func (r *organizationRepository) GetByID(ctx context.Context, id uint) (domain.O
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return org, domain.ErrNotFound
 		}
-		return org, err
+		return org, domain.ErrDataBaseInternalError
 	}
 	return org, nil
 }
@@ -57,6 +63,7 @@ func (r *organizationRepository) GetByID(ctx context.Context, id uint) (domain.O
 func (r *organizationRepository) GetByName(ctx context.Context, name string) (domain.Organization, error) {
 	var org domain.Organization
 	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "organizations.id")).
 		Preload("Role").
 		Preload("Users").
 		Preload("SubscribedServices").
@@ -65,7 +72,7 @@ func (r *organizationRepository) GetByName(ctx context.Context, name string) (do
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return org, domain.ErrNotFound
 		}
-		return org, err
+		return org, domain.ErrDataBaseInternalError
 	}
 	return org, nil
 }
@@ -74,12 +81,15 @@ func (r *organizationRepository) GetByName(ctx context.Context, name string) (do
 func (r *organizationRepository) GetUsers(ctx context.Context, organizationID uint) ([]domain.User, error) {
 	var org domain.Organization
 	if err := r.db.WithContext(ctx).
-		Preload("Users").
+		Scopes(organizationScope(ctx, "organizations.id")).
+		Preload("Users.Organization").
+		Preload("Users.Role").
+		Preload("Users.Bio").
 		First(&org, organizationID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrNotFound
 		}
-		return nil, err
+		return nil, domain.ErrDataBaseInternalError
 	}
 	return org.Users, nil
 }
@@ -88,50 +98,55 @@ func (r *organizationRepository) GetUsers(ctx context.Context, organizationID ui
 func (r *organizationRepository) GetSubscribedServices(ctx context.Context, organizationID uint) ([]domain.PublicService, error) {
 	var org domain.Organization
 	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "organizations.id")).
 		Preload("SubscribedServices").
 		First(&org, organizationID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrNotFound
 		}
-		return nil, err
+		return nil, domain.ErrDataBaseInternalError
 	}
 
-	// Converter []Service para []PublicService (supondo que sua domain tenha mapeamento)
-	var publicServices []domain.PublicService
+	publicServices := make([]domain.PublicService, 0, len(org.SubscribedServices))
 	for _, srv := range org.SubscribedServices {
 		publicServices = append(publicServices, domain.PublicService{
-			ID:   srv.ID,
-			Name: srv.Name,
+			ID:         srv.ID,
+			Name:       srv.Name,
+			AppUrl:     srv.AppUrl,
+			LastUpdate: srv.LastUpdate,
+			Status:     srv.Status,
 		})
 	}
 	return publicServices, nil
 }
 
-// Update atualiza dados de uma Organização
+// Update atualiza nome, apelido e logo de uma Organização (campos vazios também são gravados)
 func (r *organizationRepository) Update(ctx context.Context, organizationID uint, data *domain.Organization) error {
-	// Checa se existe a org
-	if err := r.db.WithContext(ctx).First(&domain.Organization{}, organizationID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return domain.ErrNotFound
-		}
-		return err
-	}
-
-	// Atualiza
-	if err := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).
 		Model(&domain.Organization{}).
+		Scopes(organizationScope(ctx, "organizations.id")).
 		Where("id = ?", organizationID).
-		Updates(data).
-		Error; err != nil {
-		return err
+		Select("Name", "Nickname", "LogoUrl").
+		Updates(data)
+	if result.Error != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
 	}
 	return nil
 }
 
-// Delete remove (fisicamente) uma Organização
+// Delete arquiva (soft delete) uma Organização
 func (r *organizationRepository) Delete(ctx context.Context, organizationID uint) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.Organization{}, organizationID).Error; err != nil {
-		return err
+	result := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "organizations.id")).
+		Delete(&domain.Organization{}, organizationID)
+	if result.Error != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
 	}
 	return nil
 }
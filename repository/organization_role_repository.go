@@ -30,7 +30,7 @@ func (r *organizationRoleRepository) Create(ctx context.Context, orgRole *domain
 // Fetch retorna todos os OrganizationRoles
 func (r *organizationRoleRepository) Fetch(ctx context.Context) ([]domain.OrganizationRole, error) {
 	var roles []domain.OrganizationRole
-	if err := r.db.WithContext(ctx).Find(&roles).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Permissions").Find(&roles).Error; err != nil {
 		return nil, err
 	}
 	return roles, nil
@@ -39,7 +39,7 @@ func (r *organizationRoleRepository) Fetch(ctx context.Context) ([]domain.Organi
 // GetByID retorna um OrganizationRole específico pelo ID
 func (r *organizationRoleRepository) GetByID(ctx context.Context, id uint) (domain.OrganizationRole, error) {
 	var role domain.OrganizationRole
-	if err := r.db.WithContext(ctx).First(&role, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Permissions").First(&role, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return role, domain.ErrNotFound
 		}
@@ -72,6 +72,16 @@ func (r *organizationRoleRepository) Update(ctx context.Context, orgRoleID uint,
 	return nil
 }
 
+// ReplacePermissions substitui todas as permissões associadas ao OrganizationRole
+func (r *organizationRoleRepository) ReplacePermissions(ctx context.Context, orgRoleID uint, permissions []domain.Permission) error {
+	role := domain.OrganizationRole{}
+	role.ID = orgRoleID
+	if err := r.db.WithContext(ctx).Model(&role).Association("Permissions").Replace(permissions); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Delete remove (fisicamente) um OrganizationRole
 func (r *organizationRoleRepository) Delete(ctx context.Context, orgRoleID uint) error {
 	if err := r.db.WithContext(ctx).Delete(&domain.OrganizationRole{}, orgRoleID).Error; err != nil {
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+type organizationSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationSubscriptionRepository retorna uma instância que implementa a interface OrganizationSubscriptionRepository
+func NewOrganizationSubscriptionRepository(db *gorm.DB) domain.OrganizationSubscriptionRepository {
+	return &organizationSubscriptionRepository{db: db}
+}
+
+// Create registra a assinatura de uma organização, apenas um platform admin pode alterar assinaturas
+func (r *organizationSubscriptionRepository) Create(ctx context.Context, subscription *domain.OrganizationSubscription) error {
+	if _, scoped := scopedOrganization(ctx); scoped {
+		return domain.ErrOutOfOrganization
+	}
+	if err := r.db.WithContext(ctx).Create(subscription).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
+
+// Fetch retorna as assinaturas visíveis para quem fez a requisição
+func (r *organizationSubscriptionRepository) Fetch(ctx context.Context) ([]domain.OrganizationSubscription, error) {
+	var subscriptions []domain.OrganizationSubscription
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "organization_subscriptions.organization_id")).
+		Order("organization_id").
+		Find(&subscriptions).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return subscriptions, nil
+}
+
+// GetByOrganizationID retorna a assinatura de uma organização
+func (r *organizationSubscriptionRepository) GetByOrganizationID(ctx context.Context, organizationID uint) (domain.OrganizationSubscription, error) {
+	var subscription domain.OrganizationSubscription
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "organization_subscriptions.organization_id")).
+		Where("organization_id = ?", organizationID).
+		First(&subscription).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return subscription, domain.ErrNotFound
+		}
+		return subscription, domain.ErrDataBaseInternalError
+	}
+	return subscription, nil
+}
+
+// Update salva os termos, o período e o estado da assinatura, apenas um platform admin pode alterar assinaturas
+func (r *organizationSubscriptionRepository) Update(ctx context.Context, subscription *domain.OrganizationSubscription) error {
+	if _, scoped := scopedOrganization(ctx); scoped {
+		return domain.ErrOutOfOrganization
+	}
+	result := r.db.WithContext(ctx).
+		Model(subscription).
+		Select("Active", "SubscriptionValue", "SubscriptionPeriod", "SubscriptionUsersLimit", "SubscriptionReportsLimit", "SubscriptionInitDate", "SubscriptionEndDate", "CancelledAt").
+		Updates(subscription)
+	if result.Error != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// DeactivateExpired desativa as assinaturas cujo período terminou
+func (r *organizationSubscriptionRepository) DeactivateExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.OrganizationSubscription{}).
+		Where("active = ? AND subscription_end_date <= ?", true, now).
+		Update("active", false)
+	if result.Error != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+	return result.RowsAffected, nil
+}
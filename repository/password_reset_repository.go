@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+type passwordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetTokenRepository retorna uma instância que implementa a interface PasswordResetTokenRepository
+func NewPasswordResetTokenRepository(db *gorm.DB) domain.PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{
+		db: db,
+	}
+}
+
+// Create registra um novo token de redefinição de senha
+func (r *passwordResetTokenRepository) Create(ctx context.Context, passwordResetToken *domain.PasswordResetToken) error {
+	if err := r.db.WithContext(ctx).Create(passwordResetToken).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
+
+// GetByTokenHash retorna um token de redefinição de senha pelo hash
+func (r *passwordResetTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (domain.PasswordResetToken, error) {
+	var passwordResetToken domain.PasswordResetToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&passwordResetToken).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return passwordResetToken, domain.ErrNotFound
+		}
+		return passwordResetToken, domain.ErrDataBaseInternalError
+	}
+	return passwordResetToken, nil
+}
+
+// MarkUsed consome o token. A atualização é condicional para que o token só possa ser usado uma vez
+func (r *passwordResetTokenRepository) MarkUsed(ctx context.Context, passwordResetTokenID uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&domain.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", passwordResetTokenID).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidResetToken
+	}
+	return nil
+}
+
+// InvalidateByUserID consome todos os tokens pendentes de um usuário
+func (r *passwordResetTokenRepository) InvalidateByUserID(ctx context.Context, userID uint) error {
+	if err := r.db.WithContext(ctx).
+		Model(&domain.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+type permissionRepository struct {
+	db *gorm.DB
+}
+
+// NewPermissionRepository retorna uma instância que implementa a interface PermissionRepository
+func NewPermissionRepository(db *gorm.DB) domain.PermissionRepository {
+	return &permissionRepository{
+		db: db,
+	}
+}
+
+// Fetch retorna todas as permissões cadastradas
+func (r *permissionRepository) Fetch(ctx context.Context) ([]domain.Permission, error) {
+	var permissions []domain.Permission
+	if err := r.db.WithContext(ctx).Order("name").Find(&permissions).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return permissions, nil
+}
+
+// GetByNames retorna as permissões com os nomes informados (nomes desconhecidos são ignorados)
+func (r *permissionRepository) GetByNames(ctx context.Context, names []string) ([]domain.Permission, error) {
+	var permissions []domain.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+	if err := r.db.WithContext(ctx).Where("name IN ?", names).Find(&permissions).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return permissions, nil
+}
+
+// GetNamesByUserID retorna as permissões efetivas do usuário: a interseção entre as permissões
+// do seu UserRole e as do OrganizationRole da sua organização
+func (r *permissionRepository) GetNamesByUserID(ctx context.Context, userID uint) ([]string, error) {
+	var names []string
+	if err := r.db.WithContext(ctx).
+		Table("permissions").
+		Select("DISTINCT permissions.name").
+		Joins("JOIN user_role_permissions ON user_role_permissions.permission_id = permissions.id").
+		Joins("JOIN users ON users.role_id = user_role_permissions.user_role_id").
+		Joins("JOIN organizations ON organizations.id = users.organization_id").
+		Joins("JOIN organization_role_permissions ON organization_role_permissions.organization_role_id = organizations.role_id AND organization_role_permissions.permission_id = permissions.id").
+		Where("users.id = ? AND users.deleted_at IS NULL AND permissions.deleted_at IS NULL", userID).
+		Pluck("permissions.name", &names).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return names, nil
+}
+
+// GetNamesByOrganizationID retorna as permissões do OrganizationRole da organização
+func (r *permissionRepository) GetNamesByOrganizationID(ctx context.Context, organizationID uint) ([]string, error) {
+	var names []string
+	if err := r.db.WithContext(ctx).
+		Table("permissions").
+		Select("DISTINCT permissions.name").
+		Joins("JOIN organization_role_permissions ON organization_role_permissions.permission_id = permissions.id").
+		Joins("JOIN organizations ON organizations.role_id = organization_role_permissions.organization_role_id").
+		Where("organizations.id = ? AND organizations.deleted_at IS NULL AND permissions.deleted_at IS NULL", organizationID).
+		Pluck("permissions.name", &names).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return names, nil
+}
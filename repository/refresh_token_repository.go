@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository retorna uma instância que implementa a interface RefreshTokenRepository
+func NewRefreshTokenRepository(db *gorm.DB) domain.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+// Create registra um novo refresh token emitido
+func (r *refreshTokenRepository) Create(ctx context.Context, refreshToken *domain.RefreshToken) error {
+	if err := r.db.WithContext(ctx).Create(refreshToken).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
+
+// GetByTokenID retorna um refresh token pelo seu identificador (jti)
+func (r *refreshTokenRepository) GetByTokenID(ctx context.Context, tokenID string) (domain.RefreshToken, error) {
+	var refreshToken domain.RefreshToken
+	if err := r.db.WithContext(ctx).Where("token_id = ?", tokenID).First(&refreshToken).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return refreshToken, domain.ErrNotFound
+		}
+		return refreshToken, domain.ErrDataBaseInternalError
+	}
+	return refreshToken, nil
+}
+
+// RotateAndCreate marca o token como já utilizado e registra o seu sucessor na mesma transação, assim uma
+// falha não deixa a sessão sem nenhum token válido. A atualização é condicional, então se duas requisições
+// tentarem rotacionar o mesmo token apenas uma vence e a outra recebe ErrRefreshTokenReused
+func (r *refreshTokenRepository) RotateAndCreate(ctx context.Context, tokenID string, next *domain.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.RefreshToken{}).
+			Where("token_id = ? AND rotated_at IS NULL AND revoked_at IS NULL", tokenID).
+			Update("rotated_at", time.Now())
+		if result.Error != nil {
+			return domain.ErrDataBaseInternalError
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrRefreshTokenReused
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return domain.ErrDataBaseInternalError
+		}
+		return nil
+	})
+}
+
+// RevokeFamily revoga todos os tokens ainda válidos de uma família
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	if err := r.db.WithContext(ctx).
+		Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
+
+// RevokeByUserID revoga todos os tokens ainda válidos de um usuário
+func (r *refreshTokenRepository) RevokeByUserID(ctx context.Context, userID uint) error {
+	if err := r.db.WithContext(ctx).
+		Model(&domain.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+type serviceAccountRepository struct {
+	db *gorm.DB
+}
+
+// NewServiceAccountRepository retorna uma instância que implementa a interface ServiceAccountRepository
+func NewServiceAccountRepository(db *gorm.DB) domain.ServiceAccountRepository {
+	return &serviceAccountRepository{
+		db: db,
+	}
+}
+
+// Create cria uma nova conta de serviço dentro da organização de quem fez a requisição
+func (r *serviceAccountRepository) Create(ctx context.Context, serviceAccount *domain.ServiceAccount) error {
+	if organizationID, scoped := scopedOrganization(ctx); scoped && serviceAccount.OrganizationID != organizationID {
+		return domain.ErrOutOfOrganization
+	}
+	if err := r.db.WithContext(ctx).Create(serviceAccount).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
+
+// Fetch retorna as contas de serviço da organização de quem fez a requisição com suas chaves
+func (r *serviceAccountRepository) Fetch(ctx context.Context) ([]domain.ServiceAccount, error) {
+	var serviceAccounts []domain.ServiceAccount
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "service_accounts.organization_id")).
+		Preload("Keys").
+		Find(&serviceAccounts).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return serviceAccounts, nil
+}
+
+// GetByID retorna uma conta de serviço específica com suas chaves
+func (r *serviceAccountRepository) GetByID(ctx context.Context, id uint) (domain.ServiceAccount, error) {
+	var serviceAccount domain.ServiceAccount
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "service_accounts.organization_id")).
+		Preload("Keys").
+		First(&serviceAccount, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return serviceAccount, domain.ErrNotFound
+		}
+		return serviceAccount, domain.ErrDataBaseInternalError
+	}
+	return serviceAccount, nil
+}
+
+// Update atualiza descrição, escopos e status da conta de serviço (inclusive valores zero, como Active=false)
+func (r *serviceAccountRepository) Update(ctx context.Context, serviceAccountID uint, serviceAccount *domain.ServiceAccount) error {
+	if err := r.db.WithContext(ctx).
+		Model(&domain.ServiceAccount{}).
+		Scopes(organizationScope(ctx, "service_accounts.organization_id")).
+		Where("id = ?", serviceAccountID).
+		Select("Description", "Scopes", "Active").
+		Updates(serviceAccount).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
+
+// Delete remove uma conta de serviço e suas chaves, ErrNotFound quando ela não existe na organização de quem
+// fez a requisição
+func (r *serviceAccountRepository) Delete(ctx context.Context, serviceAccountID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var serviceAccount domain.ServiceAccount
+		if err := tx.Scopes(organizationScope(ctx, "service_accounts.organization_id")).First(&serviceAccount, serviceAccountID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrNotFound
+			}
+			return domain.ErrDataBaseInternalError
+		}
+		if err := tx.Where("service_account_id = ?", serviceAccountID).Delete(&domain.ServiceAccountKey{}).Error; err != nil {
+			return domain.ErrDataBaseInternalError
+		}
+		if err := tx.Delete(&domain.ServiceAccount{}, serviceAccountID).Error; err != nil {
+			return domain.ErrDataBaseInternalError
+		}
+		return nil
+	})
+}
+
+// CreateKey registra uma nova chave de API
+func (r *serviceAccountRepository) CreateKey(ctx context.Context, key *domain.ServiceAccountKey) error {
+	if err := r.db.WithContext(ctx).Create(key).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
+
+// GetKeyByPrefix retorna uma chave de API pelo seu prefixo público
+func (r *serviceAccountRepository) GetKeyByPrefix(ctx context.Context, prefix string) (domain.ServiceAccountKey, error) {
+	var key domain.ServiceAccountKey
+	if err := r.db.WithContext(ctx).Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return key, domain.ErrNotFound
+		}
+		return key, domain.ErrDataBaseInternalError
+	}
+	return key, nil
+}
+
+// RevokeKey revoga uma chave de API de uma conta de serviço da organização de quem fez a requisição
+func (r *serviceAccountRepository) RevokeKey(ctx context.Context, serviceAccountID uint, keyID uint) error {
+	query := r.db.WithContext(ctx).Model(&domain.ServiceAccountKey{})
+	if organizationID, scoped := scopedOrganization(ctx); scoped {
+		query = query.Where("service_account_id IN (SELECT id FROM service_accounts WHERE organization_id = ?)", organizationID)
+	}
+	result := query.
+		Where("id = ? AND service_account_id = ? AND revoked_at IS NULL", keyID, serviceAccountID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// TouchKey atualiza a data de último uso da chave
+func (r *serviceAccountRepository) TouchKey(ctx context.Context, keyID uint, usedAt time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Model(&domain.ServiceAccountKey{}).
+		Where("id = ?", keyID).
+		Update("last_used_at", usedAt).Error; err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+	return nil
+}
@@ -67,6 +67,7 @@ func (r *serviceRepository) GetByOrganization(ctx context.Context, organizationI
 	if err := r.db.WithContext(ctx).
 		Preload("Organization").
 		Joins("JOIN organization_services ON services.id = organization_services.service_id").
+		Scopes(organizationScope(ctx, "organization_services.organization_id")).
 		Where("organization_services.organization_id = ?", organizationID).
 		Find(&services).Error; err != nil {
 		return nil, domain.ErrDataBaseInternalError
@@ -74,6 +75,18 @@ func (r *serviceRepository) GetByOrganization(ctx context.Context, organizationI
 	return services, nil
 }
 
+// IsAvailableToOrganization informa se o service está vinculado à organização na tabela pivô
+func (r *serviceRepository) IsAvailableToOrganization(ctx context.Context, serviceID uint, organizationID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Table("organization_services").
+		Where("service_id = ? AND organization_id = ?", serviceID, organizationID).
+		Count(&count).Error; err != nil {
+		return false, domain.ErrDataBaseInternalError
+	}
+	return count > 0, nil
+}
+
 // GetMarketing retorna todos os serviços de marketing
 func (r *serviceRepository) GetMarketing(ctx context.Context) ([]domain.Service, error) {
 	var services []domain.Service
@@ -112,6 +125,36 @@ func (r *serviceRepository) SetAvailabilityToOrganization(ctx context.Context, s
 	return nil
 }
 
+// RemoveAvailabilityFromOrganization desvincula o service de uma organização na tabela pivô (many2many)
+func (r *serviceRepository) RemoveAvailabilityFromOrganization(ctx context.Context, serviceID uint, organizationID uint) error {
+	var service domain.Service
+	if err := r.db.WithContext(ctx).First(&service, serviceID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrNotFound
+		}
+		return domain.ErrDataBaseInternalError
+	}
+
+	var organization domain.Organization
+	if err := r.db.WithContext(ctx).First(&organization, organizationID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrNotFound
+		}
+		return domain.ErrDataBaseInternalError
+	}
+
+	// o vínculo precisa existir, caso contrário não há o que remover
+	if count := r.db.WithContext(ctx).Model(&service).Where("organizations.id = ?", organizationID).Association("Organization").Count(); count == 0 {
+		return domain.ErrNotFound
+	}
+
+	if err := r.db.WithContext(ctx).Model(&service).Association("Organization").Delete(&organization); err != nil {
+		return domain.ErrDataBaseInternalError
+	}
+
+	return nil
+}
+
 // Update atualiza os dados de um service no banco
 func (r *serviceRepository) Update(ctx context.Context, serviceID uint, serviceData *domain.Service) error {
 	// A forma de atualização depende de como você deseja aplicar as mudanças.
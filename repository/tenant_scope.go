@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+// organizationScope restringe a consulta à organização de quem fez a requisição (domain.Principal).
+// Sem principal no contexto (seeds, login, tarefas internas) ou para um platform admin a consulta não é alterada
+// usage: r.db.WithContext(ctx).Scopes(organizationScope(ctx, "users.organization_id")).Find(&users)
+func organizationScope(ctx context.Context, column string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		organizationID, scoped := scopedOrganization(ctx)
+		if !scoped {
+			return db
+		}
+		return db.Where(column+" = ?", organizationID)
+	}
+}
+
+// userOrganizationScope restringe tabelas que só possuem user_id aos usuários da organização de quem fez a requisição
+func userOrganizationScope(ctx context.Context, column string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		organizationID, scoped := scopedOrganization(ctx)
+		if !scoped {
+			return db
+		}
+		return db.Where(column+" IN (SELECT id FROM users WHERE organization_id = ?)", organizationID)
+	}
+}
+
+// scopedOrganization retorna a organização à qual a requisição deve ser restrita
+func scopedOrganization(ctx context.Context) (uint, bool) {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || principal.IsPlatformAdmin() {
+		return 0, false
+	}
+	return principal.OrganizationID, true
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+)
+
+type usageAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewUsageAnalyticsRepository(db *gorm.DB) domain.UsageAnalyticsRepository {
+	return &usageAnalyticsRepository{
+		db: db,
+	}
+}
+
+// Aggregate groups the usage sessions started in [From, To) by time bucket, service, user and/or organization.
+// Archived users are kept, their usage happened
+func (r *usageAnalyticsRepository) Aggregate(ctx context.Context, aggregation domain.UsageAggregation) ([]domain.UsageAnalyticsRow, error) {
+	selects := []string{
+		"COUNT(*) AS sessions",
+		"COALESCE(SUM(user_service_logs.duration), 0) AS duration_seconds",
+		"COUNT(DISTINCT user_service_logs.user_id) AS active_users",
+	}
+	var groups, orders []string
+
+	query := r.db.WithContext(ctx).Model(&domain.UserServiceLog{}).
+		Scopes(userOrganizationScope(ctx, "user_service_logs.user_id")).
+		Joins("JOIN users ON users.id = user_service_logs.user_id").
+		Where("user_service_logs.created_at >= ? AND user_service_logs.created_at < ?", aggregation.From, aggregation.To)
+
+	if aggregation.Bucket != "" {
+		bucket, err := bucketExpression(r.db.Dialector.Name(), aggregation.Bucket, "user_service_logs.created_at")
+		if err != nil {
+			return nil, err
+		}
+		selects = append(selects, bucket+" AS bucket")
+		groups = append(groups, bucket)
+		orders = append(orders, "bucket")
+	}
+	for _, group := range aggregation.GroupBy {
+		switch group {
+		case domain.UsageGroupService:
+			query = query.Joins("JOIN services ON services.id = user_service_logs.service_id")
+			selects = append(selects, "user_service_logs.service_id", "services.name AS service_name")
+			groups = append(groups, "user_service_logs.service_id", "services.name")
+			orders = append(orders, "user_service_logs.service_id")
+		case domain.UsageGroupUser:
+			selects = append(selects, "user_service_logs.user_id", "users.email AS user_email")
+			groups = append(groups, "user_service_logs.user_id", "users.email")
+			orders = append(orders, "user_service_logs.user_id")
+		case domain.UsageGroupOrganization:
+			query = query.Joins("JOIN organizations ON organizations.id = users.organization_id")
+			selects = append(selects, "users.organization_id", "organizations.name AS organization_name")
+			groups = append(groups, "users.organization_id", "organizations.name")
+			orders = append(orders, "users.organization_id")
+		default:
+			return nil, domain.ErrInvalidAnalyticsQuery
+		}
+	}
+
+	if aggregation.ServiceID != 0 {
+		query = query.Where("user_service_logs.service_id = ?", aggregation.ServiceID)
+	}
+	if aggregation.UserID != 0 {
+		query = query.Where("user_service_logs.user_id = ?", aggregation.UserID)
+	}
+	if aggregation.OrganizationID != 0 {
+		query = query.Where("users.organization_id = ?", aggregation.OrganizationID)
+	}
+
+	if aggregation.OrderByDuration {
+		orders = append([]string{"duration_seconds DESC"}, orders...)
+	}
+	query = query.Select(strings.Join(selects, ", "))
+	if len(groups) > 0 {
+		query = query.Group(strings.Join(groups, ", "))
+	}
+	if len(orders) > 0 {
+		query = query.Order(strings.Join(orders, ", "))
+	}
+	if aggregation.Limit > 0 {
+		query = query.Limit(aggregation.Limit)
+	}
+
+	rows := []domain.UsageAnalyticsRow{}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return rows, nil
+}
+
+// bucketExpression returns the SQL expression of the first day ("YYYY-MM-DD") of the bucket of column,
+// postgres truncates the timestamp while sqlite works on its text representation
+func bucketExpression(dialect string, bucket string, column string) (string, error) {
+	switch dialect {
+	case "postgres":
+		switch bucket {
+		case domain.UsageBucketDay, domain.UsageBucketWeek, domain.UsageBucketMonth:
+			return fmt.Sprintf("to_char(date_trunc('%s', %s), 'YYYY-MM-DD')", bucket, column), nil
+		}
+	case "sqlite":
+		switch bucket {
+		case domain.UsageBucketDay:
+			return fmt.Sprintf("strftime('%%Y-%%m-%%d', %s)", column), nil
+		case domain.UsageBucketWeek:
+			// o próximo domingo (ou o próprio dia) menos seis dias é a segunda-feira da semana
+			return fmt.Sprintf("date(%s, 'weekday 0', '-6 days')", column), nil
+		case domain.UsageBucketMonth:
+			return fmt.Sprintf("strftime('%%Y-%%m-01', %s)", column), nil
+		}
+	default:
+		return "", domain.ErrDataBaseInternalError
+	}
+	return "", domain.ErrInvalidAnalyticsQuery
+}
@@ -2,7 +2,7 @@ package repository
 
 import (
 	"context"
-
+	"sync"
 	"time"
 
 	"github.com/gabrielfmcoelho/platform-core/domain"
@@ -19,10 +19,36 @@ func NewUserLogRepository(db *gorm.DB) domain.UserLogRepository {
 	}
 }
 
-// Create cria um novo log de usuário no banco
+// as inclusões na cadeia de hash precisam ser serializadas: o mutex cobre este processo e o advisory lock
+// (postgres) cobre as outras instâncias que usam o mesmo banco
+const auditChainLockKey = 7411201500
+
+var auditChainMutex sync.Mutex
+
+// Create inclui o log na cadeia de hash: lê o hash da última linha e insere a nova linha encadeada a ela,
+// em uma única transação
 func (r *userLogRepository) Create(ctx context.Context, userLog *domain.UserLog) error {
-	if err := r.db.WithContext(ctx).Create(userLog).Error; err != nil {
-		return err
+	auditChainMutex.Lock()
+	defer auditChainMutex.Unlock()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLockKey).Error; err != nil {
+				return err
+			}
+		}
+
+		var last domain.UserLog
+		if err := tx.Unscoped().Select("hash").Order("id DESC").Limit(1).Find(&last).Error; err != nil {
+			return err
+		}
+		userLog.PrevHash = last.Hash
+		userLog.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		userLog.Hash = userLog.ChainHash()
+		return tx.Create(userLog).Error
+	})
+	if err != nil {
+		return domain.ErrDataBaseInternalError
 	}
 	return nil
 }
@@ -30,7 +56,7 @@ func (r *userLogRepository) Create(ctx context.Context, userLog *domain.UserLog)
 // Fetch retorna todos os logs de usuário
 func (r *userLogRepository) Fetch(ctx context.Context) ([]domain.UserLog, error) {
 	var logs []domain.UserLog
-	if err := r.db.WithContext(ctx).Find(&logs).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(userOrganizationScope(ctx, "user_logs.user_id")).Find(&logs).Error; err != nil {
 		return nil, err
 	}
 	return logs, nil
@@ -39,7 +65,7 @@ func (r *userLogRepository) Fetch(ctx context.Context) ([]domain.UserLog, error)
 // GetByUserID retorna todos os logs de um usuário específico
 func (r *userLogRepository) GetByUserID(ctx context.Context, userID uint) ([]domain.UserLog, error) {
 	var logs []domain.UserLog
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&logs).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(userOrganizationScope(ctx, "user_logs.user_id")).Where("user_id = ?", userID).Find(&logs).Error; err != nil {
 		return nil, err
 	}
 	return logs, nil
@@ -48,7 +74,7 @@ func (r *userLogRepository) GetByUserID(ctx context.Context, userID uint) ([]dom
 // GetByDate retorna todos os logs de um usuário específico em uma data específica
 func (r *userLogRepository) GetByDate(ctx context.Context, userID uint, date time.Time) ([]domain.UserLog, error) {
 	var logs []domain.UserLog
-	if err := r.db.WithContext(ctx).Where("user_id = ? AND created_at BETWEEN ? AND ?", userID, date, date.AddDate(0, 0, 1)).Find(&logs).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(userOrganizationScope(ctx, "user_logs.user_id")).Where("user_id = ? AND created_at BETWEEN ? AND ?", userID, date, date.AddDate(0, 0, 1)).Find(&logs).Error; err != nil {
 		return nil, err
 	}
 	return logs, nil
@@ -56,8 +82,70 @@ func (r *userLogRepository) GetByDate(ctx context.Context, userID uint, date tim
 
 // DeleteByID deleta um log de usuário específico pelo ID
 func (r *userLogRepository) DeleteByID(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.UserLog{}, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(userOrganizationScope(ctx, "user_logs.user_id")).Delete(&domain.UserLog{}, id).Error; err != nil {
 		return err
 	}
 	return nil
 }
+
+// StreamForExport lê os logins do filtro com o usuário e a organização de cada um, do mais antigo ao mais recente
+func (r *userLogRepository) StreamForExport(ctx context.Context, filter domain.ExportFilter, fn func(row domain.LoginExportRow) error) error {
+	query := r.db.WithContext(ctx).Model(&domain.UserLog{}).
+		Scopes(userOrganizationScope(ctx, "user_logs.user_id"), exportScope(filter, "user_logs.created_at")).
+		Select("user_logs.id AS log_id, user_logs.user_id, users.email AS user_email, "+
+			"users.organization_id, organizations.name AS organization_name, "+
+			"user_logs.action, user_logs.ip_address, user_logs.created_at").
+		Joins("JOIN users ON users.id = user_logs.user_id").
+		Joins("JOIN organizations ON organizations.id = users.organization_id").
+		Where("user_logs.action = ?", domain.UserLogActionLogin).
+		Order("user_logs.id")
+	return streamRows(query, fn)
+}
+
+// StreamChain lê todos os logs, inclusive os arquivados, em ordem crescente de ID
+func (r *userLogRepository) StreamChain(ctx context.Context, fn func(userLog domain.UserLog) error) error {
+	return streamRows(r.db.WithContext(ctx).Unscoped().Model(&domain.UserLog{}).Order("id"), fn)
+}
+
+// FetchPage lista os logs do filtro após o cursor, restritos à organização de quem fez a requisição
+func (r *userLogRepository) FetchPage(ctx context.Context, filter domain.AuditFilter) ([]domain.UserLog, error) {
+	query := r.db.WithContext(ctx).Scopes(organizationScope(ctx, "user_logs.organization_id"))
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.OrganizationID != 0 {
+		query = query.Where("organization_id = ?", filter.OrganizationID)
+	}
+	for column, value := range map[string]string{
+		"action":      filter.Action,
+		"entity_type": filter.EntityType,
+		"entity_id":   filter.EntityID,
+		"ip_address":  filter.IPAddress,
+	} {
+		if value != "" {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	order := "id"
+	if filter.Descending {
+		order = "id DESC"
+		if filter.Cursor != 0 {
+			query = query.Where("id < ?", filter.Cursor)
+		}
+	} else if filter.Cursor != 0 {
+		query = query.Where("id > ?", filter.Cursor)
+	}
+
+	var logs []domain.UserLog
+	if err := query.Order(order).Limit(filter.Limit).Find(&logs).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return logs, nil
+}
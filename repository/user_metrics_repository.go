@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type userMetricsRepository struct {
+	db *gorm.DB
+}
+
+func NewUserMetricsRepository(db *gorm.DB) domain.UserMetricsRepository {
+	return &userMetricsRepository{
+		db: db,
+	}
+}
+
+// GetByUserID returns the rolled up metrics of a user
+func (r *userMetricsRepository) GetByUserID(ctx context.Context, userID uint) (domain.UserMetrics, error) {
+	var metrics domain.UserMetrics
+	if err := r.db.WithContext(ctx).Scopes(userOrganizationScope(ctx, "user_metrics.user_id")).Where("user_id = ?", userID).First(&metrics).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return metrics, domain.ErrNotFound
+		}
+		return metrics, domain.ErrDataBaseInternalError
+	}
+	return metrics, nil
+}
+
+// Recompute rolls up the logins (UserLog) and the service usage (UserServiceLog) of every active user
+// and upserts one UserMetrics row per user
+func (r *userMetricsRepository) Recompute(ctx context.Context) (int, error) {
+	db := r.db.WithContext(ctx)
+
+	var userIDs []uint
+	if err := db.Model(&domain.User{}).Pluck("id", &userIDs).Error; err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	var logins []struct {
+		UserID uint
+		Total  int
+	}
+	if err := db.Model(&domain.UserLog{}).
+		Select("user_id, COUNT(*) AS total").
+		Where("action = ?", domain.UserLogActionLogin).
+		Group("user_id").
+		Scan(&logins).Error; err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+
+	// o último login é a linha de maior id de cada usuário
+	var lastLogins []domain.UserLog
+	if err := db.Where("id IN (?)", db.Model(&domain.UserLog{}).
+		Select("MAX(id)").
+		Where("action = ?", domain.UserLogActionLogin).
+		Group("user_id")).
+		Find(&lastLogins).Error; err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+
+	var usage []struct {
+		UserID    uint
+		ServiceID uint
+		Seconds   int
+	}
+	if err := db.Model(&domain.UserServiceLog{}).
+		Select("user_id, service_id, COALESCE(SUM(duration), 0) AS seconds").
+		Group("user_id, service_id").
+		Scan(&usage).Error; err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+
+	metrics := make(map[uint]*domain.UserMetrics, len(userIDs))
+	favoriteSeconds := make(map[uint]int, len(userIDs))
+	for _, userID := range userIDs {
+		metrics[userID] = &domain.UserMetrics{UserID: userID}
+	}
+	for _, login := range logins {
+		if m, ok := metrics[login.UserID]; ok {
+			m.TotalLogins = login.Total
+		}
+	}
+	for _, login := range lastLogins {
+		if login.UserID == nil {
+			continue
+		}
+		if m, ok := metrics[*login.UserID]; ok {
+			m.LastIP = login.IPAddress
+			m.LastLogin = login.CreatedAt.UTC().Format(time.RFC3339)
+		}
+	}
+	for _, row := range usage {
+		m, ok := metrics[row.UserID]
+		if !ok {
+			continue
+		}
+		m.TotalUsageDuration += row.Seconds
+		if row.Seconds > favoriteSeconds[row.UserID] {
+			favoriteSeconds[row.UserID] = row.Seconds
+			m.FavoriteServiceID = row.ServiceID
+		}
+	}
+
+	rows := make([]domain.UserMetrics, 0, len(metrics))
+	for _, userID := range userIDs {
+		rows = append(rows, *metrics[userID])
+	}
+	if err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"favorite_service_id", "last_ip", "last_login", "total_logins", "total_usage_duration", "updated_at", "deleted_at",
+		}),
+	}).CreateInBatches(&rows, 100).Error; err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+	return len(rows), nil
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 
-	"time"
-
 	"github.com/gabrielfmcoelho/platform-core/domain"
 	"gorm.io/gorm"
 )
@@ -20,8 +18,11 @@ func NewUserRepository(db *gorm.DB) domain.UserRepository {
 	}
 }
 
-// Create cria um novo usuário no banco de dados
+// Create cria um novo usuário no banco de dados, dentro da organização de quem fez a requisição
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	if organizationID, scoped := scopedOrganization(ctx); scoped && user.OrganizationID != organizationID {
+		return domain.ErrOutOfOrganization
+	}
 	// Usando a transação, se necessário
 	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
 		return domain.ErrDataBaseInternalError
@@ -29,10 +30,13 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
-// Fetch retorna todos os usuários do banco de dados
+// Fetch retorna todos os usuários visíveis para quem fez a requisição
 func (r *userRepository) Fetch(ctx context.Context) ([]domain.User, error) {
 	var users []domain.User
-	if err := r.db.WithContext(ctx).Find(&users).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "users.organization_id")).
+		Preload("Organization").Preload("Role").Preload("Bio").
+		Find(&users).Error; err != nil {
 		return nil, domain.ErrDataBaseInternalError
 	}
 	return users, nil
@@ -41,7 +45,10 @@ func (r *userRepository) Fetch(ctx context.Context) ([]domain.User, error) {
 // GetByEmail retorna um usuário específico com base no email
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (domain.User, error) {
 	var user domain.User
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "users.organization_id")).
+		Preload("Organization").Preload("Role").Preload("Bio").
+		Where("email = ?", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return user, domain.ErrUserEmailNotFound
 		}
@@ -50,10 +57,27 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (domain.U
 	return user, nil
 }
 
+// ExistsByEmail informa se o email já está em uso, em qualquer organização e inclusive por usuários arquivados,
+// como exige o índice único de users.email
+func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&domain.User{}).
+		Where("email = ?", email).
+		Count(&count).Error; err != nil {
+		return false, domain.ErrDataBaseInternalError
+	}
+	return count > 0, nil
+}
+
 // GetByID retorna um usuário específico com base no ID
 func (r *userRepository) GetByID(ctx context.Context, id uint) (domain.User, error) {
 	var user domain.User
-	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "users.organization_id")).
+		Preload("Organization").Preload("Role").Preload("Bio").
+		First(&user, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return user, domain.ErrNotFound
 		}
@@ -62,29 +86,66 @@ func (r *userRepository) GetByID(ctx context.Context, id uint) (domain.User, err
 	return user, nil
 }
 
-// Update atualiza os dados de um usuário no banco
-func (r *userRepository) Update(ctx context.Context, userID uint, userData *domain.User) error {
-	// check if the user exists and get the user
+// GetFirstByRoleName retorna o usuário mais antigo com o UserRole informado
+func (r *userRepository) GetFirstByRoleName(ctx context.Context, roleName string) (domain.User, error) {
+	var user domain.User
 	if err := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "users.organization_id")).
+		Preload("Organization").Preload("Role").Preload("Bio").
+		Joins("JOIN user_roles ON user_roles.id = users.role_id AND user_roles.deleted_at IS NULL").
+		Where("user_roles.role_name = ?", roleName).
+		Order("users.id").First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return user, domain.ErrNotFound
+		}
+		return user, domain.ErrDataBaseInternalError
+	}
+	return user, nil
+}
+
+// Update atualiza os dados de um usuário no banco, um usuário não pode ser movido para outra organização
+// por quem não é platform admin
+func (r *userRepository) Update(ctx context.Context, userID uint, userData *domain.User) error {
+	if organizationID, scoped := scopedOrganization(ctx); scoped && userData.OrganizationID != 0 && userData.OrganizationID != organizationID {
+		return domain.ErrOutOfOrganization
+	}
+	result := r.db.WithContext(ctx).
 		Model(&domain.User{}).
+		Scopes(organizationScope(ctx, "users.organization_id")).
 		Where("id = ?", userID).
-		Updates(userData).Error; err != nil {
+		Updates(userData)
+	if result.Error != nil {
 		return domain.ErrDataBaseInternalError
 	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
 	return nil
 }
 
 // Archive marca um usuário como arquivado (soft delete)
 func (r *userRepository) Archive(ctx context.Context, userID uint) error {
-	// Alterando apenas o campo IsArchived
-	// get user by ID
-	user, err := r.GetByID(ctx, userID)
-	if err != nil {
-		return err
-	}
-	user.DeletedAt = gorm.DeletedAt{Time: time.Now()}
-	if err := r.db.WithContext(ctx).Save(user).Error; err != nil {
+	result := r.db.WithContext(ctx).
+		Scopes(organizationScope(ctx, "users.organization_id")).
+		Delete(&domain.User{}, userID)
+	if result.Error != nil {
 		return domain.ErrDataBaseInternalError
 	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
 	return nil
 }
+
+// CountByOrganization retorna quantos usuários ativos (não arquivados) a organização possui
+func (r *userRepository) CountByOrganization(ctx context.Context, organizationID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Scopes(organizationScope(ctx, "users.organization_id")).
+		Where("organization_id = ?", organizationID).
+		Count(&count).Error; err != nil {
+		return 0, domain.ErrDataBaseInternalError
+	}
+	return count, nil
+}
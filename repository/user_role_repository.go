@@ -30,7 +30,7 @@ func (r *userRoleRepository) Create(ctx context.Context, userRole *domain.UserRo
 // Fetch retorna todos os UserRoles
 func (r *userRoleRepository) Fetch(ctx context.Context) ([]domain.UserRole, error) {
 	var roles []domain.UserRole
-	if err := r.db.WithContext(ctx).Find(&roles).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Permissions").Find(&roles).Error; err != nil {
 		return nil, err
 	}
 	return roles, nil
@@ -39,7 +39,7 @@ func (r *userRoleRepository) Fetch(ctx context.Context) ([]domain.UserRole, erro
 // GetByID retorna um UserRole específico pelo ID
 func (r *userRoleRepository) GetByID(ctx context.Context, id uint) (domain.UserRole, error) {
 	var role domain.UserRole
-	if err := r.db.WithContext(ctx).First(&role, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Permissions").First(&role, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return role, domain.ErrNotFound
 		}
@@ -72,6 +72,16 @@ func (r *userRoleRepository) Update(ctx context.Context, userRoleID uint, update
 	return nil
 }
 
+// ReplacePermissions substitui todas as permissões associadas ao UserRole
+func (r *userRoleRepository) ReplacePermissions(ctx context.Context, userRoleID uint, permissions []domain.Permission) error {
+	role := domain.UserRole{}
+	role.ID = userRoleID
+	if err := r.db.WithContext(ctx).Model(&role).Association("Permissions").Replace(permissions); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Delete remove (fisicamente) um UserRole
 func (r *userRoleRepository) Delete(ctx context.Context, userRoleID uint) error {
 	if err := r.db.WithContext(ctx).Delete(&domain.UserRole{}, userRoleID).Error; err != nil {
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/gabrielfmcoelho/platform-core/domain"
 	"gorm.io/gorm"
@@ -30,7 +31,7 @@ func (r *userServiceLogRepository) Create(ctx context.Context, userServiceLog *d
 // Fetch returns all UserServiceLog entries
 func (r *userServiceLogRepository) Fetch(ctx context.Context) ([]domain.UserServiceLog, error) {
 	var logs []domain.UserServiceLog
-	if err := r.db.WithContext(ctx).Find(&logs).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(userOrganizationScope(ctx, "user_service_logs.user_id")).Find(&logs).Error; err != nil {
 		return nil, domain.ErrDataBaseInternalError
 	}
 	return logs, nil
@@ -39,7 +40,7 @@ func (r *userServiceLogRepository) Fetch(ctx context.Context) ([]domain.UserServ
 // GetByID returns a UserServiceLog by its ID
 func (r *userServiceLogRepository) GetByID(ctx context.Context, id uint) (domain.UserServiceLog, error) {
 	var log domain.UserServiceLog
-	if err := r.db.WithContext(ctx).First(&log, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(userOrganizationScope(ctx, "user_service_logs.user_id")).First(&log, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return log, domain.ErrNotFound
 		}
@@ -51,7 +52,7 @@ func (r *userServiceLogRepository) GetByID(ctx context.Context, id uint) (domain
 // GetByUserID returns a UserServiceLog by user ID
 func (r *userServiceLogRepository) GetByUserID(ctx context.Context, userID uint) (domain.UserServiceLog, error) {
 	var log domain.UserServiceLog
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&log).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(userOrganizationScope(ctx, "user_service_logs.user_id")).Where("user_id = ?", userID).First(&log).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return log, domain.ErrNotFound
 		}
@@ -63,7 +64,7 @@ func (r *userServiceLogRepository) GetByUserID(ctx context.Context, userID uint)
 // GetByServiceID returns a UserServiceLog by service ID
 func (r *userServiceLogRepository) GetByServiceID(ctx context.Context, serviceID uint) (domain.UserServiceLog, error) {
 	var log domain.UserServiceLog
-	if err := r.db.WithContext(ctx).Where("service_id = ?", serviceID).First(&log).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(userOrganizationScope(ctx, "user_service_logs.user_id")).Where("service_id = ?", serviceID).First(&log).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return log, domain.ErrNotFound
 		}
@@ -72,20 +73,157 @@ func (r *userServiceLogRepository) GetByServiceID(ctx context.Context, serviceID
 	return log, nil
 }
 
-// Update updates an existing UserServiceLog, add duration to the existing duration
-func (r *userServiceLogRepository) UpdateDuration(ctx context.Context, userServiceLogID uint, duration int) error {
-	if err := r.db.WithContext(ctx).Model(&domain.UserServiceLog{}).
-		Where("id = ?", userServiceLogID).
-		Update("duration", gorm.Expr("duration + ?", duration)).Error; err != nil {
+// UpdateSession saves the duration, heartbeat and status of a session that is still active.
+// A session closed meanwhile (by the user or by the idle job) is not touched and ErrUsageSessionClosed is returned
+func (r *userServiceLogRepository) UpdateSession(ctx context.Context, userServiceLog *domain.UserServiceLog) error {
+	result := r.db.WithContext(ctx).Model(&domain.UserServiceLog{}).
+		Scopes(userOrganizationScope(ctx, "user_service_logs.user_id")).
+		Where("id = ? AND status = ?", userServiceLog.ID, domain.UsageSessionActive).
+		Updates(map[string]interface{}{
+			"duration":          userServiceLog.Duration,
+			"last_heartbeat_at": userServiceLog.LastHeartbeatAt,
+			"status":            userServiceLog.Status,
+			"ended_at":          userServiceLog.EndedAt,
+		})
+	if result.Error != nil {
 		return domain.ErrDataBaseInternalError
 	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUsageSessionClosed
+	}
 	return nil
 }
 
+// CloseIdle expires the active sessions whose last heartbeat is older than before, they end at their last heartbeat.
+// It returns how many sessions were closed and the organizations of their users. The sessions are picked first and
+// only those are expired, so every closed session has its organization in the result even when another session
+// goes idle meanwhile
+func (r *userServiceLogRepository) CloseIdle(ctx context.Context, before time.Time) (int64, []uint, error) {
+	var closed int64
+	var organizationIDs []uint
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sessionIDs []uint
+		if err := tx.Model(&domain.UserServiceLog{}).
+			Where("status = ? AND last_heartbeat_at < ?", domain.UsageSessionActive, before).
+			Pluck("id", &sessionIDs).Error; err != nil {
+			return err
+		}
+		if len(sessionIDs) == 0 {
+			return nil
+		}
+
+		// the conditions are repeated so a session that got a heartbeat or ended since it was picked is kept
+		result := tx.Model(&domain.UserServiceLog{}).
+			Where("id IN ? AND status = ? AND last_heartbeat_at < ?", sessionIDs, domain.UsageSessionActive, before).
+			Updates(map[string]interface{}{
+				"status":   domain.UsageSessionExpired,
+				"ended_at": gorm.Expr("last_heartbeat_at"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		closed = result.RowsAffected
+
+		return tx.Model(&domain.UserServiceLog{}).
+			Distinct("users.organization_id").
+			Joins("JOIN users ON users.id = user_service_logs.user_id").
+			Where("user_service_logs.id IN ?", sessionIDs).
+			Pluck("users.organization_id", &organizationIDs).Error
+	})
+	if err != nil {
+		return 0, nil, domain.ErrDataBaseInternalError
+	}
+	return closed, organizationIDs, nil
+}
+
+// FetchActiveByOrganization returns the active sessions of the users of the organization with the user email
+// and the service name, ordered by service and start
+func (r *userServiceLogRepository) FetchActiveByOrganization(ctx context.Context, organizationID uint) ([]domain.ActiveUsageSession, error) {
+	var sessions []domain.ActiveUsageSession
+	if err := r.db.WithContext(ctx).Model(&domain.UserServiceLog{}).
+		Scopes(userOrganizationScope(ctx, "user_service_logs.user_id")).
+		Select("user_service_logs.id AS log_id, user_service_logs.user_id, users.email AS user_email, "+
+			"user_service_logs.service_id, services.name AS service_name, "+
+			"user_service_logs.created_at AS started_at, user_service_logs.last_heartbeat_at").
+		Joins("JOIN users ON users.id = user_service_logs.user_id AND users.deleted_at IS NULL").
+		Joins("JOIN services ON services.id = user_service_logs.service_id").
+		Where("user_service_logs.status = ? AND users.organization_id = ?", domain.UsageSessionActive, organizationID).
+		Order("user_service_logs.service_id, user_service_logs.created_at").
+		Scan(&sessions).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+	return sessions, nil
+}
+
+// SumDurationByOrganization returns the seconds of usage of each service by the users of the organization
+// (archived users included) in sessions started in [start, end)
+func (r *userServiceLogRepository) SumDurationByOrganization(ctx context.Context, organizationID uint, start time.Time, end time.Time) (map[uint]int64, error) {
+	var rows []struct {
+		ServiceID uint
+		Seconds   int64
+	}
+	if err := r.db.WithContext(ctx).Model(&domain.UserServiceLog{}).
+		Scopes(userOrganizationScope(ctx, "user_service_logs.user_id")).
+		Select("service_id, COALESCE(SUM(duration), 0) AS seconds").
+		Where("user_id IN (SELECT id FROM users WHERE organization_id = ?)", organizationID).
+		Where("created_at >= ? AND created_at < ?", start, end).
+		Group("service_id").
+		Scan(&rows).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+
+	usage := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		usage[row.ServiceID] = row.Seconds
+	}
+	return usage, nil
+}
+
+// CountActiveByService returns how many sessions are active in each service, across every organization
+// unless the context carries a principal
+func (r *userServiceLogRepository) CountActiveByService(ctx context.Context) (map[uint]int64, error) {
+	var rows []struct {
+		ServiceID uint
+		Sessions  int64
+	}
+	if err := r.db.WithContext(ctx).Model(&domain.UserServiceLog{}).
+		Scopes(userOrganizationScope(ctx, "user_service_logs.user_id")).
+		Select("service_id, COUNT(*) AS sessions").
+		Where("status = ?", domain.UsageSessionActive).
+		Group("service_id").
+		Scan(&rows).Error; err != nil {
+		return nil, domain.ErrDataBaseInternalError
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ServiceID] = row.Sessions
+	}
+	return counts, nil
+}
+
 // Delete removes a UserServiceLog by its ID (hard delete)
 func (r *userServiceLogRepository) Delete(ctx context.Context, userServiceLogID uint) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.UserServiceLog{}, userServiceLogID).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(userOrganizationScope(ctx, "user_service_logs.user_id")).Delete(&domain.UserServiceLog{}, userServiceLogID).Error; err != nil {
 		return domain.ErrDataBaseInternalError
 	}
 	return nil
 }
+
+// StreamForExport reads the sessions of the filter with their user, organization and service, oldest first
+func (r *userServiceLogRepository) StreamForExport(ctx context.Context, filter domain.ExportFilter, fn func(row domain.UsageExportRow) error) error {
+	query := r.db.WithContext(ctx).Model(&domain.UserServiceLog{}).
+		Scopes(userOrganizationScope(ctx, "user_service_logs.user_id"), exportScope(filter, "user_service_logs.created_at")).
+		Select("user_service_logs.id AS log_id, user_service_logs.user_id, users.email AS user_email, " +
+			"users.organization_id, organizations.name AS organization_name, " +
+			"user_service_logs.service_id, services.name AS service_name, user_service_logs.status, " +
+			"user_service_logs.created_at AS started_at, user_service_logs.ended_at, user_service_logs.duration").
+		Joins("JOIN users ON users.id = user_service_logs.user_id").
+		Joins("JOIN organizations ON organizations.id = users.organization_id").
+		Joins("JOIN services ON services.id = user_service_logs.service_id").
+		Order("user_service_logs.id")
+	if filter.ServiceID != 0 {
+		query = query.Where("user_service_logs.service_id = ?", filter.ServiceID)
+	}
+	return streamRows(query, fn)
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/audit"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+)
+
+// errAuditChainBroken interrompe a leitura da cadeia na primeira linha inválida
+var errAuditChainBroken = errors.New("audit chain broken")
+
+type auditUsecase struct {
+	userLogRepository domain.UserLogRepository
+	contextTimeout    time.Duration
+}
+
+// NewAuditUsecase cria um novo caso de uso para a trilha de auditoria (UserLog encadeado por hash)
+func NewAuditUsecase(userLogRepository domain.UserLogRepository, timeout time.Duration) domain.AuditUsecase {
+	return &auditUsecase{
+		userLogRepository: userLogRepository,
+		contextTimeout:    timeout,
+	}
+}
+
+// Record grava a alteração com o autor (principal) e os dados da requisição presentes no contexto
+func (au *auditUsecase) Record(c context.Context, entry domain.AuditEntry) {
+	ctx, cancel := withTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	changes, err := audit.Diff(entry.Before, entry.After)
+	if err != nil {
+		slog.ErrorContext(ctx, "audit diff failed", "action", entry.Action, "entity_id", entry.EntityID, "error", err)
+		return
+	}
+
+	metadata := domain.RequestMetadataFromContext(ctx)
+	userLog := domain.UserLog{
+		IPAddress:  metadata.IPAddress,
+		UserAgent:  metadata.UserAgent,
+		RequestID:  metadata.RequestID,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Changes:    changes,
+	}
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		if principal.UserID != 0 {
+			userLog.UserID = &principal.UserID
+		}
+		if principal.ServiceAccountID != 0 {
+			userLog.ServiceAccountID = &principal.ServiceAccountID
+		}
+		if principal.OrganizationID != 0 {
+			userLog.OrganizationID = &principal.OrganizationID
+		}
+	}
+
+	if err := au.userLogRepository.Create(ctx, &userLog); err != nil {
+		slog.ErrorContext(ctx, "audit record failed", "action", entry.Action, "entity_id", entry.EntityID, "error", err)
+	}
+}
+
+// Fetch lista uma página da trilha de auditoria. Uma linha a mais é lida para saber se existe a próxima página
+func (au *auditUsecase) Fetch(c context.Context, filter domain.AuditFilter) (domain.AuditPage, error) {
+	ctx, cancel := withTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultAuditPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > domain.MaxAuditPageSize {
+		return domain.AuditPage{}, domain.ErrInvalidAuditQuery
+	}
+	pageSize := filter.Limit
+	filter.Limit++
+
+	logs, err := au.userLogRepository.FetchPage(ctx, filter)
+	if err != nil {
+		return domain.AuditPage{}, mapOrganizationError(err)
+	}
+
+	page := domain.AuditPage{Items: make([]domain.PublicUserLog, 0, len(logs))}
+	if len(logs) > pageSize {
+		logs = logs[:pageSize]
+		page.NextCursor = domain.EncodeAuditCursor(logs[pageSize-1].ID)
+	}
+	for _, userLog := range logs {
+		page.Items = append(page.Items, parser.ToPublicUserLog(userLog))
+	}
+	return page, nil
+}
+
+// Verify percorre a cadeia inteira conferindo o encadeamento e o hash de cada linha. Assim como as
+// exportações, não usa o CONTEXT_TIMEOUT: a duração depende do tamanho da trilha
+func (au *auditUsecase) Verify(ctx context.Context) (domain.AuditVerification, error) {
+	verification := domain.AuditVerification{Valid: true}
+	err := au.userLogRepository.StreamChain(ctx, func(userLog domain.UserLog) error {
+		// linhas anteriores à cadeia só são aceitas antes da primeira linha encadeada
+		if userLog.Hash == "" && verification.Checked == 0 {
+			verification.Legacy++
+			return nil
+		}
+
+		verification.Checked++
+		switch {
+		case userLog.Hash == "":
+			verification.Reason = "row without hash after the start of the chain"
+		case userLog.PrevHash != verification.HeadHash:
+			verification.Reason = "previous hash does not match, a row was removed or reordered"
+		case userLog.ChainHash() != userLog.Hash:
+			verification.Reason = "hash does not match the content, the row was modified"
+		default:
+			verification.HeadHash = userLog.Hash
+			return nil
+		}
+		verification.Valid = false
+		id := userLog.ID
+		verification.BrokenAt = &id
+		return errAuditChainBroken
+	})
+	if err != nil && err != errAuditChainBroken {
+		return domain.AuditVerification{}, mapOrganizationError(err)
+	}
+	verification.VerifiedAt = time.Now().UTC()
+	return verification, nil
+}
+
+// auditID formata o id da entidade auditada
+func auditID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
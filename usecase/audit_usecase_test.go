@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"gorm.io/gorm"
+)
+
+func TestAuditVerify(t *testing.T) {
+	tests := []struct {
+		name         string
+		before       func(t *testing.T, db *gorm.DB)
+		tamper       func(t *testing.T, db *gorm.DB)
+		wantValid    bool
+		wantBrokenAt uint
+		wantChecked  int64
+		wantLegacy   int64
+	}{
+		{
+			name:        "untouched chain",
+			tamper:      func(t *testing.T, db *gorm.DB) {},
+			wantValid:   true,
+			wantChecked: 3,
+		},
+		{
+			name: "rows written before the chain",
+			before: func(t *testing.T, db *gorm.DB) {
+				execSQL(t, db, "INSERT INTO user_logs (created_at, updated_at, action) VALUES (CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'login')")
+			},
+			tamper:      func(t *testing.T, db *gorm.DB) {},
+			wantValid:   true,
+			wantChecked: 3,
+			wantLegacy:  1,
+		},
+		{
+			name: "modified row",
+			tamper: func(t *testing.T, db *gorm.DB) {
+				execSQL(t, db, `UPDATE user_logs SET changes = '{"email":{"before":null,"after":"other@org.test"}}' WHERE id = 2`)
+			},
+			wantBrokenAt: 2,
+			wantChecked:  2,
+		},
+		{
+			name: "removed row",
+			tamper: func(t *testing.T, db *gorm.DB) {
+				execSQL(t, db, "DELETE FROM user_logs WHERE id = 2")
+			},
+			wantBrokenAt: 3,
+			wantChecked:  2,
+		},
+		{
+			name: "hash erased",
+			tamper: func(t *testing.T, db *gorm.DB) {
+				execSQL(t, db, "UPDATE user_logs SET hash = '' WHERE id = 3")
+			},
+			wantBrokenAt: 3,
+			wantChecked:  3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := newTestDB(t)
+			if tt.before != nil {
+				tt.before(t, db)
+			}
+			au := NewAuditUsecase(repository.NewUserLogRepository(db), testTimeout)
+			for _, email := range []string{"first@org.test", "second@org.test", "third@org.test"} {
+				au.Record(ctx, domain.AuditEntry{
+					Action:     domain.UserLogActionUserCreate,
+					EntityType: domain.UserLogEntityUser,
+					EntityID:   email,
+					After:      map[string]interface{}{"email": email},
+				})
+			}
+			tt.tamper(t, db)
+
+			verification, err := au.Verify(ctx)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if verification.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (reason %q)", verification.Valid, tt.wantValid, verification.Reason)
+			}
+			if verification.Checked != tt.wantChecked || verification.Legacy != tt.wantLegacy {
+				t.Errorf("Checked, Legacy = %d, %d, want %d, %d", verification.Checked, verification.Legacy, tt.wantChecked, tt.wantLegacy)
+			}
+			var brokenAt uint
+			if verification.BrokenAt != nil {
+				brokenAt = *verification.BrokenAt
+			}
+			if brokenAt != tt.wantBrokenAt {
+				t.Errorf("BrokenAt = %d, want %d", brokenAt, tt.wantBrokenAt)
+			}
+		})
+	}
+}
+
+func execSQL(t *testing.T, db *gorm.DB, sql string) {
+	t.Helper()
+	if err := db.Exec(sql).Error; err != nil {
+		t.Fatalf("exec %q: %v", sql, err)
+	}
+}
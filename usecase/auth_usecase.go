@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
 	"time"
 
 	"github.com/gabrielfmcoelho/platform-core/domain"
@@ -11,22 +14,35 @@ import (
 )
 
 type AuthUsecase struct {
-	userRepository    domain.UserRepository
-	userLogRepository domain.UserLogRepository
-	contextTimeout    time.Duration
+	userRepository               domain.UserRepository
+	userLogRepository            domain.UserLogRepository
+	refreshTokenRepository       domain.RefreshTokenRepository
+	passwordResetTokenRepository domain.PasswordResetTokenRepository
+	loginLockoutRepository       domain.LoginLockoutRepository
+	lockoutPolicy                domain.LoginLockoutPolicy
+	mailer                       domain.Mailer
+	metrics                      domain.RuntimeMetrics
+	contextTimeout               time.Duration
 }
 
-func NewAuthUsecase(userRepository domain.UserRepository, userLogRepository domain.UserLogRepository, timeout time.Duration) *AuthUsecase {
+func NewAuthUsecase(userRepository domain.UserRepository, userLogRepository domain.UserLogRepository, refreshTokenRepository domain.RefreshTokenRepository, passwordResetTokenRepository domain.PasswordResetTokenRepository, loginLockoutRepository domain.LoginLockoutRepository, lockoutPolicy domain.LoginLockoutPolicy, mailer domain.Mailer, metrics domain.RuntimeMetrics, timeout time.Duration) *AuthUsecase {
 	return &AuthUsecase{
-		userRepository:    userRepository,
-		userLogRepository: userLogRepository,
-		contextTimeout:    timeout,
+		userRepository:               userRepository,
+		userLogRepository:            userLogRepository,
+		refreshTokenRepository:       refreshTokenRepository,
+		passwordResetTokenRepository: passwordResetTokenRepository,
+		loginLockoutRepository:       loginLockoutRepository,
+		lockoutPolicy:                lockoutPolicy,
+		mailer:                       mailer,
+		metrics:                      metrics,
+		contextTimeout:               timeout,
 	}
 }
 
-func (au *AuthUsecase) LoginUserByEmail(c context.Context, email string, rawPassword string, accessSecret string, accessExpiry int, refreshSecret string, refreshExpiry int) (loginResponse *domain.LoginResponse, err error) {
-	ctx, cancel := context.WithTimeout(c, au.contextTimeout) // This creates a new context with a timeout and a cancel function, which should be called at the end of the function to release resources
+func (au *AuthUsecase) LoginUserByEmail(c context.Context, email string, rawPassword string, ipAddress string, accessSecret string, accessExpiry int, refreshSecret string, refreshExpiry int) (loginResponse *domain.LoginResponse, err error) {
+	ctx, cancel := withTimeout(c, au.contextTimeout) // This creates a new context with a timeout and a cancel function, which should be called at the end of the function to release resources
 	defer cancel()
+	defer func() { au.metrics.LoginAttempt("password", err) }()
 
 	user, err := au.userRepository.GetByEmail(ctx, email)
 	// if the user is not found, return an error with a message
@@ -37,11 +53,28 @@ func (au *AuthUsecase) LoginUserByEmail(c context.Context, email string, rawPass
 		return nil, err
 	}
 
+	// uma conta bloqueada é recusada antes de conferir a senha, tentar adivinhá-la não adianta enquanto o bloqueio durar
+	lockout, err := au.loginLockoutRepository.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+	if lockout.LockedUntil != nil && time.Now().Before(*lockout.LockedUntil) {
+		return nil, &domain.AccountLockedError{RetryAfter: time.Until(*lockout.LockedUntil)}
+	}
+
 	// verify if the password is match
 	err = password.VerifyPassword(user.Password, rawPassword)
 	if err != nil {
+		if errors.Is(err, domain.ErrUserPasswordNotMatch) {
+			return nil, au.recordFailedLogin(ctx, user)
+		}
 		return nil, err
 	}
+	if lockout.FailedAttempts > 0 {
+		if err = au.loginLockoutRepository.Reset(ctx, user.ID); err != nil {
+			return nil, domain.ErrInternalServerError
+		}
+	}
 
 	// create access token
 	accessToken, err := au.CreateAccessToken(&user, accessSecret, accessExpiry)
@@ -49,17 +82,18 @@ func (au *AuthUsecase) LoginUserByEmail(c context.Context, email string, rawPass
 		return nil, err
 	}
 
-	// create refresh token
-	refreshToken, err := au.CreateRefreshToken(&user, refreshSecret, refreshExpiry)
+	// create refresh token, every login starts a new token family
+	familyID, err := tokenutil.GenerateTokenID()
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+	refreshToken, err := au.CreateRefreshToken(ctx, &user, familyID, refreshSecret, refreshExpiry)
 	if err != nil {
 		return nil, err
 	}
 
 	// LOG INTO USER LOG
-	au.userLogRepository.Create(ctx, &domain.UserLog{
-		UserID: user.ID,
-		Action: "login",
-	})
+	au.recordLogin(ctx, user, ipAddress)
 
 	// return the login response
 	return &domain.LoginResponse{
@@ -68,37 +102,38 @@ func (au *AuthUsecase) LoginUserByEmail(c context.Context, email string, rawPass
 	}, nil
 }
 
-func (au *AuthUsecase) LoginGuestUser(c context.Context, accessSecret string, accessExpiry int, refreshSecret string, refreshExpiry int) (loginResponse *domain.LoginResponse, err error) {
-	ctx, cancel := context.WithTimeout(c, au.contextTimeout) // This creates a new context with a timeout and a cancel function, which should be called at the end of the function to release resources
+func (au *AuthUsecase) LoginGuestUser(c context.Context, ipAddress string, accessSecret string, accessExpiry int, refreshSecret string, refreshExpiry int) (loginResponse *domain.LoginResponse, err error) {
+	ctx, cancel := withTimeout(c, au.contextTimeout) // This creates a new context with a timeout and a cancel function, which should be called at the end of the function to release resources
 	defer cancel()
+	defer func() { au.metrics.LoginAttempt("guest", err) }()
 
-	//log.Println("Requesting IP")
-	//incomingIP := ctx.Value("ip").(string) DOES NOT WORK
-	//log.Println("Incoming IP:", incomingIP)
-
-	user, err := au.userRepository.GetByID(ctx, 4)
-
+	// the guest account is the first user with the Guest role, created by the seed profiles
+	user, err := au.userRepository.GetFirstByRoleName(ctx, domain.GuestRoleName)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrGuestLoginUnavailable
+		}
+		return nil, domain.ErrInternalServerError
 	}
 
 	// create access token
-	accessToken, err := au.CreateAccessToken(&user, "accessSecret", 1)
+	accessToken, err := au.CreateAccessToken(&user, accessSecret, accessExpiry)
 	if err != nil {
 		return nil, err
 	}
 
 	// create refresh token
-	refreshToken, err := au.CreateRefreshToken(&user, "refreshSecret", 1)
+	familyID, err := tokenutil.GenerateTokenID()
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+	refreshToken, err := au.CreateRefreshToken(ctx, &user, familyID, refreshSecret, refreshExpiry)
 	if err != nil {
 		return nil, err
 	}
 
 	// LOG INTO USER LOG
-	au.userLogRepository.Create(ctx, &domain.UserLog{
-		UserID: user.ID,
-		Action: "login",
-	})
+	au.recordLogin(ctx, user, ipAddress)
 
 	return &domain.LoginResponse{
 		AccessToken:  accessToken,
@@ -106,53 +141,292 @@ func (au *AuthUsecase) LoginGuestUser(c context.Context, accessSecret string, ac
 	}, nil
 }
 
+// recordFailedLogin conta a senha errada e bloqueia a conta quando a política manda, o bloqueio é progressivo:
+// cada falha depois do limite dobra a sua duração
+func (au *AuthUsecase) recordFailedLogin(ctx context.Context, user domain.User) error {
+	if au.lockoutPolicy.Threshold <= 0 {
+		return domain.ErrUserPasswordNotMatch
+	}
+
+	failedAttempts, err := au.loginLockoutRepository.RecordFailure(ctx, user.ID)
+	if err != nil {
+		return domain.ErrInternalServerError
+	}
+	lockFor := au.lockoutPolicy.LockFor(failedAttempts)
+	if lockFor == 0 {
+		return domain.ErrUserPasswordNotMatch
+	}
+	if err := au.loginLockoutRepository.Lock(ctx, user.ID, time.Now().Add(lockFor)); err != nil {
+		return domain.ErrInternalServerError
+	}
+	slog.WarnContext(ctx, "account locked after failed logins", "locked_user_id", user.ID, "failed_attempts", failedAttempts, "locked_for", lockFor.String())
+	return domain.ErrUserPasswordNotMatch
+}
+
+// recordLogin registra o login na trilha de auditoria, o autor é o próprio usuário
+func (au *AuthUsecase) recordLogin(ctx context.Context, user domain.User, ipAddress string) {
+	metadata := domain.RequestMetadataFromContext(ctx)
+	au.userLogRepository.Create(ctx, &domain.UserLog{
+		UserID:         &user.ID,
+		OrganizationID: &user.OrganizationID,
+		IPAddress:      ipAddress,
+		UserAgent:      metadata.UserAgent,
+		RequestID:      metadata.RequestID,
+		Action:         domain.UserLogActionLogin,
+		EntityType:     domain.UserLogEntityUser,
+		EntityID:       auditID(user.ID),
+	})
+}
+
 func (au *AuthUsecase) CreateAccessToken(user *domain.User, accessSecret string, expiry int) (accessToken string, err error) {
 	return tokenutil.CreateAccessToken(user, accessSecret, expiry)
 }
 
-func (au *AuthUsecase) CreateRefreshToken(user *domain.User, refreshSecret string, expiry int) (refreshToken string, err error) {
-	return tokenutil.CreateRefreshToken(user, refreshSecret, expiry)
+// CreateRefreshToken signs a new refresh token for the given family and persists it so it can be rotated or revoked later
+func (au *AuthUsecase) CreateRefreshToken(ctx context.Context, user *domain.User, familyID string, refreshSecret string, expiry int) (refreshToken string, err error) {
+	refreshToken, stored, err := signRefreshToken(user, familyID, refreshSecret, expiry)
+	if err != nil {
+		return "", err
+	}
+
+	if err = au.refreshTokenRepository.Create(ctx, &stored); err != nil {
+		return "", err
+	}
+
+	return refreshToken, nil
 }
 
-func (au *AuthUsecase) ForgotPassword(c context.Context, email string) (err error) {
-	//ctx, cancel := context.WithTimeout(c, au.contextTimeout)
-	//defer cancel()
+// signRefreshToken signs a new refresh token for the given family and returns the record that tracks it
+func signRefreshToken(user *domain.User, familyID string, refreshSecret string, expiry int) (string, domain.RefreshToken, error) {
+	tokenID, err := tokenutil.GenerateTokenID()
+	if err != nil {
+		return "", domain.RefreshToken{}, domain.ErrInternalServerError
+	}
 
-	//user, err := au.userRepository.GetByEmail(ctx, email)
-	//if err != nil {
-	//	if !errors.Is(err, domain.ErrUserEmailNotFound) {
-	//		return domain.ErrInternalServerError
-	//	}
-	//	return err
-	//}
+	refreshToken, err := tokenutil.CreateRefreshToken(user, refreshSecret, expiry, tokenID)
+	if err != nil {
+		return "", domain.RefreshToken{}, err
+	}
 
-	// send email with the reset password link
+	return refreshToken, domain.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenID:   tokenID,
+		ExpiresAt: time.Now().Add(time.Hour * time.Duration(expiry)),
+	}, nil
+}
+
+// RefreshToken validates a refresh token, rotates it and returns a new access/refresh pair.
+// If a token that was already rotated is presented again the whole family is revoked,
+// since it means the token leaked and is being replayed.
+func (au *AuthUsecase) RefreshToken(c context.Context, rawRefreshToken string, accessSecret string, accessExpiry int, refreshSecret string, refreshExpiry int) (refreshTokenResponse *domain.RefreshTokenResponse, err error) {
+	ctx, cancel := withTimeout(c, au.contextTimeout)
+	defer cancel()
+	defer func() { au.metrics.LoginAttempt("refresh", err) }()
+
+	claims, err := tokenutil.ExtractRefreshClaims(rawRefreshToken, refreshSecret)
+	if err != nil {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	stored, err := au.refreshTokenRepository.GetByTokenID(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, domain.ErrInternalServerError
+	}
+
+	userID, err := tokenutil.ExtractUserIDFromSubject(claims.Subject)
+	if err != nil || userID != stored.UserID {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	if stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	// a rotated token presented again means reuse, the conditional update in RotateAndCreate also covers concurrent refreshes
+	if stored.RotatedAt != nil {
+		return nil, au.revokeFamily(ctx, stored.FamilyID)
+	}
+
+	user, err := au.userRepository.GetByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, domain.ErrInternalServerError
+	}
+
+	accessToken, err := au.CreateAccessToken(&user, accessSecret, accessExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, next, err := signRefreshToken(&user, stored.FamilyID, refreshSecret, refreshExpiry)
+	if err != nil {
+		return nil, err
+	}
+	if err = au.refreshTokenRepository.RotateAndCreate(ctx, stored.TokenID, &next); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			return nil, au.revokeFamily(ctx, stored.FamilyID)
+		}
+		return nil, domain.ErrInternalServerError
+	}
+
+	return &domain.RefreshTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// revokeFamily revokes every token of a family after a reuse was detected
+func (au *AuthUsecase) revokeFamily(ctx context.Context, familyID string) error {
+	if err := au.refreshTokenRepository.RevokeFamily(ctx, familyID); err != nil {
+		return domain.ErrInternalServerError
+	}
+	return domain.ErrRefreshTokenReused
+}
+
+// RevokeSessions revokes every refresh token of the user, the access tokens already issued expire on their own
+func (au *AuthUsecase) RevokeSessions(c context.Context, userID uint) error {
+	ctx, cancel := withTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	if err := au.refreshTokenRepository.RevokeByUserID(ctx, userID); err != nil {
+		return domain.ErrInternalServerError
+	}
+	return nil
+}
+
+// Unlock apaga as falhas de login do usuário. Um operador desbloqueando a conta ou uma redefinição de senha
+// mostram que o dono voltou a controlá-la
+func (au *AuthUsecase) Unlock(c context.Context, userID uint) error {
+	ctx, cancel := withTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	if err := au.loginLockoutRepository.Reset(ctx, userID); err != nil {
+		return domain.ErrInternalServerError
+	}
 	return nil
 }
 
-func (au *AuthUsecase) ResetPassword(c context.Context, email string, newRawPassword string) (err error) {
-	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+// ForgotPassword issues a single-use reset token for the user and emails the reset link.
+// Unknown emails are silently ignored so the endpoint can't be used to discover accounts, for the same reason
+// a link that could not be issued or sent is only logged: every email gets the same response.
+func (au *AuthUsecase) ForgotPassword(c context.Context, email string, resetURL string, resetExpiry int) (err error) {
+	ctx, cancel := withTimeout(c, au.contextTimeout)
 	defer cancel()
 
 	user, err := au.userRepository.GetByEmail(ctx, email)
 	if err != nil {
-		if !errors.Is(err, domain.ErrUserEmailNotFound) {
-			return domain.ErrInternalServerError
+		if errors.Is(err, domain.ErrUserEmailNotFound) {
+			return nil
 		}
+		return domain.ErrInternalServerError
+	}
+
+	if err := au.sendPasswordReset(ctx, user, resetURL, resetExpiry); err != nil {
+		slog.ErrorContext(ctx, "password reset link not sent", "reset_user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+// sendPasswordReset invalidates the previous links of the user, issues a new one and emails it
+func (au *AuthUsecase) sendPasswordReset(ctx context.Context, user domain.User, resetURL string, resetExpiry int) error {
+	// only the most recent link stays valid
+	if err := au.passwordResetTokenRepository.InvalidateByUserID(ctx, user.ID); err != nil {
 		return err
 	}
 
+	rawToken, err := tokenutil.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(time.Minute * time.Duration(resetExpiry))
+	err = au.passwordResetTokenRepository.Create(ctx, &domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: tokenutil.HashOpaqueToken(rawToken),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	// send email with the reset password link
+	link := fmt.Sprintf("%s?token=%s", resetURL, url.QueryEscape(rawToken))
+	return au.mailer.Send(ctx, &domain.MailMessage{
+		To:      []string{user.Email},
+		Subject: "Redefinição de senha",
+		Body: fmt.Sprintf(
+			"Recebemos uma solicitação para redefinir a sua senha.\n\nAcesse o link abaixo até %s para escolher uma nova senha:\n%s\n\nSe você não fez essa solicitação, ignore este email.\n",
+			expiresAt.Format("02/01/2006 15:04"), link,
+		),
+	})
+}
+
+// ResetPassword consumes a reset token and sets the new password.
+// Every refresh token of the user is revoked, ending the sessions opened with the old password.
+func (au *AuthUsecase) ResetPassword(c context.Context, rawToken string, newRawPassword string) (err error) {
+	ctx, cancel := withTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	resetToken, err := au.passwordResetTokenRepository.GetByTokenHash(ctx, tokenutil.HashOpaqueToken(rawToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidResetToken
+		}
+		return domain.ErrInternalServerError
+	}
+
+	if resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		return domain.ErrInvalidResetToken
+	}
+
+	user, err := au.userRepository.GetByID(ctx, resetToken.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidResetToken
+		}
+		return domain.ErrInternalServerError
+	}
+
+	// consume the token before touching the password, so a concurrent request with the same token fails
+	if err = au.passwordResetTokenRepository.MarkUsed(ctx, resetToken.ID); err != nil {
+		if errors.Is(err, domain.ErrInvalidResetToken) {
+			return err
+		}
+		return domain.ErrInternalServerError
+	}
+
 	// update user password
-	user.Password, err = password.HashPassword(newRawPassword)
+	hashedPassword, err := password.HashPassword(newRawPassword)
 	if err != nil {
 		return err
 	}
 
-	err = au.userRepository.Update(ctx, user.ID, &user)
+	err = au.userRepository.Update(ctx, user.ID, &domain.User{Password: hashedPassword})
 	if err != nil {
 		return err
 	}
 
-	// send email with the password reset confirmation
+	if err = au.refreshTokenRepository.RevokeByUserID(ctx, user.ID); err != nil {
+		return domain.ErrInternalServerError
+	}
+
+	// quem recebeu o link de redefinição é o dono da conta, o bloqueio causado pela senha antiga deixa de valer
+	if err = au.loginLockoutRepository.Reset(ctx, user.ID); err != nil {
+		return domain.ErrInternalServerError
+	}
+
+	// send email with the password reset confirmation, the password is already changed so a delivery failure is not reported
+	au.mailer.Send(ctx, &domain.MailMessage{
+		To:      []string{user.Email},
+		Subject: "Senha redefinida",
+		Body:    "A sua senha foi redefinida com sucesso. Se você não reconhece esta alteração, entre em contato com o suporte.\n",
+	})
+
 	return nil
 }
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/metrics"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	testAccessSecret  = "access-secret"
+	testRefreshSecret = "refresh-secret"
+	testPassword      = "correct-password"
+)
+
+func newTestAuthUsecase(db *gorm.DB, lockoutPolicy domain.LoginLockoutPolicy) *AuthUsecase {
+	return NewAuthUsecase(
+		repository.NewUserRepository(db),
+		repository.NewUserLogRepository(db),
+		repository.NewRefreshTokenRepository(db),
+		repository.NewPasswordResetTokenRepository(db),
+		repository.NewLoginLockoutRepository(db),
+		lockoutPolicy,
+		nil,
+		metrics.New(),
+		testTimeout,
+	)
+}
+
+func login(ctx context.Context, au *AuthUsecase, email string, rawPassword string) (*domain.LoginResponse, error) {
+	return au.LoginUserByEmail(ctx, email, rawPassword, "127.0.0.1", testAccessSecret, 1, testRefreshSecret, 1)
+}
+
+func refresh(ctx context.Context, au *AuthUsecase, rawRefreshToken string) (*domain.RefreshTokenResponse, error) {
+	return au.RefreshToken(ctx, rawRefreshToken, testAccessSecret, 1, testRefreshSecret, 1)
+}
+
+func TestRefreshTokenReplay(t *testing.T) {
+	tests := []struct {
+		name             string
+		present          func(first string, second string) string
+		wantErr          error
+		wantSuccessorErr error // erro ao apresentar depois o token emitido pelo primeiro refresh
+	}{
+		{
+			name:             "current token",
+			present:          func(first string, second string) string { return second },
+			wantSuccessorErr: domain.ErrRefreshTokenReused,
+		},
+		{
+			name:             "rotated token replayed",
+			present:          func(first string, second string) string { return first },
+			wantErr:          domain.ErrRefreshTokenReused,
+			wantSuccessorErr: domain.ErrInvalidRefreshToken,
+		},
+		{
+			name:    "malformed token",
+			present: func(first string, second string) string { return "not-a-token" },
+			wantErr: domain.ErrInvalidRefreshToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := newTestDB(t)
+			organization := seedOrganization(t, db, "Org")
+			role := seedUserRole(t, db, "Basic")
+			seedUser(t, db, "user@org.test", testPassword, organization.ID, role.ID)
+			au := newTestAuthUsecase(db, domain.LoginLockoutPolicy{})
+
+			loginResponse, err := login(ctx, au, "user@org.test", testPassword)
+			if err != nil {
+				t.Fatalf("login: %v", err)
+			}
+			refreshed, err := refresh(ctx, au, loginResponse.RefreshToken)
+			if err != nil {
+				t.Fatalf("first refresh: %v", err)
+			}
+
+			if _, err := refresh(ctx, au, tt.present(loginResponse.RefreshToken, refreshed.RefreshToken)); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("refresh error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := refresh(ctx, au, refreshed.RefreshToken); !errors.Is(err, tt.wantSuccessorErr) {
+				t.Errorf("refresh with the successor error = %v, want %v", err, tt.wantSuccessorErr)
+			}
+
+			if errors.Is(tt.wantErr, domain.ErrRefreshTokenReused) || errors.Is(tt.wantSuccessorErr, domain.ErrRefreshTokenReused) {
+				var active int64
+				if err := db.Model(&domain.RefreshToken{}).Where("revoked_at IS NULL").Count(&active).Error; err != nil {
+					t.Fatalf("count refresh tokens: %v", err)
+				}
+				if active != 0 {
+					t.Errorf("%d refresh tokens still active after a replay, want the whole family revoked", active)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	policy := domain.LoginLockoutPolicy{Threshold: 3, Duration: time.Minute, MaxDuration: 3 * time.Minute}
+	tests := []struct {
+		name          string
+		policy        domain.LoginLockoutPolicy
+		failures      int
+		expireLock    bool // uma falha a mais depois que o bloqueio anterior expirou
+		wantLocked    bool
+		wantLockFor   time.Duration
+		wantRemaining int // falhas gravadas depois do login com a senha correta
+	}{
+		{name: "below the threshold", policy: policy, failures: 2},
+		{name: "at the threshold", policy: policy, failures: 3, wantLocked: true, wantLockFor: time.Minute, wantRemaining: 3},
+		{name: "failure after the lock expired doubles it", policy: policy, failures: 3, expireLock: true, wantLocked: true, wantLockFor: 2 * time.Minute, wantRemaining: 4},
+		{name: "lockout disabled", policy: domain.LoginLockoutPolicy{}, failures: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := newTestDB(t)
+			organization := seedOrganization(t, db, "Org")
+			role := seedUserRole(t, db, "Basic")
+			user := seedUser(t, db, "user@org.test", testPassword, organization.ID, role.ID)
+			au := newTestAuthUsecase(db, tt.policy)
+
+			for i := 0; i < tt.failures; i++ {
+				if _, err := login(ctx, au, user.Email, "wrong-password"); !errors.Is(err, domain.ErrUserPasswordNotMatch) {
+					t.Fatalf("failure %d error = %v, want %v", i+1, err, domain.ErrUserPasswordNotMatch)
+				}
+			}
+			if tt.expireLock {
+				execSQL(t, db, "UPDATE login_lockouts SET locked_until = '2000-01-01 00:00:00'")
+				if _, err := login(ctx, au, user.Email, "wrong-password"); !errors.Is(err, domain.ErrUserPasswordNotMatch) {
+					t.Fatalf("failure after the lock expired error = %v, want %v", err, domain.ErrUserPasswordNotMatch)
+				}
+			}
+
+			_, err := login(ctx, au, user.Email, testPassword)
+			var locked *domain.AccountLockedError
+			if tt.wantLocked {
+				if !errors.As(err, &locked) || !errors.Is(err, domain.ErrAccountLocked) {
+					t.Fatalf("login error = %v, want %v", err, domain.ErrAccountLocked)
+				}
+				if locked.RetryAfter <= tt.wantLockFor-time.Minute/2 || locked.RetryAfter > tt.wantLockFor {
+					t.Errorf("RetryAfter = %s, want about %s", locked.RetryAfter, tt.wantLockFor)
+				}
+			} else if err != nil {
+				t.Fatalf("login error = %v, want nil", err)
+			}
+
+			lockout, err := repository.NewLoginLockoutRepository(db).GetByUserID(ctx, user.ID)
+			if err != nil {
+				t.Fatalf("GetByUserID: %v", err)
+			}
+			if lockout.FailedAttempts != tt.wantRemaining {
+				t.Errorf("FailedAttempts = %d, want %d", lockout.FailedAttempts, tt.wantRemaining)
+			}
+		})
+	}
+}
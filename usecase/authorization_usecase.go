@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+)
+
+// roles with this name always keep role:manage, so an edit can't lock every administrator out
+const adminRoleName = "Admin"
+
+type authorizationUsecase struct {
+	permissionRepository       domain.PermissionRepository
+	userRoleRepository         domain.UserRoleRepository
+	organizationRoleRepository domain.OrganizationRoleRepository
+	auditUsecase               domain.AuditUsecase
+	contextTimeout             time.Duration
+}
+
+// NewAuthorizationUsecase cria um novo caso de uso para permissões e papéis
+func NewAuthorizationUsecase(permissionRepository domain.PermissionRepository, userRoleRepository domain.UserRoleRepository, organizationRoleRepository domain.OrganizationRoleRepository, auditUsecase domain.AuditUsecase, timeout time.Duration) domain.AuthorizationUsecase {
+	return &authorizationUsecase{
+		permissionRepository:       permissionRepository,
+		userRoleRepository:         userRoleRepository,
+		organizationRoleRepository: organizationRoleRepository,
+		auditUsecase:               auditUsecase,
+		contextTimeout:             timeout,
+	}
+}
+
+// GetPrincipalPermissions resolve as permissões efetivas de quem fez a requisição.
+// Usuários recebem a interseção entre UserRole e OrganizationRole; contas de serviço
+// recebem os seus escopos limitados pelo OrganizationRole da sua organização
+func (au *authorizationUsecase) GetPrincipalPermissions(ctx context.Context, principal domain.Principal) ([]string, error) {
+	ctx, cancel := withTimeout(ctx, au.contextTimeout)
+	defer cancel()
+
+	if !principal.IsServiceAccount() {
+		permissions, err := au.permissionRepository.GetNamesByUserID(ctx, principal.UserID)
+		if err != nil {
+			return nil, domain.ErrInternalServerError
+		}
+		return permissions, nil
+	}
+
+	organizationPermissions, err := au.permissionRepository.GetNamesByOrganizationID(ctx, principal.OrganizationID)
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+
+	permissions := make([]string, 0, len(principal.Scopes))
+	for _, scope := range principal.Scopes {
+		for _, allowed := range organizationPermissions {
+			if scope == allowed {
+				permissions = append(permissions, scope)
+				break
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// FetchPermissions retorna todas as permissões existentes
+func (au *authorizationUsecase) FetchPermissions(ctx context.Context) ([]domain.PublicPermission, error) {
+	ctx, cancel := withTimeout(ctx, au.contextTimeout)
+	defer cancel()
+
+	permissions, err := au.permissionRepository.Fetch(ctx)
+	if err != nil {
+		if errors.Is(err, domain.ErrDataBaseInternalError) {
+			return nil, domain.ErrDataBaseInternalError
+		}
+		return nil, domain.ErrInternalServerError
+	}
+
+	publicPermissions := make([]domain.PublicPermission, 0, len(permissions))
+	for _, p := range permissions {
+		publicPermissions = append(publicPermissions, parser.ToPublicPermission(p))
+	}
+	return publicPermissions, nil
+}
+
+// FetchUserRoles retorna os UserRoles com as suas permissões
+func (au *authorizationUsecase) FetchUserRoles(ctx context.Context) ([]domain.PublicRole, error) {
+	ctx, cancel := withTimeout(ctx, au.contextTimeout)
+	defer cancel()
+
+	roles, err := au.userRoleRepository.Fetch(ctx)
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+
+	publicRoles := make([]domain.PublicRole, 0, len(roles))
+	for _, r := range roles {
+		publicRoles = append(publicRoles, parser.ToPublicUserRole(r))
+	}
+	return publicRoles, nil
+}
+
+// FetchOrganizationRoles retorna os OrganizationRoles com as suas permissões
+func (au *authorizationUsecase) FetchOrganizationRoles(ctx context.Context) ([]domain.PublicRole, error) {
+	ctx, cancel := withTimeout(ctx, au.contextTimeout)
+	defer cancel()
+
+	roles, err := au.organizationRoleRepository.Fetch(ctx)
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+
+	publicRoles := make([]domain.PublicRole, 0, len(roles))
+	for _, r := range roles {
+		publicRoles = append(publicRoles, parser.ToPublicOrganizationRole(r))
+	}
+	return publicRoles, nil
+}
+
+// SetUserRolePermissions substitui as permissões de um UserRole
+func (au *authorizationUsecase) SetUserRolePermissions(ctx context.Context, userRoleID uint, names []string) (domain.PublicRole, error) {
+	ctx, cancel := withTimeout(ctx, au.contextTimeout)
+	defer cancel()
+
+	role, err := au.userRoleRepository.GetByID(ctx, userRoleID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.PublicRole{}, domain.ErrNotFound
+		}
+		return domain.PublicRole{}, domain.ErrInternalServerError
+	}
+
+	permissions, err := au.resolvePermissions(ctx, role.RoleName, names)
+	if err != nil {
+		return domain.PublicRole{}, err
+	}
+
+	if err := au.userRoleRepository.ReplacePermissions(ctx, userRoleID, permissions); err != nil {
+		return domain.PublicRole{}, domain.ErrInternalServerError
+	}
+
+	before := parser.ToPublicUserRole(role)
+	role.Permissions = permissions
+	au.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionUserRolePermissions,
+		EntityType: domain.UserLogEntityUserRole,
+		EntityID:   auditID(userRoleID),
+		Before:     before,
+		After:      parser.ToPublicUserRole(role),
+	})
+	return parser.ToPublicUserRole(role), nil
+}
+
+// SetOrganizationRolePermissions substitui as permissões de um OrganizationRole
+func (au *authorizationUsecase) SetOrganizationRolePermissions(ctx context.Context, organizationRoleID uint, names []string) (domain.PublicRole, error) {
+	ctx, cancel := withTimeout(ctx, au.contextTimeout)
+	defer cancel()
+
+	role, err := au.organizationRoleRepository.GetByID(ctx, organizationRoleID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.PublicRole{}, domain.ErrNotFound
+		}
+		return domain.PublicRole{}, domain.ErrInternalServerError
+	}
+
+	permissions, err := au.resolvePermissions(ctx, role.RoleName, names)
+	if err != nil {
+		return domain.PublicRole{}, err
+	}
+
+	if err := au.organizationRoleRepository.ReplacePermissions(ctx, organizationRoleID, permissions); err != nil {
+		return domain.PublicRole{}, domain.ErrInternalServerError
+	}
+
+	before := parser.ToPublicOrganizationRole(role)
+	role.Permissions = permissions
+	au.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionOrganizationRolePermissions,
+		EntityType: domain.UserLogEntityOrganizationRole,
+		EntityID:   auditID(organizationRoleID),
+		Before:     before,
+		After:      parser.ToPublicOrganizationRole(role),
+	})
+	return parser.ToPublicOrganizationRole(role), nil
+}
+
+// resolvePermissions converte nomes em permissões, rejeitando nomes desconhecidos
+func (au *authorizationUsecase) resolvePermissions(ctx context.Context, roleName string, names []string) ([]domain.Permission, error) {
+	unique := make([]string, 0, len(names)+1)
+	seen := make(map[string]bool, len(names)+1)
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	if roleName == adminRoleName && !seen[domain.PermRoleManage] {
+		unique = append(unique, domain.PermRoleManage)
+	}
+
+	permissions, err := au.permissionRepository.GetByNames(ctx, unique)
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+	if len(permissions) != len(unique) {
+		return nil, domain.ErrUnknownPermission
+	}
+	return permissions, nil
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/export"
+)
+
+type exportUsecase struct {
+	userServiceLogRepository domain.UserServiceLogRepository
+	userLogRepository        domain.UserLogRepository
+}
+
+// NewExportUsecase cria um novo caso de uso para as exportações em planilha. As exportações não usam o
+// CONTEXT_TIMEOUT das demais consultas, elas duram enquanto o cliente estiver recebendo as linhas
+func NewExportUsecase(userServiceLogRepository domain.UserServiceLogRepository, userLogRepository domain.UserLogRepository) domain.ExportUsecase {
+	return &exportUsecase{
+		userServiceLogRepository: userServiceLogRepository,
+		userLogRepository:        userLogRepository,
+	}
+}
+
+// ExportUsage grava as sessões de uso dos serviços
+func (eu *exportUsecase) ExportUsage(ctx context.Context, filter domain.ExportFilter, format string, w io.Writer) error {
+	writer, err := export.NewWriter(format, w, "Uso dos serviços")
+	if err != nil {
+		return err
+	}
+
+	if err := writer.WriteRow("ID", "Usuário ID", "Usuário", "Organização ID", "Organização", "Serviço ID", "Serviço", "Status", "Início (UTC)", "Fim (UTC)", "Duração (s)"); err != nil {
+		return domain.ErrInternalServerError
+	}
+	err = eu.userServiceLogRepository.StreamForExport(ctx, filter, func(row domain.UsageExportRow) error {
+		return writer.WriteRow(row.LogID, row.UserID, row.UserEmail, row.OrganizationID, row.OrganizationName,
+			row.ServiceID, row.ServiceName, row.Status, row.StartedAt, row.EndedAt, row.Duration)
+	})
+	if err != nil {
+		return mapOrganizationError(err)
+	}
+	return writer.Close()
+}
+
+// ExportLogins grava o histórico de logins
+func (eu *exportUsecase) ExportLogins(ctx context.Context, filter domain.ExportFilter, format string, w io.Writer) error {
+	writer, err := export.NewWriter(format, w, "Logins")
+	if err != nil {
+		return err
+	}
+
+	if err := writer.WriteRow("ID", "Usuário ID", "Usuário", "Organização ID", "Organização", "Ação", "IP", "Data (UTC)"); err != nil {
+		return domain.ErrInternalServerError
+	}
+	err = eu.userLogRepository.StreamForExport(ctx, filter, func(row domain.LoginExportRow) error {
+		return writer.WriteRow(row.LogID, row.UserID, row.UserEmail, row.OrganizationID, row.OrganizationName,
+			row.Action, row.IPAddress, row.CreatedAt)
+	})
+	if err != nil {
+		return mapOrganizationError(err)
+	}
+	return writer.Close()
+}
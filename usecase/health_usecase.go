@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+type healthUsecase struct {
+	healthRepository domain.HealthRepository
+	latestMigration  int64
+	shutdown         <-chan struct{}
+	contextTimeout   time.Duration
+}
+
+// NewHealthUsecase cria um novo caso de uso para as verificações de saúde. latestMigration é a última migration
+// conhecida pelo binário e shutdown é fechado quando a instância começa a encerrar
+func NewHealthUsecase(healthRepository domain.HealthRepository, latestMigration int64, shutdown <-chan struct{}, timeout time.Duration) domain.HealthUsecase {
+	return &healthUsecase{
+		healthRepository: healthRepository,
+		latestMigration:  latestMigration,
+		shutdown:         shutdown,
+		contextTimeout:   timeout,
+	}
+}
+
+// Readiness verifica se a instância pode receber tráfego: não está encerrando, o banco responde e o schema
+// tem todas as migrations do binário. Os erros vão para o log, a resposta é pública e não os expõe
+func (hu *healthUsecase) Readiness(c context.Context) domain.Readiness {
+	ctx, cancel := withTimeout(c, hu.contextTimeout)
+	defer cancel()
+
+	checks := map[string]domain.HealthCheck{
+		"shutdown":   {Status: domain.HealthStatusOK},
+		"database":   {Status: domain.HealthStatusOK},
+		"migrations": {Status: domain.HealthStatusOK},
+	}
+	select {
+	case <-hu.shutdown:
+		checks["shutdown"] = domain.HealthCheck{Status: domain.HealthStatusUnavailable, Detail: "shutting down"}
+	default:
+	}
+
+	if err := hu.healthRepository.Ping(ctx); err != nil {
+		slog.ErrorContext(ctx, "readiness: database unreachable", "error", err)
+		checks["database"] = domain.HealthCheck{Status: domain.HealthStatusUnavailable, Detail: "database unreachable"}
+		checks["migrations"] = domain.HealthCheck{Status: domain.HealthStatusUnavailable, Detail: "database unreachable"}
+		return newReadiness(checks)
+	}
+
+	// um schema à frente do binário é aceito: durante um deploy as instâncias antigas seguem atendendo
+	// depois que a nova aplicou as suas migrations
+	version, err := hu.healthRepository.SchemaVersion(ctx)
+	switch {
+	case err != nil:
+		slog.ErrorContext(ctx, "readiness: schema version unavailable", "error", err)
+		checks["migrations"] = domain.HealthCheck{Status: domain.HealthStatusUnavailable, Detail: "schema version unavailable"}
+	case version < hu.latestMigration:
+		checks["migrations"] = domain.HealthCheck{Status: domain.HealthStatusUnavailable, Detail: fmt.Sprintf("schema at version %d, expected %d", version, hu.latestMigration)}
+	default:
+		checks["migrations"] = domain.HealthCheck{Status: domain.HealthStatusOK, Detail: fmt.Sprintf("version %d", version)}
+	}
+	return newReadiness(checks)
+}
+
+func newReadiness(checks map[string]domain.HealthCheck) domain.Readiness {
+	readiness := domain.Readiness{Status: domain.HealthStatusOK, Checks: checks}
+	for _, check := range checks {
+		if check.Status != domain.HealthStatusOK {
+			readiness.Status = domain.HealthStatusUnavailable
+		}
+	}
+	return readiness
+}
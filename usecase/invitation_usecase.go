@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gabrielfmcoelho/platform-core/internal/password"
+	"github.com/gabrielfmcoelho/platform-core/internal/tokenutil"
+)
+
+type invitationUsecase struct {
+	invitationRepository   domain.InvitationRepository
+	organizationRepository domain.OrganizationRepository
+	userRoleRepository     domain.UserRoleRepository
+	userRepository         domain.UserRepository
+	subscriptionRepository domain.OrganizationSubscriptionRepository
+	mailer                 domain.Mailer
+	contextTimeout         time.Duration
+}
+
+// NewInvitationUsecase cria um novo caso de uso para convites de organização
+func NewInvitationUsecase(invitationRepository domain.InvitationRepository, organizationRepository domain.OrganizationRepository, userRoleRepository domain.UserRoleRepository, userRepository domain.UserRepository, subscriptionRepository domain.OrganizationSubscriptionRepository, mailer domain.Mailer, timeout time.Duration) domain.InvitationUsecase {
+	return &invitationUsecase{
+		invitationRepository:   invitationRepository,
+		organizationRepository: organizationRepository,
+		userRoleRepository:     userRoleRepository,
+		userRepository:         userRepository,
+		subscriptionRepository: subscriptionRepository,
+		mailer:                 mailer,
+		contextTimeout:         timeout,
+	}
+}
+
+// Create convida um email para a organização com o UserRole escolhido e envia o link de cadastro.
+// Quem convida não pode conceder permissões que não possui e a organização precisa ter vagas na assinatura
+func (iu *invitationUsecase) Create(c context.Context, organizationID uint, createInvitation *domain.CreateInvitation, inviteURL string, expiryHours int) (domain.PublicInvitation, error) {
+	ctx, cancel := withTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	email := strings.ToLower(strings.TrimSpace(createInvitation.Email))
+
+	organization, err := iu.organizationRepository.GetByID(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.PublicInvitation{}, domain.ErrNotFound
+		}
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	}
+
+	role, err := iu.userRoleRepository.GetByID(ctx, createInvitation.RoleID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.PublicInvitation{}, domain.ErrInvalidUserRole
+		}
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	}
+
+	principal, hasPrincipal := domain.PrincipalFromContext(ctx)
+	if hasPrincipal && !principal.IsPlatformAdmin() {
+		for _, permission := range role.Permissions {
+			if !principal.HasPermission(permission.Name) {
+				return domain.PublicInvitation{}, domain.ErrForbidden
+			}
+		}
+	}
+
+	if exists, err := iu.userRepository.ExistsByEmail(ctx, email); err != nil {
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	} else if exists {
+		return domain.PublicInvitation{}, domain.ErrUserAlreadyExists
+	}
+
+	if _, err := iu.invitationRepository.GetPendingByEmail(ctx, organizationID, email); err == nil {
+		return domain.PublicInvitation{}, domain.ErrInvitationExists
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	}
+
+	if err := checkUsersLimit(ctx, iu.subscriptionRepository, iu.userRepository, organization.ID); err != nil {
+		return domain.PublicInvitation{}, err
+	}
+
+	rawToken, err := tokenutil.GenerateOpaqueToken()
+	if err != nil {
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	}
+
+	invitation := domain.Invitation{
+		Email:          email,
+		OrganizationID: organization.ID,
+		RoleID:         role.ID,
+		InvitedByID:    principal.UserID,
+		TokenHash:      tokenutil.HashOpaqueToken(rawToken),
+		ExpiresAt:      time.Now().Add(time.Hour * time.Duration(expiryHours)),
+	}
+	if err := iu.invitationRepository.Create(ctx, &invitation); err != nil {
+		if errors.Is(err, domain.ErrOutOfOrganization) {
+			return domain.PublicInvitation{}, domain.ErrOutOfOrganization
+		}
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	}
+	invitation.Organization = organization
+	invitation.Role = role
+
+	if err := iu.sendInvitation(ctx, invitation, rawToken, inviteURL); err != nil {
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	}
+
+	return parser.ToPublicInvitation(invitation), nil
+}
+
+// FetchPending retorna os convites pendentes da organização
+func (iu *invitationUsecase) FetchPending(c context.Context, organizationID uint) ([]domain.PublicInvitation, error) {
+	ctx, cancel := withTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	if _, err := iu.organizationRepository.GetByID(ctx, organizationID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, domain.ErrInternalServerError
+	}
+
+	invitations, err := iu.invitationRepository.FetchPendingByOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+
+	publicInvitations := make([]domain.PublicInvitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		publicInvitations = append(publicInvitations, parser.ToPublicInvitation(invitation))
+	}
+	return publicInvitations, nil
+}
+
+// Resend gera um novo link para o convite (o anterior deixa de funcionar) e renova a sua validade,
+// convites expirados também podem ser reenviados
+func (iu *invitationUsecase) Resend(c context.Context, invitationID uint, inviteURL string, expiryHours int) (domain.PublicInvitation, error) {
+	ctx, cancel := withTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	invitation, err := iu.invitationRepository.GetByID(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.PublicInvitation{}, domain.ErrNotFound
+		}
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	}
+
+	if status := invitation.Status(); status == domain.InvitationStatusAccepted || status == domain.InvitationStatusRevoked {
+		return domain.PublicInvitation{}, domain.ErrInvalidInvitation
+	}
+
+	rawToken, err := tokenutil.GenerateOpaqueToken()
+	if err != nil {
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	}
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	invitation.InvitedByID = principal.UserID
+	invitation.TokenHash = tokenutil.HashOpaqueToken(rawToken)
+	invitation.ExpiresAt = time.Now().Add(time.Hour * time.Duration(expiryHours))
+
+	err = iu.invitationRepository.RenewToken(ctx, invitation.ID, invitation.InvitedByID, invitation.TokenHash, invitation.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.PublicInvitation{}, domain.ErrInvalidInvitation
+		}
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	}
+
+	if err := iu.sendInvitation(ctx, invitation, rawToken, inviteURL); err != nil {
+		return domain.PublicInvitation{}, domain.ErrInternalServerError
+	}
+
+	return parser.ToPublicInvitation(invitation), nil
+}
+
+// Revoke cancela um convite que ainda não foi aceito
+func (iu *invitationUsecase) Revoke(c context.Context, invitationID uint) error {
+	ctx, cancel := withTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	if err := iu.invitationRepository.Revoke(ctx, invitationID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return domain.ErrInternalServerError
+	}
+	return nil
+}
+
+// Accept consome o convite e cria o usuário na organização e com o UserRole do convite
+func (iu *invitationUsecase) Accept(c context.Context, request *domain.AcceptInvitationRequest) (domain.PublicUser, error) {
+	ctx, cancel := withTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	invitation, err := iu.invitationRepository.GetByTokenHash(ctx, tokenutil.HashOpaqueToken(request.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.PublicUser{}, domain.ErrInvalidInvitation
+		}
+		return domain.PublicUser{}, domain.ErrInternalServerError
+	}
+
+	if invitation.Status() != domain.InvitationStatusPending {
+		return domain.PublicUser{}, domain.ErrInvalidInvitation
+	}
+
+	if exists, err := iu.userRepository.ExistsByEmail(ctx, invitation.Email); err != nil {
+		return domain.PublicUser{}, domain.ErrInternalServerError
+	} else if exists {
+		return domain.PublicUser{}, domain.ErrUserAlreadyExists
+	}
+
+	if err := checkUsersLimit(ctx, iu.subscriptionRepository, iu.userRepository, invitation.OrganizationID); err != nil {
+		return domain.PublicUser{}, err
+	}
+
+	hashedPassword, err := password.HashPassword(request.Password)
+	if err != nil {
+		return domain.PublicUser{}, domain.ErrInternalServerError
+	}
+
+	user := domain.User{
+		Email:          invitation.Email,
+		Password:       hashedPassword,
+		OrganizationID: invitation.OrganizationID,
+		RoleID:         invitation.RoleID,
+		Bio: domain.UserBio{
+			FirstName: request.FirstName,
+			SurName:   request.SurName,
+			Position:  request.Position,
+			Phone:     request.Phone,
+		},
+	}
+	if err := iu.invitationRepository.Accept(ctx, invitation.ID, &user); err != nil {
+		if errors.Is(err, domain.ErrInvalidInvitation) {
+			return domain.PublicUser{}, domain.ErrInvalidInvitation
+		}
+		return domain.PublicUser{}, domain.ErrInternalServerError
+	}
+
+	user.Organization = invitation.Organization
+	user.Role = invitation.Role
+	return parser.ToPublicUser(user), nil
+}
+
+// sendInvitation envia o email com o link de cadastro
+func (iu *invitationUsecase) sendInvitation(ctx context.Context, invitation domain.Invitation, rawToken string, inviteURL string) error {
+	link := fmt.Sprintf("%s?token=%s", inviteURL, url.QueryEscape(rawToken))
+	return iu.mailer.Send(ctx, &domain.MailMessage{
+		To:      []string{invitation.Email},
+		Subject: fmt.Sprintf("Convite para %s", invitation.Organization.Name),
+		Body: fmt.Sprintf(
+			"Você foi convidado(a) para acessar a plataforma como membro de %s.\n\nAcesse o link abaixo até %s para concluir o seu cadastro:\n%s\n\nSe você não esperava este convite, ignore este email.\n",
+			invitation.Organization.Name, invitation.ExpiresAt.Format("02/01/2006 15:04"), link,
+		),
+	})
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/invoicerender"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+)
+
+type invoiceUsecase struct {
+	invoiceRepository        domain.InvoiceRepository
+	organizationRepository   domain.OrganizationRepository
+	serviceRepository        domain.ServiceRepository
+	userServiceLogRepository domain.UserServiceLogRepository
+	contextTimeout           time.Duration
+}
+
+// NewInvoiceUsecase cria um novo caso de uso para o faturamento das organizações
+func NewInvoiceUsecase(invoiceRepository domain.InvoiceRepository, organizationRepository domain.OrganizationRepository, serviceRepository domain.ServiceRepository, userServiceLogRepository domain.UserServiceLogRepository, timeout time.Duration) domain.InvoiceUsecase {
+	return &invoiceUsecase{
+		invoiceRepository:        invoiceRepository,
+		organizationRepository:   organizationRepository,
+		serviceRepository:        serviceRepository,
+		userServiceLogRepository: userServiceLogRepository,
+		contextTimeout:           timeout,
+	}
+}
+
+// Generate gera os rascunhos das faturas do mês para uma organização ou para todas. Rascunhos existentes são
+// recalculados, faturas já emitidas não são alteradas (gerar todas as organizações apenas as ignora)
+func (iu *invoiceUsecase) Generate(c context.Context, request *domain.GenerateInvoices) ([]domain.PublicInvoice, error) {
+	ctx, cancel := withTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	periodStart, err := time.ParseInLocation("2006-01", request.Month, time.UTC)
+	if err != nil || periodStart.After(time.Now()) {
+		return nil, domain.ErrInvalidBillingPeriod
+	}
+	periodEnd := periodStart.AddDate(0, 1, 0)
+
+	var organizations []domain.Organization
+	if request.OrganizationID != nil {
+		organization, err := iu.organizationRepository.GetByID(ctx, *request.OrganizationID)
+		if err != nil {
+			return nil, mapInvoiceError(err)
+		}
+		organizations = append(organizations, organization)
+	} else {
+		organizations, err = iu.organizationRepository.Fetch(ctx)
+		if err != nil {
+			return nil, mapInvoiceError(err)
+		}
+	}
+
+	invoices := make([]domain.PublicInvoice, 0, len(organizations))
+	for _, organization := range organizations {
+		existing, err := iu.invoiceRepository.GetByPeriod(ctx, organization.ID, periodStart)
+		switch {
+		case err == nil && existing.Status == domain.InvoiceStatusDraft:
+			if err := iu.invoiceRepository.DeleteDraft(ctx, existing.ID); err != nil {
+				return nil, mapInvoiceError(err)
+			}
+		case err == nil && request.OrganizationID != nil:
+			return nil, domain.ErrInvoiceExists
+		case err == nil:
+			continue
+		case !errors.Is(err, domain.ErrNotFound):
+			return nil, mapInvoiceError(err)
+		}
+
+		invoice, err := iu.buildInvoice(ctx, organization, periodStart, periodEnd)
+		if err != nil {
+			return nil, err
+		}
+		if len(invoice.Items) == 0 && request.OrganizationID == nil {
+			continue
+		}
+
+		if err := iu.invoiceRepository.Create(ctx, &invoice); err != nil {
+			return nil, mapInvoiceError(err)
+		}
+		invoice.Organization = organization
+		invoices = append(invoices, parser.ToPublicInvoice(invoice))
+	}
+	return invoices, nil
+}
+
+// buildInvoice calcula um item por serviço vinculado à organização: o preço fixo do serviço mais,
+// quando o serviço possui preço por hora, as horas registradas pelos usuários da organização no período
+func (iu *invoiceUsecase) buildInvoice(ctx context.Context, organization domain.Organization, periodStart time.Time, periodEnd time.Time) (domain.Invoice, error) {
+	services, err := iu.serviceRepository.GetByOrganization(ctx, organization.ID)
+	if err != nil {
+		return domain.Invoice{}, mapInvoiceError(err)
+	}
+
+	usage, err := iu.userServiceLogRepository.SumDurationByOrganization(ctx, organization.ID, periodStart, periodEnd)
+	if err != nil {
+		return domain.Invoice{}, mapInvoiceError(err)
+	}
+
+	invoice := domain.Invoice{
+		OrganizationID: organization.ID,
+		Status:         domain.InvoiceStatusDraft,
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+	}
+	for _, service := range services {
+		item := domain.InvoiceItem{
+			ServiceID:   service.ID,
+			Description: service.Name,
+			FlatPrice:   service.Price,
+			HourlyPrice: service.HourlyPrice,
+		}
+		if service.HourlyPrice > 0 {
+			item.UsageSeconds = usage[service.ID]
+			item.UsageAmount = roundCents(float64(item.UsageSeconds) / 3600 * service.HourlyPrice)
+		}
+		item.Amount = roundCents(item.FlatPrice + item.UsageAmount)
+		invoice.Total = roundCents(invoice.Total + item.Amount)
+		invoice.Items = append(invoice.Items, item)
+	}
+	return invoice, nil
+}
+
+// Fetch retorna as faturas visíveis para quem fez a requisição
+func (iu *invoiceUsecase) Fetch(c context.Context, filter domain.InvoiceFilter) ([]domain.PublicInvoice, error) {
+	ctx, cancel := withTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	invoices, err := iu.invoiceRepository.Fetch(ctx, filter)
+	if err != nil {
+		return nil, mapInvoiceError(err)
+	}
+
+	publicInvoices := make([]domain.PublicInvoice, 0, len(invoices))
+	for _, invoice := range invoices {
+		publicInvoices = append(publicInvoices, parser.ToPublicInvoice(invoice))
+	}
+	return publicInvoices, nil
+}
+
+// GetByID retorna uma fatura com os seus itens
+func (iu *invoiceUsecase) GetByID(c context.Context, id uint) (domain.PublicInvoice, error) {
+	ctx, cancel := withTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	invoice, err := iu.invoiceRepository.GetByID(ctx, id)
+	if err != nil {
+		return domain.PublicInvoice{}, mapInvoiceError(err)
+	}
+	return parser.ToPublicInvoice(invoice), nil
+}
+
+// Issue emite um rascunho, a fatura recebe um número e não é mais recalculada
+func (iu *invoiceUsecase) Issue(c context.Context, id uint) (domain.PublicInvoice, error) {
+	return iu.changeStatus(c, id, func(invoice *domain.Invoice, now time.Time) {
+		number := fmt.Sprintf("%d-%06d", now.Year(), invoice.ID)
+		invoice.Number = &number
+		invoice.IssuedAt = &now
+	}, domain.InvoiceStatusIssued, domain.InvoiceStatusDraft)
+}
+
+// Pay registra o pagamento de uma fatura emitida
+func (iu *invoiceUsecase) Pay(c context.Context, id uint) (domain.PublicInvoice, error) {
+	return iu.changeStatus(c, id, func(invoice *domain.Invoice, now time.Time) {
+		invoice.PaidAt = &now
+	}, domain.InvoiceStatusPaid, domain.InvoiceStatusIssued)
+}
+
+// Void cancela um rascunho ou uma fatura emitida, o período pode então ser gerado novamente
+func (iu *invoiceUsecase) Void(c context.Context, id uint) (domain.PublicInvoice, error) {
+	return iu.changeStatus(c, id, func(invoice *domain.Invoice, now time.Time) {
+		invoice.VoidedAt = &now
+	}, domain.InvoiceStatusVoid, domain.InvoiceStatusDraft, domain.InvoiceStatusIssued)
+}
+
+// changeStatus aplica uma transição do ciclo de vida da fatura, permitida apenas a partir dos status em from
+func (iu *invoiceUsecase) changeStatus(c context.Context, id uint, apply func(invoice *domain.Invoice, now time.Time), to string, from ...string) (domain.PublicInvoice, error) {
+	ctx, cancel := withTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	invoice, err := iu.invoiceRepository.GetByID(ctx, id)
+	if err != nil {
+		return domain.PublicInvoice{}, mapInvoiceError(err)
+	}
+
+	allowed := false
+	for _, status := range from {
+		if invoice.Status == status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return domain.PublicInvoice{}, domain.ErrInvalidInvoiceStatus
+	}
+
+	apply(&invoice, time.Now())
+	invoice.Status = to
+	if err := iu.invoiceRepository.UpdateStatus(ctx, &invoice, from...); err != nil {
+		return domain.PublicInvoice{}, mapInvoiceError(err)
+	}
+	return parser.ToPublicInvoice(invoice), nil
+}
+
+// Render gera a fatura em HTML ou PDF localmente
+func (iu *invoiceUsecase) Render(c context.Context, id uint, format string) ([]byte, error) {
+	ctx, cancel := withTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	if format != domain.InvoiceFormatHTML && format != domain.InvoiceFormatPDF {
+		return nil, domain.ErrInvalidInvoiceFormat
+	}
+
+	invoice, err := iu.invoiceRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, mapInvoiceError(err)
+	}
+
+	var document []byte
+	if format == domain.InvoiceFormatPDF {
+		document, err = invoicerender.PDF(parser.ToPublicInvoice(invoice))
+	} else {
+		document, err = invoicerender.HTML(parser.ToPublicInvoice(invoice))
+	}
+	if err != nil {
+		return nil, domain.ErrInternalServerError
+	}
+	return document, nil
+}
+
+// roundCents arredonda um valor monetário para centavos
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// mapInvoiceError mantém os erros de domínio conhecidos e esconde os demais
+func mapInvoiceError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInvoiceStatus):
+		return domain.ErrInvalidInvoiceStatus
+	default:
+		return mapOrganizationError(err)
+	}
+}
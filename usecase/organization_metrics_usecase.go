@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+)
+
+type organizationMetricsUsecase struct {
+	organizationMetricsRepository domain.OrganizationMetricsRepository
+	organizationRepository        domain.OrganizationRepository
+	contextTimeout                time.Duration
+}
+
+// NewOrganizationMetricsUsecase cria um novo caso de uso para as métricas de organização
+func NewOrganizationMetricsUsecase(organizationMetricsRepository domain.OrganizationMetricsRepository, organizationRepository domain.OrganizationRepository, timeout time.Duration) domain.OrganizationMetricsUsecase {
+	return &organizationMetricsUsecase{
+		organizationMetricsRepository: organizationMetricsRepository,
+		organizationRepository:        organizationRepository,
+		contextTimeout:                timeout,
+	}
+}
+
+// GetByOrganizationID retorna as métricas consolidadas de uma organização. Uma organização que ainda não
+// passou pelo job de métricas recebe as métricas zeradas
+func (mu *organizationMetricsUsecase) GetByOrganizationID(c context.Context, organizationID uint) (domain.PublicOrganizationMetrics, error) {
+	ctx, cancel := withTimeout(c, mu.contextTimeout)
+	defer cancel()
+
+	if _, err := mu.organizationRepository.GetByID(ctx, organizationID); err != nil {
+		return domain.PublicOrganizationMetrics{}, mapOrganizationError(err)
+	}
+
+	metrics, err := mu.organizationMetricsRepository.GetByOrganizationID(ctx, organizationID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.PublicOrganizationMetrics{OrganizationID: organizationID}, nil
+	}
+	if err != nil {
+		return domain.PublicOrganizationMetrics{}, mapOrganizationError(err)
+	}
+	return parser.ToPublicOrganizationMetrics(metrics), nil
+}
+
+// Recompute consolida as métricas de todas as organizações a partir dos membros e dos serviços vinculados
+func (mu *organizationMetricsUsecase) Recompute(c context.Context) (int, error) {
+	ctx, cancel := withTimeout(c, mu.contextTimeout)
+	defer cancel()
+
+	updated, err := mu.organizationMetricsRepository.Recompute(ctx)
+	if err != nil {
+		return 0, mapOrganizationError(err)
+	}
+	return updated, nil
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+)
+
+type organizationSubscriptionUsecase struct {
+	organizationSubscriptionRepository domain.OrganizationSubscriptionRepository
+	organizationRepository             domain.OrganizationRepository
+	contextTimeout                     time.Duration
+}
+
+// NewOrganizationSubscriptionUsecase cria um novo caso de uso para assinaturas de organização
+func NewOrganizationSubscriptionUsecase(organizationSubscriptionRepository domain.OrganizationSubscriptionRepository, organizationRepository domain.OrganizationRepository, timeout time.Duration) domain.OrganizationSubscriptionUsecase {
+	return &organizationSubscriptionUsecase{
+		organizationSubscriptionRepository: organizationSubscriptionRepository,
+		organizationRepository:             organizationRepository,
+		contextTimeout:                     timeout,
+	}
+}
+
+// Create registra a assinatura de uma organização, o fim é calculado a partir do início e do período.
+// Cada organização possui uma única assinatura, que depois é renovada ou cancelada
+func (su *organizationSubscriptionUsecase) Create(c context.Context, organizationID uint, createSubscription *domain.CreateSubscription) (domain.PublicSubscription, error) {
+	ctx, cancel := withTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	if _, err := su.organizationRepository.GetByID(ctx, organizationID); err != nil {
+		return domain.PublicSubscription{}, mapOrganizationError(err)
+	}
+
+	if _, err := su.organizationSubscriptionRepository.GetByOrganizationID(ctx, organizationID); err == nil {
+		return domain.PublicSubscription{}, domain.ErrSubscriptionExists
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return domain.PublicSubscription{}, mapOrganizationError(err)
+	}
+
+	initDate := time.Now()
+	if createSubscription.InitDate != nil {
+		initDate = *createSubscription.InitDate
+	}
+
+	subscription := domain.OrganizationSubscription{
+		Active:                   true,
+		OrganizationID:           organizationID,
+		SubscriptionValue:        createSubscription.Value,
+		SubscriptionPeriod:       createSubscription.Period,
+		SubscriptionUsersLimit:   createSubscription.UsersLimit,
+		SubscriptionReportsLimit: createSubscription.ReportsLimit,
+		SubscriptionInitDate:     initDate,
+		SubscriptionEndDate:      domain.SubscriptionPeriodEnd(createSubscription.Period, initDate),
+	}
+	if err := su.organizationSubscriptionRepository.Create(ctx, &subscription); err != nil {
+		return domain.PublicSubscription{}, mapOrganizationError(err)
+	}
+	return parser.ToPublicSubscription(subscription), nil
+}
+
+// Fetch retorna as assinaturas visíveis para quem fez a requisição
+func (su *organizationSubscriptionUsecase) Fetch(c context.Context) ([]domain.PublicSubscription, error) {
+	ctx, cancel := withTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	subscriptions, err := su.organizationSubscriptionRepository.Fetch(ctx)
+	if err != nil {
+		return nil, mapOrganizationError(err)
+	}
+
+	publicSubscriptions := make([]domain.PublicSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		publicSubscriptions = append(publicSubscriptions, parser.ToPublicSubscription(subscription))
+	}
+	return publicSubscriptions, nil
+}
+
+// GetByOrganizationID retorna a assinatura de uma organização
+func (su *organizationSubscriptionUsecase) GetByOrganizationID(c context.Context, organizationID uint) (domain.PublicSubscription, error) {
+	ctx, cancel := withTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	subscription, err := su.organizationSubscriptionRepository.GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return domain.PublicSubscription{}, mapOrganizationError(err)
+	}
+	return parser.ToPublicSubscription(subscription), nil
+}
+
+// Renew estende a assinatura por mais um período e a reativa. Uma assinatura em vigor é estendida a partir
+// do seu fim, uma expirada ou cancelada recomeça agora. Os termos enviados substituem os atuais
+func (su *organizationSubscriptionUsecase) Renew(c context.Context, organizationID uint, renewSubscription *domain.RenewSubscription) (domain.PublicSubscription, error) {
+	ctx, cancel := withTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	subscription, err := su.organizationSubscriptionRepository.GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return domain.PublicSubscription{}, mapOrganizationError(err)
+	}
+
+	if renewSubscription.Value != nil {
+		subscription.SubscriptionValue = *renewSubscription.Value
+	}
+	if renewSubscription.Period != nil {
+		subscription.SubscriptionPeriod = *renewSubscription.Period
+	}
+	if renewSubscription.UsersLimit != nil {
+		subscription.SubscriptionUsersLimit = *renewSubscription.UsersLimit
+	}
+	if renewSubscription.ReportsLimit != nil {
+		subscription.SubscriptionReportsLimit = *renewSubscription.ReportsLimit
+	}
+
+	now := time.Now()
+	if !subscription.IsActive(now) && !now.Before(subscription.SubscriptionInitDate) {
+		subscription.SubscriptionInitDate = now
+		subscription.SubscriptionEndDate = domain.SubscriptionPeriodEnd(subscription.SubscriptionPeriod, now)
+	} else {
+		subscription.SubscriptionEndDate = domain.SubscriptionPeriodEnd(subscription.SubscriptionPeriod, subscription.SubscriptionEndDate)
+	}
+	subscription.Active = true
+	subscription.CancelledAt = nil
+
+	if err := su.organizationSubscriptionRepository.Update(ctx, &subscription); err != nil {
+		return domain.PublicSubscription{}, mapOrganizationError(err)
+	}
+	return parser.ToPublicSubscription(subscription), nil
+}
+
+// Cancel desativa a assinatura imediatamente
+func (su *organizationSubscriptionUsecase) Cancel(c context.Context, organizationID uint) (domain.PublicSubscription, error) {
+	ctx, cancel := withTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	subscription, err := su.organizationSubscriptionRepository.GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return domain.PublicSubscription{}, mapOrganizationError(err)
+	}
+	if subscription.CancelledAt != nil {
+		return domain.PublicSubscription{}, domain.ErrSubscriptionInactive
+	}
+
+	now := time.Now()
+	subscription.Active = false
+	subscription.CancelledAt = &now
+	if err := su.organizationSubscriptionRepository.Update(ctx, &subscription); err != nil {
+		return domain.PublicSubscription{}, mapOrganizationError(err)
+	}
+	return parser.ToPublicSubscription(subscription), nil
+}
+
+// DeactivateExpired desativa as assinaturas cujo período terminou, é executado diariamente (ver job.Setup)
+func (su *organizationSubscriptionUsecase) DeactivateExpired(c context.Context) (int64, error) {
+	ctx, cancel := withTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	deactivated, err := su.organizationSubscriptionRepository.DeactivateExpired(ctx, time.Now())
+	if err != nil {
+		return 0, mapOrganizationError(err)
+	}
+	return deactivated, nil
+}
+
+// checkSubscriptionActive recusa organizações cuja assinatura está inativa ou expirada,
+// organizações sem assinatura não são limitadas
+func checkSubscriptionActive(ctx context.Context, organizationSubscriptionRepository domain.OrganizationSubscriptionRepository, organizationID uint) error {
+	subscription, err := organizationSubscriptionRepository.GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return domain.ErrInternalServerError
+	}
+	if !subscription.IsActive(time.Now()) {
+		return domain.ErrSubscriptionInactive
+	}
+	return nil
+}
+
+// checkUsersLimit recusa um novo usuário quando a organização já atingiu o limite de usuários da assinatura
+func checkUsersLimit(ctx context.Context, organizationSubscriptionRepository domain.OrganizationSubscriptionRepository, userRepository domain.UserRepository, organizationID uint) error {
+	subscription, err := organizationSubscriptionRepository.GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return domain.ErrInternalServerError
+	}
+
+	users, err := userRepository.CountByOrganization(ctx, organizationID)
+	if err != nil {
+		return domain.ErrInternalServerError
+	}
+	if !subscription.HasUsersAvailable(users) {
+		return domain.ErrSubscriptionUsersLimit
+	}
+	return nil
+}
@@ -4,53 +4,80 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gabrielfmcoelho/platform-core/domain"
 	"github.com/gabrielfmcoelho/platform-core/internal/parser"
 )
 
 type organizationUsecase struct {
-	repo domain.OrganizationRepository
+	repo                       domain.OrganizationRepository
+	organizationRoleRepository domain.OrganizationRoleRepository
+	auditUsecase               domain.AuditUsecase
+	contextTimeout             time.Duration
 }
 
 // NewOrganizationUsecase retorna uma instância que implementa a interface OrganizationUsecase
-func NewOrganizationUsecase(repo domain.OrganizationRepository) domain.OrganizationUsecase {
+func NewOrganizationUsecase(repo domain.OrganizationRepository, organizationRoleRepository domain.OrganizationRoleRepository, auditUsecase domain.AuditUsecase, timeout time.Duration) domain.OrganizationUsecase {
 	return &organizationUsecase{
-		repo: repo,
+		repo:                       repo,
+		organizationRoleRepository: organizationRoleRepository,
+		auditUsecase:               auditUsecase,
+		contextTimeout:             timeout,
 	}
 }
 
 // Create cria uma nova organização
-func (uc *organizationUsecase) Create(ctx context.Context, organization *domain.Organization) error {
-	// Poderia validar se a Role existe, etc.
-	if organization.Name == "" {
-		return errors.New("organization name is required")
+func (uc *organizationUsecase) Create(c context.Context, createOrganization *domain.CreateOrganization) (domain.PublicOrganization, error) {
+	ctx, cancel := withTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.repo.GetByName(ctx, createOrganization.Name); err == nil {
+		return domain.PublicOrganization{}, domain.ErrOrganizationExists
+	}
+
+	if _, err := uc.organizationRoleRepository.GetByID(ctx, createOrganization.OrganizationRoleID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.PublicOrganization{}, domain.ErrInvalidOrganization
+		}
+		return domain.PublicOrganization{}, domain.ErrInternalServerError
 	}
 
-	// Criar no repositório
+	organization := parser.ToOrganization(createOrganization)
 	if err := uc.repo.Create(ctx, organization); err != nil {
-		return err
+		return domain.PublicOrganization{}, mapOrganizationError(err)
 	}
-	return nil
+	uc.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionOrganizationCreate,
+		EntityType: domain.UserLogEntityOrganization,
+		EntityID:   auditID(organization.ID),
+		After:      parser.ToPublicOrganization(*organization),
+	})
+	return parser.ToPublicOrganization(*organization), nil
 }
 
 // Fetch retorna todas as organizações, convertendo para PublicOrganization
-func (uc *organizationUsecase) Fetch(ctx context.Context) ([]domain.PublicOrganization, error) {
+func (uc *organizationUsecase) Fetch(c context.Context) ([]domain.PublicOrganization, error) {
+	ctx, cancel := withTimeout(c, uc.contextTimeout)
+	defer cancel()
+
 	orgs, err := uc.repo.Fetch(ctx)
 	if err != nil {
-		return nil, err
+		return nil, mapOrganizationError(err)
 	}
 
-	var result []domain.PublicOrganization
+	result := make([]domain.PublicOrganization, 0, len(orgs))
 	for _, org := range orgs {
 		result = append(result, parser.ToPublicOrganization(org))
 	}
 	return result, nil
 }
 
-// GetByIdentifier busca organização por ID ou Nome (exemplo)
-func (uc *organizationUsecase) GetByIdentifier(ctx context.Context, identifier string) (domain.PublicOrganization, error) {
-	// tentar converter identifier para uint
+// GetByIdentifier busca organização por ID ou Nome
+func (uc *organizationUsecase) GetByIdentifier(c context.Context, identifier string) (domain.PublicOrganization, error) {
+	ctx, cancel := withTimeout(c, uc.contextTimeout)
+	defer cancel()
+
 	var org domain.Organization
 	var err error
 
@@ -63,43 +90,109 @@ func (uc *organizationUsecase) GetByIdentifier(ctx context.Context, identifier s
 		org, err = uc.repo.GetByName(ctx, identifier)
 	}
 	if err != nil {
-		return domain.PublicOrganization{}, err
+		return domain.PublicOrganization{}, mapOrganizationError(err)
 	}
 	return parser.ToPublicOrganization(org), nil
 }
 
 // GetUsers retorna a lista de usuários da organização, convertendo para PublicUser
-func (uc *organizationUsecase) GetUsers(ctx context.Context, id uint) ([]domain.PublicUser, error) {
+func (uc *organizationUsecase) GetUsers(c context.Context, id uint) ([]domain.PublicUser, error) {
+	ctx, cancel := withTimeout(c, uc.contextTimeout)
+	defer cancel()
+
 	users, err := uc.repo.GetUsers(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, mapOrganizationError(err)
 	}
 
-	var publicUsers []domain.PublicUser
+	publicUsers := make([]domain.PublicUser, 0, len(users))
 	for _, u := range users {
-		publicUsers = append(publicUsers, domain.PublicUser{
-			ID:               u.ID,
-			Email:            u.Email,
-			FirstName:        "", // se tiver esse campo no domain.User, pode mapear
-			OrganizationID:   u.OrganizationID,
-			OrganizationName: "", // se quiser, busque org e retorne
-			RoleID:           u.RoleID,
-		})
+		publicUsers = append(publicUsers, parser.ToPublicUser(u))
 	}
 	return publicUsers, nil
 }
 
 // GetSubscribedServices retorna os serviços que a org está inscrita
-func (uc *organizationUsecase) GetSubscribedServices(ctx context.Context, id uint) ([]domain.PublicService, error) {
-	return uc.repo.GetSubscribedServices(ctx, id)
+func (uc *organizationUsecase) GetSubscribedServices(c context.Context, id uint) ([]domain.PublicService, error) {
+	ctx, cancel := withTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	services, err := uc.repo.GetSubscribedServices(ctx, id)
+	if err != nil {
+		return nil, mapOrganizationError(err)
+	}
+	return services, nil
 }
 
-// Update atualiza uma organização
-func (uc *organizationUsecase) Update(ctx context.Context, organizationID uint, organization *domain.Organization) error {
-	return uc.repo.Update(ctx, organizationID, organization)
+// Update atualiza nome, apelido e logo de uma organização, apenas os campos enviados são alterados
+func (uc *organizationUsecase) Update(c context.Context, organizationID uint, updateOrganization *domain.UpdateOrganization) (domain.PublicOrganization, error) {
+	ctx, cancel := withTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	org, err := uc.repo.GetByID(ctx, organizationID)
+	if err != nil {
+		return domain.PublicOrganization{}, mapOrganizationError(err)
+	}
+	before := parser.ToPublicOrganization(org)
+
+	if updateOrganization.Name != nil && *updateOrganization.Name != org.Name {
+		if _, err := uc.repo.GetByName(ctx, *updateOrganization.Name); err == nil {
+			return domain.PublicOrganization{}, domain.ErrOrganizationExists
+		}
+		org.Name = *updateOrganization.Name
+	}
+	if updateOrganization.Nickname != nil {
+		org.Nickname = *updateOrganization.Nickname
+	}
+	if updateOrganization.LogoUrl != nil {
+		org.LogoUrl = *updateOrganization.LogoUrl
+	}
+
+	if err := uc.repo.Update(ctx, organizationID, &org); err != nil {
+		return domain.PublicOrganization{}, mapOrganizationError(err)
+	}
+	uc.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionOrganizationUpdate,
+		EntityType: domain.UserLogEntityOrganization,
+		EntityID:   auditID(organizationID),
+		Before:     before,
+		After:      parser.ToPublicOrganization(org),
+	})
+	return parser.ToPublicOrganization(org), nil
 }
 
 // Delete remove a organização
-func (uc *organizationUsecase) Delete(ctx context.Context, organizationID uint) error {
-	return uc.repo.Delete(ctx, organizationID)
+func (uc *organizationUsecase) Delete(c context.Context, organizationID uint) error {
+	ctx, cancel := withTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	before, err := uc.repo.GetByID(ctx, organizationID)
+	if err != nil {
+		return mapOrganizationError(err)
+	}
+
+	if err := uc.repo.Delete(ctx, organizationID); err != nil {
+		return mapOrganizationError(err)
+	}
+	uc.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionOrganizationDelete,
+		EntityType: domain.UserLogEntityOrganization,
+		EntityID:   auditID(organizationID),
+		Before:     parser.ToPublicOrganization(before),
+	})
+	return nil
+}
+
+// mapOrganizationError mantém os erros de domínio conhecidos e esconde os demais
+func mapOrganizationError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return domain.ErrNotFound
+	case errors.Is(err, domain.ErrOutOfOrganization):
+		return domain.ErrOutOfOrganization
+	case errors.Is(err, domain.ErrDataBaseInternalError):
+		return domain.ErrDataBaseInternalError
+	default:
+		return domain.ErrInternalServerError
+	}
 }
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+type presenceUsecase struct {
+	userServiceLogRepository domain.UserServiceLogRepository
+	organizationRepository   domain.OrganizationRepository
+	presenceBroker           domain.PresenceBroker
+	contextTimeout           time.Duration
+}
+
+// NewPresenceUsecase cria um novo caso de uso para a presença em tempo real nos serviços
+func NewPresenceUsecase(userServiceLogRepository domain.UserServiceLogRepository, organizationRepository domain.OrganizationRepository, presenceBroker domain.PresenceBroker, timeout time.Duration) domain.PresenceUsecase {
+	return &presenceUsecase{
+		userServiceLogRepository: userServiceLogRepository,
+		organizationRepository:   organizationRepository,
+		presenceBroker:           presenceBroker,
+		contextTimeout:           timeout,
+	}
+}
+
+// GetByOrganizationID retorna quem está usando cada serviço da organização neste momento
+func (pu *presenceUsecase) GetByOrganizationID(c context.Context, organizationID uint) (domain.PresenceSnapshot, error) {
+	ctx, cancel := withTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	if _, err := pu.organizationRepository.GetByID(ctx, organizationID); err != nil {
+		return domain.PresenceSnapshot{}, mapOrganizationError(err)
+	}
+
+	snapshot, err := buildPresenceSnapshot(ctx, pu.userServiceLogRepository, organizationID)
+	if err != nil {
+		return domain.PresenceSnapshot{}, mapOrganizationError(err)
+	}
+	return snapshot, nil
+}
+
+// Subscribe assina as atualizações de presença da organização, o acesso deve ser verificado antes (GetByOrganizationID)
+func (pu *presenceUsecase) Subscribe(organizationID uint) (<-chan domain.PresenceSnapshot, func()) {
+	return pu.presenceBroker.Subscribe(organizationID)
+}
+
+// buildPresenceSnapshot agrupa as sessões ativas da organização por serviço
+func buildPresenceSnapshot(ctx context.Context, uslr domain.UserServiceLogRepository, organizationID uint) (domain.PresenceSnapshot, error) {
+	sessions, err := uslr.FetchActiveByOrganization(ctx, organizationID)
+	if err != nil {
+		return domain.PresenceSnapshot{}, err
+	}
+
+	snapshot := domain.PresenceSnapshot{
+		OrganizationID: organizationID,
+		Services:       []domain.ServicePresence{},
+		UpdatedAt:      time.Now(),
+	}
+	for _, session := range sessions {
+		last := len(snapshot.Services) - 1
+		if last < 0 || snapshot.Services[last].ServiceID != session.ServiceID {
+			snapshot.Services = append(snapshot.Services, domain.ServicePresence{
+				ServiceID:   session.ServiceID,
+				ServiceName: session.ServiceName,
+			})
+			last++
+		}
+		snapshot.Services[last].ActiveUsers++
+		snapshot.Services[last].Users = append(snapshot.Services[last].Users, domain.PresenceUser{
+			UserID:          session.UserID,
+			Email:           session.UserEmail,
+			LogID:           session.LogID,
+			Since:           session.StartedAt,
+			LastHeartbeatAt: session.LastHeartbeatAt,
+		})
+	}
+	return snapshot, nil
+}
+
+// publishPresence publica a presença atual da organização. A sessão já foi salva, então uma falha aqui
+// apenas atrasa a atualização dos assinantes até a próxima mudança
+// usage: publishPresence(ctx, su.userServiceLogRepository, su.presenceBroker, user.OrganizationID)
+func publishPresence(ctx context.Context, uslr domain.UserServiceLogRepository, broker domain.PresenceBroker, organizationID uint) {
+	snapshot, err := buildPresenceSnapshot(ctx, uslr, organizationID)
+	if err != nil {
+		slog.ErrorContext(ctx, "presence publish failed", "organization", organizationID, "error", err)
+		return
+	}
+	broker.Publish(snapshot)
+}
@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+	"github.com/gabrielfmcoelho/platform-core/internal/tokenutil"
+)
+
+// last_used_at is only written when the stored value is older than this, so busy integrations don't cause a write per request
+const serviceAccountKeyTouchInterval = time.Minute
+
+type serviceAccountUsecase struct {
+	serviceAccountRepository domain.ServiceAccountRepository
+	contextTimeout           time.Duration
+}
+
+// NewServiceAccountUsecase cria um novo caso de uso para ServiceAccount
+func NewServiceAccountUsecase(serviceAccountRepository domain.ServiceAccountRepository, timeout time.Duration) domain.ServiceAccountUsecase {
+	return &serviceAccountUsecase{
+		serviceAccountRepository: serviceAccountRepository,
+		contextTimeout:           timeout,
+	}
+}
+
+// Create cria uma nova conta de serviço ativa e sem chaves, na organização de quem a cria (a não ser que seja um
+// platform admin) e apenas com escopos que ele possui
+func (sau *serviceAccountUsecase) Create(ctx context.Context, create *domain.CreateServiceAccount) (domain.PublicServiceAccount, error) {
+	ctx, cancel := withTimeout(ctx, sau.contextTimeout)
+	defer cancel()
+
+	if err := checkGrantableScopes(ctx, create.Scopes); err != nil {
+		return domain.PublicServiceAccount{}, err
+	}
+
+	serviceAccount := domain.ServiceAccount{
+		Name:           create.Name,
+		Description:    create.Description,
+		OrganizationID: create.OrganizationID,
+		Scopes:         internal.JoinDelimitedStrings(create.Scopes),
+		Active:         true,
+	}
+
+	if err := sau.serviceAccountRepository.Create(ctx, &serviceAccount); err != nil {
+		if errors.Is(err, domain.ErrOutOfOrganization) {
+			return domain.PublicServiceAccount{}, domain.ErrOutOfOrganization
+		}
+		if errors.Is(err, domain.ErrDataBaseInternalError) {
+			return domain.PublicServiceAccount{}, domain.ErrDataBaseInternalError
+		}
+		return domain.PublicServiceAccount{}, domain.ErrInternalServerError
+	}
+
+	return parser.ToPublicServiceAccount(serviceAccount), nil
+}
+
+// Fetch retorna todas as contas de serviço
+func (sau *serviceAccountUsecase) Fetch(ctx context.Context) ([]domain.PublicServiceAccount, error) {
+	ctx, cancel := withTimeout(ctx, sau.contextTimeout)
+	defer cancel()
+
+	serviceAccounts, err := sau.serviceAccountRepository.Fetch(ctx)
+	if err != nil {
+		if errors.Is(err, domain.ErrDataBaseInternalError) {
+			return nil, domain.ErrDataBaseInternalError
+		}
+		return nil, domain.ErrInternalServerError
+	}
+
+	publicServiceAccounts := make([]domain.PublicServiceAccount, 0, len(serviceAccounts))
+	for _, sa := range serviceAccounts {
+		publicServiceAccounts = append(publicServiceAccounts, parser.ToPublicServiceAccount(sa))
+	}
+	return publicServiceAccounts, nil
+}
+
+// GetByID retorna uma conta de serviço
+func (sau *serviceAccountUsecase) GetByID(ctx context.Context, id uint) (domain.PublicServiceAccount, error) {
+	ctx, cancel := withTimeout(ctx, sau.contextTimeout)
+	defer cancel()
+
+	serviceAccount, err := sau.serviceAccountRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.PublicServiceAccount{}, domain.ErrNotFound
+		}
+		return domain.PublicServiceAccount{}, domain.ErrInternalServerError
+	}
+
+	return parser.ToPublicServiceAccount(serviceAccount), nil
+}
+
+// Update altera descrição, escopos e status da conta de serviço, apenas os campos enviados são modificados
+func (sau *serviceAccountUsecase) Update(ctx context.Context, serviceAccountID uint, update *domain.UpdateServiceAccount) (domain.PublicServiceAccount, error) {
+	ctx, cancel := withTimeout(ctx, sau.contextTimeout)
+	defer cancel()
+
+	serviceAccount, err := sau.serviceAccountRepository.GetByID(ctx, serviceAccountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.PublicServiceAccount{}, domain.ErrNotFound
+		}
+		return domain.PublicServiceAccount{}, domain.ErrInternalServerError
+	}
+
+	if update.Description != nil {
+		serviceAccount.Description = *update.Description
+	}
+	if update.Scopes != nil {
+		if err := checkGrantableScopes(ctx, update.Scopes); err != nil {
+			return domain.PublicServiceAccount{}, err
+		}
+		serviceAccount.Scopes = internal.JoinDelimitedStrings(update.Scopes)
+	}
+	if update.Active != nil {
+		serviceAccount.Active = *update.Active
+	}
+
+	if err := sau.serviceAccountRepository.Update(ctx, serviceAccountID, &serviceAccount); err != nil {
+		if errors.Is(err, domain.ErrDataBaseInternalError) {
+			return domain.PublicServiceAccount{}, domain.ErrDataBaseInternalError
+		}
+		return domain.PublicServiceAccount{}, domain.ErrInternalServerError
+	}
+
+	return parser.ToPublicServiceAccount(serviceAccount), nil
+}
+
+// Delete remove a conta de serviço e todas as suas chaves
+func (sau *serviceAccountUsecase) Delete(ctx context.Context, serviceAccountID uint) error {
+	ctx, cancel := withTimeout(ctx, sau.contextTimeout)
+	defer cancel()
+
+	if err := sau.serviceAccountRepository.Delete(ctx, serviceAccountID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		if errors.Is(err, domain.ErrDataBaseInternalError) {
+			return domain.ErrDataBaseInternalError
+		}
+		return domain.ErrInternalServerError
+	}
+	return nil
+}
+
+// IssueKey gera uma nova chave de API. A chave completa só é devolvida nesta chamada,
+// no banco ficam apenas o prefixo e o hash do segredo
+func (sau *serviceAccountUsecase) IssueKey(ctx context.Context, serviceAccountID uint, expiresInDays int) (domain.IssuedServiceAccountKey, error) {
+	ctx, cancel := withTimeout(ctx, sau.contextTimeout)
+	defer cancel()
+
+	var issued domain.IssuedServiceAccountKey
+
+	if _, err := sau.serviceAccountRepository.GetByID(ctx, serviceAccountID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return issued, domain.ErrNotFound
+		}
+		return issued, domain.ErrInternalServerError
+	}
+
+	prefix, secret, err := tokenutil.GenerateAPIKey()
+	if err != nil {
+		return issued, domain.ErrInternalServerError
+	}
+
+	key := domain.ServiceAccountKey{
+		ServiceAccountID: serviceAccountID,
+		Prefix:           prefix,
+		SecretHash:       tokenutil.HashOpaqueToken(secret),
+	}
+	if expiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, expiresInDays)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := sau.serviceAccountRepository.CreateKey(ctx, &key); err != nil {
+		if errors.Is(err, domain.ErrDataBaseInternalError) {
+			return issued, domain.ErrDataBaseInternalError
+		}
+		return issued, domain.ErrInternalServerError
+	}
+
+	return domain.IssuedServiceAccountKey{
+		ID:        key.ID,
+		Key:       prefix + "." + secret,
+		Prefix:    prefix,
+		ExpiresAt: key.ExpiresAt,
+	}, nil
+}
+
+// RevokeKey revoga uma chave de API da conta de serviço
+func (sau *serviceAccountUsecase) RevokeKey(ctx context.Context, serviceAccountID uint, keyID uint) error {
+	ctx, cancel := withTimeout(ctx, sau.contextTimeout)
+	defer cancel()
+
+	if err := sau.serviceAccountRepository.RevokeKey(ctx, serviceAccountID, keyID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return domain.ErrInternalServerError
+	}
+	return nil
+}
+
+// checkGrantableScopes impede que alguém conceda a uma conta de serviço permissões que não possui. Chamadas
+// internas, sem principal, e platform admins não são restritas
+func checkGrantableScopes(ctx context.Context, scopes []string) error {
+	principal, hasPrincipal := domain.PrincipalFromContext(ctx)
+	if !hasPrincipal || principal.IsPlatformAdmin() {
+		return nil
+	}
+	for _, scope := range scopes {
+		if !principal.HasPermission(scope) {
+			return domain.ErrForbidden
+		}
+	}
+	return nil
+}
+
+// Authenticate valida uma chave de API recebida no header Authorization e retorna a conta de serviço dona dela
+func (sau *serviceAccountUsecase) Authenticate(ctx context.Context, rawKey string) (domain.ServiceAccount, error) {
+	ctx, cancel := withTimeout(ctx, sau.contextTimeout)
+	defer cancel()
+
+	var serviceAccount domain.ServiceAccount
+
+	prefix, secret, ok := tokenutil.SplitAPIKey(rawKey)
+	if !ok {
+		return serviceAccount, domain.ErrInvalidAPIKey
+	}
+
+	key, err := sau.serviceAccountRepository.GetKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return serviceAccount, domain.ErrInvalidAPIKey
+		}
+		return serviceAccount, domain.ErrInternalServerError
+	}
+
+	secretHash := tokenutil.HashOpaqueToken(secret)
+	if subtle.ConstantTimeCompare([]byte(secretHash), []byte(key.SecretHash)) != 1 {
+		return serviceAccount, domain.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && now.After(*key.ExpiresAt)) {
+		return serviceAccount, domain.ErrInvalidAPIKey
+	}
+
+	serviceAccount, err = sau.serviceAccountRepository.GetByID(ctx, key.ServiceAccountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return serviceAccount, domain.ErrInvalidAPIKey
+		}
+		return serviceAccount, domain.ErrInternalServerError
+	}
+	if !serviceAccount.Active {
+		return serviceAccount, domain.ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > serviceAccountKeyTouchInterval {
+		if err := sau.serviceAccountRepository.TouchKey(ctx, key.ID, now); err != nil {
+			return serviceAccount, domain.ErrInternalServerError
+		}
+	}
+
+	return serviceAccount, nil
+}
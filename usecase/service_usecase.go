@@ -13,21 +13,31 @@ import (
 type serviceUsecase struct {
 	serviceRepository        domain.ServiceRepository
 	userServiceLogRepository domain.UserServiceLogRepository
+	userRepository           domain.UserRepository
+	subscriptionRepository   domain.OrganizationSubscriptionRepository
+	presenceBroker           domain.PresenceBroker
+	auditUsecase             domain.AuditUsecase
+	metrics                  domain.RuntimeMetrics
 	contextTimeout           time.Duration
 }
 
 // NewServiceUsecase cria um novo caso de uso para Service
-func NewServiceUsecase(serviceRepository domain.ServiceRepository, userServiceLogRepository domain.UserServiceLogRepository, timeout time.Duration) domain.ServiceUsecase {
+func NewServiceUsecase(serviceRepository domain.ServiceRepository, userServiceLogRepository domain.UserServiceLogRepository, userRepository domain.UserRepository, subscriptionRepository domain.OrganizationSubscriptionRepository, presenceBroker domain.PresenceBroker, auditUsecase domain.AuditUsecase, metrics domain.RuntimeMetrics, timeout time.Duration) domain.ServiceUsecase {
 	return &serviceUsecase{
 		serviceRepository:        serviceRepository,
 		userServiceLogRepository: userServiceLogRepository,
+		userRepository:           userRepository,
+		subscriptionRepository:   subscriptionRepository,
+		presenceBroker:           presenceBroker,
+		auditUsecase:             auditUsecase,
+		metrics:                  metrics,
 		contextTimeout:           timeout,
 	}
 }
 
 // Create cria um novo service
 func (su *serviceUsecase) Create(ctx context.Context, service *domain.Service) error {
-	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
 	defer cancel()
 
 	err := su.serviceRepository.Create(ctx, service)
@@ -38,12 +48,18 @@ func (su *serviceUsecase) Create(ctx context.Context, service *domain.Service) e
 		return domain.ErrInternalServerError
 	}
 
+	su.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionServiceCreate,
+		EntityType: domain.UserLogEntityService,
+		EntityID:   auditID(service.ID),
+		After:      parser.ToHubService(*service),
+	})
 	return nil
 }
 
 // Fetch retorna todos os serviços, convertidos em PublicService
 func (su *serviceUsecase) Fetch(ctx context.Context) ([]domain.PublicService, error) {
-	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
 	defer cancel()
 
 	services, err := su.serviceRepository.Fetch(ctx)
@@ -63,7 +79,7 @@ func (su *serviceUsecase) Fetch(ctx context.Context) ([]domain.PublicService, er
 
 // GetByIdentifier obtém um serviço por ID (se o identifier for numérico) ou por nome (caso contrário)
 func (su *serviceUsecase) GetByIdentifier(ctx context.Context, identifier string) (domain.PublicService, error) {
-	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
 	defer cancel()
 
 	var service domain.Service
@@ -93,7 +109,7 @@ func (su *serviceUsecase) GetByIdentifier(ctx context.Context, identifier string
 
 // GetByOrganization retorna todos os serviços vinculados a uma organização
 func (su *serviceUsecase) GetByOrganization(ctx context.Context, organizationID uint) ([]domain.HubService, error) {
-	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
 	defer cancel()
 
 	services, err := su.serviceRepository.GetByOrganization(ctx, organizationID)
@@ -116,7 +132,7 @@ func (su *serviceUsecase) GetByOrganization(ctx context.Context, organizationID
 
 // GetMarketing retorna todos os serviços de marketing
 func (su *serviceUsecase) GetMarketing(ctx context.Context) ([]domain.MarketingService, error) {
-	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
 	defer cancel()
 
 	services, err := su.serviceRepository.GetMarketing(ctx)
@@ -139,7 +155,7 @@ func (su *serviceUsecase) GetMarketing(ctx context.Context) ([]domain.MarketingS
 
 // SetAvailabilityToOrganization vincula o service a uma organização
 func (su *serviceUsecase) SetAvailabilityToOrganization(ctx context.Context, serviceID uint, organizationID uint) error {
-	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
 	defer cancel()
 
 	err := su.serviceRepository.SetAvailabilityToOrganization(ctx, serviceID, organizationID)
@@ -153,31 +169,61 @@ func (su *serviceUsecase) SetAvailabilityToOrganization(ctx context.Context, ser
 		return domain.ErrInternalServerError
 	}
 
+	su.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionServiceLink,
+		EntityType: domain.UserLogEntityService,
+		EntityID:   auditID(serviceID),
+		After:      map[string]uint{"organization_id": organizationID},
+	})
 	return nil
 }
 
+// RemoveAvailabilityFromOrganization desvincula o service de uma organização
+func (su *serviceUsecase) RemoveAvailabilityFromOrganization(ctx context.Context, serviceID uint, organizationID uint) error {
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
+	defer cancel()
+
+	err := su.serviceRepository.RemoveAvailabilityFromOrganization(ctx, serviceID, organizationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrDataBaseInternalError) {
+			return domain.ErrDataBaseInternalError
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return domain.ErrInternalServerError
+	}
+
+	su.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionServiceUnlink,
+		EntityType: domain.UserLogEntityService,
+		EntityID:   auditID(serviceID),
+		Before:     map[string]uint{"organization_id": organizationID},
+	})
+	return nil
+}
+
+// Use inicia uma sessão de uso do serviço. Serviços não vinculados à organização do usuário são tratados como
+// inexistentes
 func (su *serviceUsecase) Use(ctx context.Context, userID uint, serviceID uint) (domain.UseService, uint, error) {
-	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
 	defer cancel()
 
 	var service domain.Service
 	var useService domain.UseService
 	var logID uint
 
-	log := domain.UserServiceLog{
-		UserID:    userID,
-		ServiceID: serviceID,
-	}
-
-	err := su.userServiceLogRepository.Create(ctx, &log)
+	// organizações com assinatura inativa ou expirada não podem usar os serviços
+	user, err := su.userRepository.GetByID(ctx, userID)
 	if err != nil {
-		if errors.Is(err, domain.ErrDataBaseInternalError) {
-			return useService, logID, domain.ErrDataBaseInternalError
+		if errors.Is(err, domain.ErrNotFound) {
+			return useService, logID, domain.ErrNotFound
 		}
 		return useService, logID, domain.ErrInternalServerError
 	}
-
-	logID = log.ID
+	if err := checkSubscriptionActive(ctx, su.subscriptionRepository, user.OrganizationID); err != nil {
+		return useService, logID, err
+	}
 
 	service, err = su.serviceRepository.GetByID(ctx, serviceID)
 	if err != nil {
@@ -186,34 +232,140 @@ func (su *serviceUsecase) Use(ctx context.Context, userID uint, serviceID uint)
 		}
 		return useService, logID, domain.ErrInternalServerError
 	}
+	available, err := su.serviceRepository.IsAvailableToOrganization(ctx, serviceID, user.OrganizationID)
+	if err != nil {
+		return useService, logID, domain.ErrInternalServerError
+	}
+	if !available {
+		return useService, logID, domain.ErrNotFound
+	}
+
+	// a sessão começa ativa, o primeiro heartbeat conta a partir do início
+	log := domain.UserServiceLog{
+		UserID:          userID,
+		ServiceID:       serviceID,
+		Status:          domain.UsageSessionActive,
+		LastHeartbeatAt: time.Now(),
+	}
+
+	err = su.userServiceLogRepository.Create(ctx, &log)
+	if err != nil {
+		if errors.Is(err, domain.ErrDataBaseInternalError) {
+			return useService, logID, domain.ErrDataBaseInternalError
+		}
+		return useService, logID, domain.ErrInternalServerError
+	}
+
+	logID = log.ID
+	su.metrics.UsageSessionStarted(serviceID)
+	publishPresence(ctx, su.userServiceLogRepository, su.presenceBroker, user.OrganizationID)
 
 	return parser.ToUseService(service), logID, nil
 }
 
-func (su *serviceUsecase) Heartbeat(ctx context.Context, logID uint, duration int) error {
-	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+// Heartbeat mantém a sessão de uso ativa e soma o tempo desde o último heartbeat, limitado a maxGap
+func (su *serviceUsecase) Heartbeat(ctx context.Context, userID uint, logID uint, maxGap time.Duration) (domain.PublicUserServiceLog, error) {
+	return su.advanceSession(ctx, userID, logID, maxGap, false)
+}
+
+// EndUsage encerra a sessão de uso, somando o tempo desde o último heartbeat como em Heartbeat
+func (su *serviceUsecase) EndUsage(ctx context.Context, userID uint, logID uint, maxGap time.Duration) (domain.PublicUserServiceLog, error) {
+	return su.advanceSession(ctx, userID, logID, maxGap, true)
+}
+
+// advanceSession soma à duração o tempo desde o último heartbeat. Apenas segundos inteiros são contados e o
+// heartbeat avança o mesmo tanto, então a fração restante entra no próximo heartbeat. Um intervalo maior que
+// maxGap (o cliente ficou sem enviar heartbeats) conta apenas maxGap
+func (su *serviceUsecase) advanceSession(ctx context.Context, userID uint, logID uint, maxGap time.Duration, end bool) (domain.PublicUserServiceLog, error) {
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
 	defer cancel()
 
-	err := su.userServiceLogRepository.UpdateDuration(ctx, logID, duration)
+	if maxGap <= 0 {
+		maxGap = domain.DefaultUsageHeartbeatMaxGap
+	}
+
+	log, err := su.userServiceLogRepository.GetByID(ctx, logID)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			return domain.ErrNotFound
-		}
-		if errors.Is(err, domain.ErrDataBaseInternalError) {
-			return domain.ErrDataBaseInternalError
+		return domain.PublicUserServiceLog{}, mapUsageSessionError(err)
+	}
+	if log.UserID != userID {
+		return domain.PublicUserServiceLog{}, domain.ErrUsageSessionOwner
+	}
+	if log.Status != domain.UsageSessionActive {
+		return domain.PublicUserServiceLog{}, domain.ErrUsageSessionClosed
+	}
+
+	now := time.Now()
+	gap := now.Sub(log.LastHeartbeatAt)
+	switch {
+	case gap > maxGap:
+		log.Duration += int(maxGap / time.Second)
+		log.LastHeartbeatAt = now
+	case gap > 0:
+		seconds := gap / time.Second
+		log.Duration += int(seconds)
+		log.LastHeartbeatAt = log.LastHeartbeatAt.Add(seconds * time.Second)
+	}
+
+	if end {
+		log.Status = domain.UsageSessionEnded
+		log.EndedAt = &now
+	}
+
+	if err := su.userServiceLogRepository.UpdateSession(ctx, &log); err != nil {
+		return domain.PublicUserServiceLog{}, mapUsageSessionError(err)
+	}
+	su.metrics.UsageHeartbeat(log.ServiceID)
+
+	// heartbeats não mudam quem está usando os serviços, apenas o encerramento é publicado
+	if end {
+		if user, err := su.userRepository.GetByID(ctx, userID); err == nil {
+			publishPresence(ctx, su.userServiceLogRepository, su.presenceBroker, user.OrganizationID)
 		}
-		return domain.ErrInternalServerError
 	}
+	return parser.ToPublicUserServiceLog(log), nil
+}
 
-	return nil
+// CloseIdleSessions expira as sessões ativas sem heartbeat há mais de idle, é executado pelo job de sessões de uso
+func (su *serviceUsecase) CloseIdleSessions(ctx context.Context, idle time.Duration) (int64, error) {
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
+	defer cancel()
+
+	if idle <= 0 {
+		idle = domain.DefaultUsageSessionIdle
+	}
+
+	closed, organizationIDs, err := su.userServiceLogRepository.CloseIdle(ctx, time.Now().Add(-idle))
+	if err != nil {
+		return 0, mapUsageSessionError(err)
+	}
+	for _, organizationID := range organizationIDs {
+		publishPresence(ctx, su.userServiceLogRepository, su.presenceBroker, organizationID)
+	}
+	return closed, nil
+}
+
+// mapUsageSessionError mantém os erros de domínio conhecidos das sessões de uso e esconde os demais
+func mapUsageSessionError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUsageSessionClosed):
+		return domain.ErrUsageSessionClosed
+	default:
+		return mapOrganizationError(err)
+	}
 }
 
 // Update atualiza os dados de um serviço
 func (su *serviceUsecase) Update(ctx context.Context, serviceID uint, service *domain.Service) error {
-	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
 	defer cancel()
 
-	err := su.serviceRepository.Update(ctx, serviceID, service)
+	before, err := su.serviceRepository.GetByID(ctx, serviceID)
+	if err != nil {
+		return mapOrganizationError(err)
+	}
+
+	err = su.serviceRepository.Update(ctx, serviceID, service)
 	if err != nil {
 		if errors.Is(err, domain.ErrDataBaseInternalError) {
 			return domain.ErrDataBaseInternalError
@@ -221,15 +373,31 @@ func (su *serviceUsecase) Update(ctx context.Context, serviceID uint, service *d
 		return domain.ErrInternalServerError
 	}
 
+	after, err := su.serviceRepository.GetByID(ctx, serviceID)
+	if err != nil {
+		return mapOrganizationError(err)
+	}
+	su.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionServiceUpdate,
+		EntityType: domain.UserLogEntityService,
+		EntityID:   auditID(serviceID),
+		Before:     parser.ToHubService(before),
+		After:      parser.ToHubService(after),
+	})
 	return nil
 }
 
 // Delete remove um serviço do banco
 func (su *serviceUsecase) Delete(ctx context.Context, serviceID uint) error {
-	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+	ctx, cancel := withTimeout(ctx, su.contextTimeout)
 	defer cancel()
 
-	err := su.serviceRepository.Delete(ctx, serviceID)
+	before, err := su.serviceRepository.GetByID(ctx, serviceID)
+	if err != nil {
+		return mapOrganizationError(err)
+	}
+
+	err = su.serviceRepository.Delete(ctx, serviceID)
 	if err != nil {
 		if errors.Is(err, domain.ErrDataBaseInternalError) {
 			return domain.ErrDataBaseInternalError
@@ -237,5 +405,11 @@ func (su *serviceUsecase) Delete(ctx context.Context, serviceID uint) error {
 		return domain.ErrInternalServerError
 	}
 
+	su.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionServiceDelete,
+		EntityType: domain.UserLogEntityService,
+		EntityID:   auditID(serviceID),
+		Before:     parser.ToHubService(before),
+	})
 	return nil
 }
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/migrate"
+	"github.com/gabrielfmcoelho/platform-core/internal/password"
+	"github.com/gabrielfmcoelho/platform-core/migrations"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testTimeout = 5 * time.Second
+
+// newTestDB abre um sqlite novo no diretório temporário do teste com todas as migrations aplicadas
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.Exec("PRAGMA foreign_keys = ON;").Error; err != nil {
+		t.Fatalf("enable foreign keys: %v", err)
+	}
+	migrator, err := migrate.New(db, migrations.Files)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	return db
+}
+
+// seedOrganization cria uma organização com um OrganizationRole próprio
+func seedOrganization(t *testing.T, db *gorm.DB, name string) domain.Organization {
+	t.Helper()
+	role := domain.OrganizationRole{RoleName: name}
+	if err := db.Create(&role).Error; err != nil {
+		t.Fatalf("create organization role: %v", err)
+	}
+	organization := domain.Organization{Name: name, RoleID: role.ID}
+	if err := db.Create(&organization).Error; err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	return organization
+}
+
+// seedUserRole cria um UserRole com as permissões informadas, as que ainda não existem são criadas
+func seedUserRole(t *testing.T, db *gorm.DB, name string, permissions ...string) domain.UserRole {
+	t.Helper()
+	role := domain.UserRole{RoleName: name}
+	for _, permissionName := range permissions {
+		permission := domain.Permission{Name: permissionName}
+		if err := db.Where("name = ?", permissionName).FirstOrCreate(&permission).Error; err != nil {
+			t.Fatalf("create permission: %v", err)
+		}
+		role.Permissions = append(role.Permissions, permission)
+	}
+	if err := db.Create(&role).Error; err != nil {
+		t.Fatalf("create user role: %v", err)
+	}
+	return role
+}
+
+// seedUser cria um usuário com a senha gravada com hash
+func seedUser(t *testing.T, db *gorm.DB, email string, rawPassword string, organizationID uint, roleID uint) domain.User {
+	t.Helper()
+	hashedPassword, err := password.HashPassword(rawPassword)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	user := domain.User{Email: email, Password: hashedPassword, OrganizationID: organizationID, RoleID: roleID}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user
+}
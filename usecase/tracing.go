@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/internal/tracing"
+)
+
+// withTimeout aplica o timeout do caso de uso e inicia o span do método que a chama ("serviceUsecase.Use"),
+// filho do span da requisição. cancel encerra os dois
+func withTimeout(c context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, span := tracing.Tracer().Start(c, tracing.CallerName(1))
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		span.End()
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+)
+
+type usageAnalyticsUsecase struct {
+	usageAnalyticsRepository domain.UsageAnalyticsRepository
+	contextTimeout           time.Duration
+}
+
+// NewUsageAnalyticsUsecase cria um novo caso de uso para as análises de uso dos serviços
+func NewUsageAnalyticsUsecase(usageAnalyticsRepository domain.UsageAnalyticsRepository, timeout time.Duration) domain.UsageAnalyticsUsecase {
+	return &usageAnalyticsUsecase{
+		usageAnalyticsRepository: usageAnalyticsRepository,
+		contextTimeout:           timeout,
+	}
+}
+
+// Usage agrega o uso pelos grupos e pelo intervalo de tempo pedidos
+func (au *usageAnalyticsUsecase) Usage(c context.Context, filter domain.UsageAnalyticsFilter) (domain.UsageAnalytics, error) {
+	return au.aggregate(c, domain.UsageAggregation{UsageAnalyticsFilter: filter})
+}
+
+// TopServices ordena os serviços pelo tempo de uso, limit vale DefaultTopServicesLimit quando não informado
+func (au *usageAnalyticsUsecase) TopServices(c context.Context, filter domain.UsageAnalyticsFilter, limit int) (domain.UsageAnalytics, error) {
+	if limit == 0 {
+		limit = domain.DefaultTopServicesLimit
+	}
+	if limit < 0 || limit > domain.MaxTopServicesLimit {
+		return domain.UsageAnalytics{}, domain.ErrInvalidAnalyticsQuery
+	}
+
+	filter.Bucket = ""
+	filter.GroupBy = []string{domain.UsageGroupService}
+	return au.aggregate(c, domain.UsageAggregation{UsageAnalyticsFilter: filter, OrderByDuration: true, Limit: limit})
+}
+
+// ActiveUsers conta os usuários distintos por intervalo, diário quando bucket não é informado
+func (au *usageAnalyticsUsecase) ActiveUsers(c context.Context, filter domain.UsageAnalyticsFilter) (domain.UsageAnalytics, error) {
+	if filter.Bucket == "" {
+		filter.Bucket = domain.UsageBucketDay
+	}
+	filter.GroupBy = nil
+	return au.aggregate(c, domain.UsageAggregation{UsageAnalyticsFilter: filter})
+}
+
+// aggregate valida o filtro, aplica o período padrão (os últimos DefaultUsageAnalyticsDays dias) e consulta o repositório
+func (au *usageAnalyticsUsecase) aggregate(c context.Context, aggregation domain.UsageAggregation) (domain.UsageAnalytics, error) {
+	ctx, cancel := withTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	switch aggregation.Bucket {
+	case "", domain.UsageBucketDay, domain.UsageBucketWeek, domain.UsageBucketMonth:
+	default:
+		return domain.UsageAnalytics{}, domain.ErrInvalidAnalyticsQuery
+	}
+	seen := make(map[string]bool, len(aggregation.GroupBy))
+	for _, group := range aggregation.GroupBy {
+		switch group {
+		case domain.UsageGroupService, domain.UsageGroupUser, domain.UsageGroupOrganization:
+		default:
+			return domain.UsageAnalytics{}, domain.ErrInvalidAnalyticsQuery
+		}
+		if seen[group] {
+			return domain.UsageAnalytics{}, domain.ErrInvalidAnalyticsQuery
+		}
+		seen[group] = true
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if aggregation.To.IsZero() {
+		aggregation.To = today.AddDate(0, 0, 1)
+	}
+	if aggregation.From.IsZero() {
+		aggregation.From = aggregation.To.AddDate(0, 0, -domain.DefaultUsageAnalyticsDays)
+	}
+	if !aggregation.From.Before(aggregation.To) {
+		return domain.UsageAnalytics{}, domain.ErrInvalidAnalyticsQuery
+	}
+
+	rows, err := au.usageAnalyticsRepository.Aggregate(ctx, aggregation)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAnalyticsQuery) {
+			return domain.UsageAnalytics{}, domain.ErrInvalidAnalyticsQuery
+		}
+		return domain.UsageAnalytics{}, mapOrganizationError(err)
+	}
+
+	groupBy := aggregation.GroupBy
+	if groupBy == nil {
+		groupBy = []string{}
+	}
+	return domain.UsageAnalytics{
+		From:    aggregation.From.Format("2006-01-02"),
+		To:      aggregation.To.AddDate(0, 0, -1).Format("2006-01-02"),
+		Bucket:  aggregation.Bucket,
+		GroupBy: groupBy,
+		Rows:    rows,
+	}, nil
+}
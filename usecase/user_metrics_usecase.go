@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/internal/parser"
+)
+
+type userMetricsUsecase struct {
+	userMetricsRepository domain.UserMetricsRepository
+	userRepository        domain.UserRepository
+	contextTimeout        time.Duration
+}
+
+// NewUserMetricsUsecase cria um novo caso de uso para as métricas de usuário
+func NewUserMetricsUsecase(userMetricsRepository domain.UserMetricsRepository, userRepository domain.UserRepository, timeout time.Duration) domain.UserMetricsUsecase {
+	return &userMetricsUsecase{
+		userMetricsRepository: userMetricsRepository,
+		userRepository:        userRepository,
+		contextTimeout:        timeout,
+	}
+}
+
+// GetByUserID retorna as métricas consolidadas de um usuário. Um usuário que ainda não passou pelo job
+// de métricas recebe as métricas zeradas
+func (mu *userMetricsUsecase) GetByUserID(c context.Context, userID uint) (domain.PublicUserMetrics, error) {
+	ctx, cancel := withTimeout(c, mu.contextTimeout)
+	defer cancel()
+
+	if _, err := mu.userRepository.GetByID(ctx, userID); err != nil {
+		return domain.PublicUserMetrics{}, mapOrganizationError(err)
+	}
+
+	metrics, err := mu.userMetricsRepository.GetByUserID(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.PublicUserMetrics{UserID: userID}, nil
+	}
+	if err != nil {
+		return domain.PublicUserMetrics{}, mapOrganizationError(err)
+	}
+	return parser.ToPublicUserMetrics(metrics), nil
+}
+
+// Recompute consolida as métricas de todos os usuários a partir dos logs de login e de uso dos serviços
+func (mu *userMetricsUsecase) Recompute(c context.Context) (int, error) {
+	ctx, cancel := withTimeout(c, mu.contextTimeout)
+	defer cancel()
+
+	updated, err := mu.userMetricsRepository.Recompute(ctx)
+	if err != nil {
+		return 0, mapOrganizationError(err)
+	}
+	return updated, nil
+}
@@ -27,7 +27,7 @@ func NewUserServiceLogUsecase(
 
 // Fetch all UserServiceLog entries
 func (u *userServiceLogUsecase) Fetch(ctx context.Context) ([]domain.PublicUserServiceLog, error) {
-	c, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	c, cancel := withTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
 	logs, err := u.userServiceLogRepo.Fetch(c)
@@ -52,7 +52,7 @@ func (u *userServiceLogUsecase) Fetch(ctx context.Context) ([]domain.PublicUserS
 // - or if it starts with "service:" -> parse the rest as serviceID
 // Otherwise returns ErrInvalidIdentifier
 func (u *userServiceLogUsecase) GetByIdentifier(ctx context.Context, identifier string) (domain.PublicUserServiceLog, error) {
-	c, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	c, cancel := withTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
 	var publicLog domain.PublicUserServiceLog
@@ -106,7 +106,7 @@ func (u *userServiceLogUsecase) GetByIdentifier(ctx context.Context, identifier
 
 // Delete removes a UserServiceLog by ID
 func (u *userServiceLogUsecase) Delete(ctx context.Context, userServiceLogID uint) error {
-	c, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	c, cancel := withTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
 	if err := u.userServiceLogRepo.Delete(c, userServiceLogID); err != nil {
@@ -12,26 +12,43 @@ import (
 )
 
 type UserUsecase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository                     domain.UserRepository
+	organizationSubscriptionRepository domain.OrganizationSubscriptionRepository
+	userRoleRepository                 domain.UserRoleRepository
+	auditUsecase                       domain.AuditUsecase
+	contextTimeout                     time.Duration
 }
 
-func NewUserUsecase(userRepository domain.UserRepository, timeout time.Duration) *UserUsecase {
+func NewUserUsecase(userRepository domain.UserRepository, organizationSubscriptionRepository domain.OrganizationSubscriptionRepository, userRoleRepository domain.UserRoleRepository, auditUsecase domain.AuditUsecase, timeout time.Duration) *UserUsecase {
 	return &UserUsecase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:                     userRepository,
+		organizationSubscriptionRepository: organizationSubscriptionRepository,
+		userRoleRepository:                 userRoleRepository,
+		auditUsecase:                       auditUsecase,
+		contextTimeout:                     timeout,
 	}
 }
 
 func (uu *UserUsecase) Create(c context.Context, createUser *domain.CreateUser) error {
-	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
+	ctx, cancel := withTimeout(c, uu.contextTimeout)
 	defer cancel()
 
-	_, err := uu.userRepository.GetByEmail(ctx, createUser.Email)
-	if err == nil {
+	exists, err := uu.userRepository.ExistsByEmail(ctx, createUser.Email)
+	if err != nil {
+		return domain.ErrInternalServerError
+	}
+	if exists {
 		return domain.ErrUserAlreadyExists
 	}
 
+	if err := uu.checkGrantableRole(ctx, createUser.RoleID); err != nil {
+		return err
+	}
+
+	if err := checkUsersLimit(ctx, uu.organizationSubscriptionRepository, uu.userRepository, createUser.OrganizationID); err != nil {
+		return err
+	}
+
 	hashedPassword, err := password.HashPassword(createUser.Password)
 	if err != nil {
 		return err
@@ -42,17 +59,26 @@ func (uu *UserUsecase) Create(c context.Context, createUser *domain.CreateUser)
 
 	err = uu.userRepository.Create(ctx, user)
 	if err != nil {
+		if errors.Is(err, domain.ErrOutOfOrganization) {
+			return domain.ErrOutOfOrganization
+		}
 		if errors.Is(err, domain.ErrDataBaseInternalError) {
 			return domain.ErrDataBaseInternalError
 		}
 		return domain.ErrInternalServerError
 	}
 
+	uu.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionUserCreate,
+		EntityType: domain.UserLogEntityUser,
+		EntityID:   auditID(user.ID),
+		After:      auditUser(*user),
+	})
 	return nil
 }
 
 func (uu *UserUsecase) Fetch(c context.Context) ([]domain.PublicUser, error) {
-	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
+	ctx, cancel := withTimeout(c, uu.contextTimeout)
 	defer cancel()
 
 	users, err := uu.userRepository.Fetch(ctx)
@@ -75,7 +101,7 @@ func (uu *UserUsecase) Fetch(c context.Context) ([]domain.PublicUser, error) {
 func (uu *UserUsecase) GetByIdentifier(c context.Context, identifier string) (domain.PublicUser, error) {
 	// if the identifier is an email, get the user by email
 	// if the identifier is an ID, get the user by ID
-	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
+	ctx, cancel := withTimeout(c, uu.contextTimeout)
 	defer cancel()
 
 	var user domain.User
@@ -93,7 +119,7 @@ func (uu *UserUsecase) GetByIdentifier(c context.Context, identifier string) (do
 			user, err = uu.userRepository.GetByEmail(ctx, identifier)
 		}
 		if err != nil {
-			if errors.Is(err, domain.ErrNotFound) {
+			if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrUserEmailNotFound) {
 				return publicUser, domain.ErrNotFound
 			}
 			return publicUser, domain.ErrInternalServerError
@@ -104,32 +130,128 @@ func (uu *UserUsecase) GetByIdentifier(c context.Context, identifier string) (do
 	return parser.ToPublicUser(user), nil
 }
 
-func (uu *UserUsecase) Update(c context.Context, userID uint, user *domain.User) error {
-	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
+// Update altera apenas os campos enviados, a nova senha é gravada com hash. Só altera usuários cujo UserRole
+// atual poderia ser atribuído por quem faz a requisição, assim ninguém troca a senha de alguém com mais permissões
+func (uu *UserUsecase) Update(c context.Context, userID uint, update *domain.UpdateUser) error {
+	ctx, cancel := withTimeout(c, uu.contextTimeout)
 	defer cancel()
 
-	err := uu.userRepository.Update(ctx, userID, user)
+	before, err := uu.userRepository.GetByID(ctx, userID)
 	if err != nil {
+		return mapOrganizationError(err)
+	}
+	if err := uu.checkGrantableRole(ctx, before.RoleID); err != nil {
+		return err
+	}
+
+	user := &domain.User{}
+	if update.Email != nil {
+		user.Email = *update.Email
+	}
+	if update.Password != nil {
+		if user.Password, err = password.HashPassword(*update.Password); err != nil {
+			return domain.ErrInternalServerError
+		}
+	}
+	if update.OrganizationID != nil {
+		user.OrganizationID = *update.OrganizationID
+	}
+	if update.RoleID != nil {
+		if err := uu.checkGrantableRole(ctx, *update.RoleID); err != nil {
+			return err
+		}
+		user.RoleID = *update.RoleID
+	}
+
+	err = uu.userRepository.Update(ctx, userID, user)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		if errors.Is(err, domain.ErrOutOfOrganization) {
+			return domain.ErrOutOfOrganization
+		}
 		if errors.Is(err, domain.ErrDataBaseInternalError) {
 			return domain.ErrDataBaseInternalError
 		}
 		return domain.ErrInternalServerError
 	}
 
+	after, err := uu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return mapOrganizationError(err)
+	}
+	uu.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionUserUpdate,
+		EntityType: domain.UserLogEntityUser,
+		EntityID:   auditID(userID),
+		Before:     auditUser(before),
+		After:      auditUser(after),
+	})
 	return nil
 }
 
+// checkGrantableRole impede que alguém atribua um UserRole com permissões que não possui. Chamadas internas,
+// sem principal, e platform admins não são restritas
+func (uu *UserUsecase) checkGrantableRole(ctx context.Context, roleID uint) error {
+	role, err := uu.userRoleRepository.GetByID(ctx, roleID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidUserRole
+		}
+		return domain.ErrInternalServerError
+	}
+
+	principal, hasPrincipal := domain.PrincipalFromContext(ctx)
+	if hasPrincipal && !principal.IsPlatformAdmin() {
+		for _, permission := range role.Permissions {
+			if !principal.HasPermission(permission.Name) {
+				return domain.ErrForbidden
+			}
+		}
+	}
+	return nil
+}
+
+// Archive arquiva o usuário, com a mesma restrição de UserRole de Update
 func (uu *UserUsecase) Archive(c context.Context, userID uint) error {
-	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
+	ctx, cancel := withTimeout(c, uu.contextTimeout)
 	defer cancel()
 
-	err := uu.userRepository.Archive(ctx, userID)
+	before, err := uu.userRepository.GetByID(ctx, userID)
 	if err != nil {
+		return mapOrganizationError(err)
+	}
+	if err := uu.checkGrantableRole(ctx, before.RoleID); err != nil {
+		return err
+	}
+
+	err = uu.userRepository.Archive(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrNotFound
+		}
 		if errors.Is(err, domain.ErrDataBaseInternalError) {
 			return domain.ErrDataBaseInternalError
 		}
 		return domain.ErrInternalServerError
 	}
 
+	uu.auditUsecase.Record(ctx, domain.AuditEntry{
+		Action:     domain.UserLogActionUserArchive,
+		EntityType: domain.UserLogEntityUser,
+		EntityID:   auditID(userID),
+		Before:     auditUser(before),
+	})
 	return nil
 }
+
+// auditUser é o retrato do usuário gravado na auditoria, a senha entra apenas para registrar que mudou
+func auditUser(user domain.User) map[string]interface{} {
+	return map[string]interface{}{
+		"email":           user.Email,
+		"organization_id": user.OrganizationID,
+		"role_id":         user.RoleID,
+		"password":        user.Password,
+	}
+}
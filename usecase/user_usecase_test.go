@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gabrielfmcoelho/platform-core/domain"
+	"github.com/gabrielfmcoelho/platform-core/repository"
+	"gorm.io/gorm"
+)
+
+func newTestUserUsecase(db *gorm.DB) *UserUsecase {
+	return NewUserUsecase(
+		repository.NewUserRepository(db),
+		repository.NewOrganizationSubscriptionRepository(db),
+		repository.NewUserRoleRepository(db),
+		NewAuditUsecase(repository.NewUserLogRepository(db), testTimeout),
+		testTimeout,
+	)
+}
+
+func TestCheckGrantableRole(t *testing.T) {
+	db := newTestDB(t)
+	organization := seedOrganization(t, db, "Org")
+	admin := seedUserRole(t, db, "Admin", domain.PermUserRead, domain.PermUserWrite, domain.PermUserArchive, domain.PermRoleManage)
+	basic := seedUserRole(t, db, "Basic", domain.PermUserRead)
+	uu := newTestUserUsecase(db)
+
+	manager := domain.Principal{UserID: 1, OrganizationID: organization.ID, Permissions: []string{domain.PermUserRead, domain.PermUserWrite}}
+	platformAdmin := domain.Principal{UserID: 1, OrganizationID: organization.ID, Permissions: []string{domain.PermPlatformAdmin}}
+
+	tests := []struct {
+		name      string
+		principal *domain.Principal
+		roleID    uint
+		wantErr   error
+	}{
+		{name: "role with permissions the caller has", principal: &manager, roleID: basic.ID},
+		{name: "role with permissions the caller lacks", principal: &manager, roleID: admin.ID, wantErr: domain.ErrForbidden},
+		{name: "platform admin", principal: &platformAdmin, roleID: admin.ID},
+		{name: "internal call without principal", roleID: admin.ID},
+		{name: "unknown role", principal: &manager, roleID: 9999, wantErr: domain.ErrInvalidUserRole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = domain.WithPrincipal(ctx, *tt.principal)
+			}
+			if err := uu.checkGrantableRole(ctx, tt.roleID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkGrantableRole error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateAndArchiveTargetRole(t *testing.T) {
+	newPassword := "new-password"
+	tests := []struct {
+		name       string
+		targetRole string
+		call       func(ctx context.Context, uu *UserUsecase, userID uint) error
+		wantErr    error
+	}{
+		{
+			name:       "update user with a grantable role",
+			targetRole: "Basic",
+			call: func(ctx context.Context, uu *UserUsecase, userID uint) error {
+				return uu.Update(ctx, userID, &domain.UpdateUser{Password: &newPassword})
+			},
+		},
+		{
+			name:       "update user with more permissions",
+			targetRole: "Admin",
+			call: func(ctx context.Context, uu *UserUsecase, userID uint) error {
+				return uu.Update(ctx, userID, &domain.UpdateUser{Password: &newPassword})
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:       "archive user with a grantable role",
+			targetRole: "Basic",
+			call: func(ctx context.Context, uu *UserUsecase, userID uint) error {
+				return uu.Archive(ctx, userID)
+			},
+		},
+		{
+			name:       "archive user with more permissions",
+			targetRole: "Admin",
+			call: func(ctx context.Context, uu *UserUsecase, userID uint) error {
+				return uu.Archive(ctx, userID)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			organization := seedOrganization(t, db, "Org")
+			roles := map[string]domain.UserRole{
+				"Admin": seedUserRole(t, db, "Admin", domain.PermUserRead, domain.PermUserWrite, domain.PermUserArchive, domain.PermRoleManage),
+				"Basic": seedUserRole(t, db, "Basic", domain.PermUserRead),
+			}
+			target := seedUser(t, db, "target@org.test", testPassword, organization.ID, roles[tt.targetRole].ID)
+			uu := newTestUserUsecase(db)
+
+			ctx := domain.WithPrincipal(context.Background(), domain.Principal{
+				UserID:         target.ID + 1,
+				OrganizationID: organization.ID,
+				Permissions:    []string{domain.PermUserRead, domain.PermUserWrite, domain.PermUserArchive},
+			})
+			if err := tt.call(ctx, uu, target.ID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+
+			var stored domain.User
+			if err := db.Unscoped().First(&stored, target.ID).Error; err != nil {
+				t.Fatalf("load target: %v", err)
+			}
+			if tt.wantErr != nil && (stored.Password != target.Password || stored.DeletedAt.Valid) {
+				t.Errorf("target was changed by a forbidden call")
+			}
+		})
+	}
+}
+
+func TestCrossOrganizationAccess(t *testing.T) {
+	db := newTestDB(t)
+	home := seedOrganization(t, db, "Home")
+	other := seedOrganization(t, db, "Other")
+	basic := seedUserRole(t, db, "Basic", domain.PermUserRead)
+	member := seedUser(t, db, "member@home.test", testPassword, home.ID, basic.ID)
+	stranger := seedUser(t, db, "stranger@other.test", testPassword, other.ID, basic.ID)
+	uu := newTestUserUsecase(db)
+
+	manager := domain.Principal{UserID: member.ID, OrganizationID: home.ID, Permissions: []string{domain.PermUserRead, domain.PermUserWrite}}
+	platformAdmin := domain.Principal{UserID: member.ID, OrganizationID: home.ID, Permissions: []string{domain.PermPlatformAdmin}}
+	newPassword := "new-password"
+
+	getByID := func(userID uint) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := uu.GetByIdentifier(ctx, auditID(userID))
+			return err
+		}
+	}
+	getByEmail := func(email string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := uu.GetByIdentifier(ctx, email)
+			return err
+		}
+	}
+	update := func(userID uint, changes domain.UpdateUser) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			return uu.Update(ctx, userID, &changes)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		principal domain.Principal
+		call      func(ctx context.Context) error
+		wantErr   error
+	}{
+		{name: "get user of the same organization by id", principal: manager, call: getByID(member.ID)},
+		{name: "get user of another organization by id", principal: manager, call: getByID(stranger.ID), wantErr: domain.ErrNotFound},
+		{name: "get user of another organization by email", principal: manager, call: getByEmail(stranger.Email), wantErr: domain.ErrNotFound},
+		{name: "platform admin gets user of another organization", principal: platformAdmin, call: getByID(stranger.ID)},
+		{name: "update user of the same organization", principal: manager, call: update(member.ID, domain.UpdateUser{Password: &newPassword})},
+		{name: "update user of another organization", principal: manager, call: update(stranger.ID, domain.UpdateUser{Password: &newPassword}), wantErr: domain.ErrNotFound},
+		{name: "move user to another organization", principal: manager, call: update(member.ID, domain.UpdateUser{OrganizationID: &other.ID}), wantErr: domain.ErrOutOfOrganization},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := domain.WithPrincipal(context.Background(), tt.principal)
+			if err := tt.call(ctx); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	var stored domain.User
+	if err := db.First(&stored, stranger.ID).Error; err != nil {
+		t.Fatalf("load user of another organization: %v", err)
+	}
+	if stored.Password != stranger.Password {
+		t.Errorf("user of another organization had the password changed")
+	}
+}